@@ -2,13 +2,21 @@ package app
 
 import (
 	"context"
+	"errors"
+	"exchanger/internal/cache"
 	"exchanger/internal/config"
+	"exchanger/internal/domain/event"
 	"exchanger/internal/handler"
+	"exchanger/internal/provider/currency"
+	"exchanger/internal/provider/epay"
+	"exchanger/internal/publisher"
 	"exchanger/internal/repository"
 	"exchanger/internal/service/auth"
+	"exchanger/internal/service/exchange"
 	"exchanger/internal/service/hiring"
 	"exchanger/pkg/log"
 	"exchanger/pkg/server"
+	"expvar"
 	"flag"
 	"fmt"
 	"go.uber.org/zap"
@@ -27,48 +35,127 @@ func Run() {
 		return
 	}
 
-	//currencyClient := currency.New(currency.Credentials{
-	//	URL: configs.CURRENCY.URL,
-	//})
+	currencyClient, err := newCurrencyClient(configs.CURRENCY)
+	if err != nil {
+		logger.Error("ERR_INIT_CURRENCY_CLIENT", zap.Error(err))
+		return
+	}
+
+	store, err := newStore(configs.STORAGE)
+	if err != nil {
+		logger.Error("ERR_INIT_STORAGE", zap.Error(err))
+		return
+	}
 
-	repositories, err := repository.New(
-		repository.WithMemoryStore())
+	repositories, err := repository.New(store)
 	if err != nil {
-		logger.Error("ERR_INIT_REPOSITORIES", zap.Error(err))
+		logger.Error("ERR_INIT_REPOSITORIES", zap.String("driver", configs.STORAGE.Driver), zap.Error(err))
+		repositories.Close()
 		return
 	}
 	defer repositories.Close()
 
-	authService, err := auth.New()
+	caches, err := newCache(configs.CACHE, repositories)
+	if err != nil {
+		logger.Error("ERR_INIT_CACHE", zap.String("driver", configs.CACHE.Driver), zap.Error(err))
+		return
+	}
+	defer caches.Close()
+	expvar.Publish("cache", expvar.Func(func() any {
+		return caches.Stats()
+	}))
+
+	authService, err := auth.New(
+		auth.WithUserRepository(repositories.User),
+		auth.WithClientRepository(repositories.Client),
+		auth.WithTokenRepository(repositories.Token),
+		auth.WithAdmins(configs.TOKEN.Admins...),
+		auth.WithTokenSalt(configs.TOKEN.Salt))
 	if err != nil {
 		logger.Error("ERR_INIT_AUTH_SERVICE", zap.Error(err))
 		return
 	}
 
+	if err = authService.SeedAdmins(context.Background()); err != nil {
+		logger.Error("ERR_SEED_ADMINS", zap.Error(err))
+		return
+	}
+
+	exchangeService, err := exchange.New(
+		exchange.WithRateRepository(repositories.Rate),
+		exchange.WithCurrencyClient(currencyClient))
+	if err != nil {
+		logger.Error("ERR_INIT_EXCHANGE_SERVICE", zap.Error(err))
+		return
+	}
+
+	epayClient, err := newEpayClient(configs.EPAY)
+	if err != nil {
+		logger.Error("ERR_INIT_EPAY_CLIENT", zap.Error(err))
+		return
+	}
+
+	eventPublisher, err := newPublisher(configs.EVENTS)
+	if err != nil {
+		logger.Error("ERR_INIT_PUBLISHER", zap.String("driver", configs.EVENTS.Driver), zap.Error(err))
+		return
+	}
+
+	// The events are written to the outbox only while there's a publisher to relay them
+	var outbox event.Repository
+	if eventPublisher != nil {
+		outbox = repositories.Outbox
+	}
+
 	hiringService, err := hiring.New(
 		hiring.WithCustomerRepository(repositories.Customer),
 		hiring.WithHireRepository(repositories.Hire),
-		hiring.WithWorkerRepository(repositories.Worker))
+		hiring.WithHireHistoryRepository(repositories.HireHistory),
+		hiring.WithWorkerRepository(repositories.Worker),
+		hiring.WithProposalRepository(repositories.Proposal),
+		hiring.WithPaymentRepository(repositories.Payment),
+		hiring.WithPaymentCallbackRepository(repositories.PaymentCallback),
+		hiring.WithPaymentHistoryRepository(repositories.PaymentHistory),
+		hiring.WithEpayClient(epayClient),
+		hiring.WithPaymentTTL(configs.EPAY.PaymentTTL),
+		hiring.WithExchangeService(exchangeService),
+		hiring.WithCustomerCache(caches.Customer),
+		hiring.WithWorkerCache(caches.Worker),
+		hiring.WithHireCache(caches.Hire),
+		hiring.WithUnitOfWork(repositories.UnitOfWork),
+		hiring.WithAuditRepository(repositories.Audit),
+		hiring.WithOutboxRepository(outbox))
 	if err != nil {
 		logger.Error("ERR_INIT_HIRING_SERVICE", zap.Error(err))
 		return
 	}
 
+	// The gRPC server starts next to the HTTP one only while its port is configured
+	handlerConfigs := []handler.Configuration{handler.WithHTTPHandler()}
+	if configs.APP.GRPCPort != "" {
+		handlerConfigs = append(handlerConfigs, handler.WithGRPCHandler(configs.APP.GRPCReflection))
+	}
+
 	handlers, err := handler.New(
 		handler.Dependencies{
-			Configs:       configs,
-			AuthService:   authService,
-			HiringService: hiringService,
-		}, handler.WithHTTPHandler())
+			Configs:         configs,
+			AuthService:     authService,
+			HiringService:   hiringService,
+			ExchangeService: exchangeService,
+		}, handlerConfigs...)
 	if err != nil {
 		logger.Error("ERR_INIT_HANDLERS", zap.Error(err))
 		return
 	}
 
-	servers, err := server.New(
-		server.WithHTTPServer(handlers.HTTP, configs.APP.Port))
+	serverConfigs := []server.Configuration{server.WithHTTPServer(handlers.HTTP, configs.APP.Port)}
+	if handlers.GRPC != nil {
+		serverConfigs = append(serverConfigs, server.WithGRPCServer(handlers.GRPC, configs.APP.GRPCPort))
+	}
+
+	servers, err := server.New(serverConfigs...)
 	if err != nil {
-		logger.Error("ERR_INIT_SERVERS")
+		logger.Error("ERR_INIT_SERVERS", zap.Error(err))
 		return
 	}
 
@@ -78,6 +165,38 @@ func Run() {
 		return
 	}
 	logger.Info("http server started on http://localhost:" + configs.APP.Port + "/swagger/index.html")
+	if handlers.GRPC != nil {
+		logger.Info("grpc server started on localhost:" + configs.APP.GRPCPort)
+	}
+
+	// The rates are refreshed only while the feed is configured
+	var refresher *currency.Refresher
+	if currencyClient != nil {
+		refresher = currencyClient.NewRefresher(configs.CURRENCY.RefreshInterval, configs.CURRENCY.Currencies...)
+		refresher.Start(context.Background())
+	}
+
+	// The provider token is refreshed and the payments are reconciled only while the provider is configured
+	var reconciler *hiring.PaymentReconciler
+	if epayClient != nil {
+		epayClient.Start(context.Background())
+
+		reconciler = hiringService.NewPaymentReconciler(configs.EPAY.ReconcileInterval, configs.EPAY.ReconcileMaxBackoff)
+		reconciler.Start(context.Background())
+	}
+
+	// Deleted entities are purged only while the interval is configured
+	var purger *hiring.Purger
+	if configs.STORAGE.PurgeInterval > 0 {
+		purger = hiringService.NewPurger(configs.STORAGE.PurgeInterval, configs.STORAGE.PurgeRetention)
+		purger.Start(context.Background())
+	}
+
+	var relay *hiring.OutboxRelay
+	if eventPublisher != nil {
+		relay = hiringService.NewOutboxRelay(eventPublisher, configs.EVENTS.RelayInterval, configs.EVENTS.RelayBatch)
+		relay.Start(context.Background())
+	}
 
 	// Graceful Shutdown
 	var wait time.Duration
@@ -104,6 +223,152 @@ func Run() {
 
 	fmt.Println("running cleanup tasks...")
 	// Your cleanup tasks go here
+	if reconciler != nil {
+		if err = reconciler.Stop(ctx); err != nil {
+			logger.Error("ERR_STOP_RECONCILER", zap.Error(err))
+		}
+	}
+
+	if epayClient != nil {
+		if err = epayClient.Stop(ctx); err != nil {
+			logger.Error("ERR_STOP_EPAY", zap.Error(err))
+		}
+	}
+
+	if purger != nil {
+		if err = purger.Stop(ctx); err != nil {
+			logger.Error("ERR_STOP_PURGER", zap.Error(err))
+		}
+	}
+
+	if relay != nil {
+		if err = relay.Stop(ctx); err != nil {
+			logger.Error("ERR_STOP_RELAY", zap.Error(err))
+		}
+	}
+
+	if refresher != nil {
+		if err = refresher.Stop(ctx); err != nil {
+			logger.Error("ERR_STOP_REFRESHER", zap.Error(err))
+		}
+	}
 
 	fmt.Println("server was successful shutdown.")
 }
+
+// newStore builds the repository configuration of the storage driver chosen in configs
+func newStore(cfg config.StorageConfig) (store repository.Configuration, err error) {
+	switch cfg.Driver {
+	case "memory":
+		store = repository.WithMemoryStore()
+	case "postgres":
+		if cfg.DSN == "" {
+			return nil, errors.New("storage: postgres dsn cannot be blank")
+		}
+		store = repository.WithPostgresStore(cfg.DSN, cfg.MaxOpenConns, cfg.MaxIdleConns)
+	case "mongo":
+		if cfg.DSN == "" {
+			return nil, errors.New("storage: mongo dsn cannot be blank")
+		}
+		store = repository.WithMongoStore(cfg.DSN, cfg.Name, cfg.MaxOpenConns, cfg.MaxIdleConns)
+	default:
+		return nil, fmt.Errorf("storage: unknown driver %q", cfg.Driver)
+	}
+
+	return
+}
+
+// newCache builds the caches of the driver chosen in configs reading through to the repositories
+func newCache(cfg config.CacheConfig, repositories *repository.Repository) (caches *cache.Cache, err error) {
+	switch cfg.Driver {
+	case "":
+		return cache.New()
+	case "memory":
+		return cache.New(cache.WithMemoryStore(repositories, cfg.TTL))
+	case "redis":
+		if cfg.URL == "" {
+			return nil, errors.New("cache: redis url cannot be blank")
+		}
+		return cache.New(cache.WithRedisStore(cfg.URL, repositories, cfg.TTL))
+	default:
+		return nil, fmt.Errorf("cache: unknown driver %q", cfg.Driver)
+	}
+}
+
+// newPublisher builds the publisher of the driver chosen in configs, the events aren't published without any.
+// The events of the channel are received in the process and logged
+func newPublisher(cfg config.EventsConfig) (dest event.Publisher, err error) {
+	switch cfg.Driver {
+	case "":
+		return
+	case "channel":
+		channel := publisher.NewChannel(cfg.RelayBatch)
+		go func() {
+			logger := log.LoggerFromContext(context.Background()).Named("events")
+			for data := range channel.Events() {
+				logger.Info("event published", zap.String("id", data.ID), zap.String("type", data.Type),
+					zap.String("entity_id", data.EntityID))
+			}
+		}()
+		return channel, nil
+	case "webhook":
+		if cfg.URL == "" {
+			return nil, errors.New("events: webhook url cannot be blank")
+		}
+		return publisher.NewWebhook(cfg.URL, cfg.Secret, cfg.Timeout), nil
+	default:
+		return nil, fmt.Errorf("events: unknown driver %q", cfg.Driver)
+	}
+}
+
+// newEpayClient connects to the epay provider, payments stay disabled without its login
+func newEpayClient(cfg config.EpayConfig) (client *epay.Client, err error) {
+	if cfg.Login == "" {
+		return
+	}
+
+	return epay.New(epay.Credentials{
+		URL:             cfg.URL,
+		Login:           cfg.Login,
+		Password:        cfg.Password,
+		OAuthURL:        cfg.OAuthURL,
+		PaymentPageURL:  cfg.PaymentPageURL,
+		TerminalID:      cfg.TerminalID,
+		Currency:        cfg.Currency,
+		BackLink:        cfg.BackLink,
+		FailureBackLink: cfg.FailureBackLink,
+		PostLink:        cfg.PostLink,
+		FailurePostLink: cfg.FailurePostLink,
+	})
+}
+
+// newCurrencyClient connects to the rate sources, the budgets aren't converted without any
+func newCurrencyClient(cfg config.ClientConfig) (client *currency.Client, err error) {
+	sources := cfg.Sources
+	if len(sources) == 0 && cfg.URL != "" {
+		sources = []string{currency.SourceNationalBank}
+	}
+
+	if len(sources) == 0 {
+		return
+	}
+
+	client, err = currency.New(currency.Credentials{
+		URL: cfg.URL,
+	},
+		currency.WithSourceChain(currency.SourceConfig{
+			NationalBankURL: cfg.URL,
+			ECBURL:          cfg.ECBURL,
+			ManualFile:      cfg.ManualFile,
+		}, sources...),
+		currency.WithRetry(cfg.RetryAttempts, cfg.RetryDelay, cfg.RetryMaxDelay),
+		currency.WithCircuitBreaker(cfg.BreakerThreshold, cfg.BreakerTimeout))
+	if err != nil {
+		return
+	}
+	expvar.Publish("currency", expvar.Func(func() any {
+		return client.Metrics()
+	}))
+
+	return
+}
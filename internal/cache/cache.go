@@ -0,0 +1,95 @@
+package cache
+
+import (
+	"exchanger/internal/cache/memory"
+	"exchanger/internal/cache/redis"
+	"exchanger/internal/domain/customer"
+	"exchanger/internal/domain/hire"
+	"exchanger/internal/domain/worker"
+	"exchanger/internal/repository"
+	"exchanger/pkg/market"
+	"fmt"
+	"time"
+)
+
+// Configuration is an alias for a function that will take in a pointer to a Cache and modify it
+type Configuration func(c *Cache) error
+
+// Cache is an implementation of the Cache, the caches stay nil until a store is applied
+type Cache struct {
+	redis market.Redis
+
+	Customer customer.Cache
+	Worker   worker.Cache
+	Hire     hire.Cache
+}
+
+// New takes a variable amount of Configuration functions and returns a new Cache
+// Each Configuration will be called in the order they are passed in
+func New(configs ...Configuration) (c *Cache, err error) {
+	// Create the cache
+	c = &Cache{}
+
+	// Apply all Configurations passed in
+	for _, cfg := range configs {
+		// Pass the cache into the configuration function
+		if err = cfg(c); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// Close closes the connection of the cache store
+func (c *Cache) Close() {
+	if c.redis.Connection != nil {
+		c.redis.Connection.Close()
+	}
+}
+
+// Stats returns the hits and the misses of each cache applied
+func (c *Cache) Stats() (dest map[string]market.CacheStats) {
+	dest = make(map[string]market.CacheStats)
+
+	if c.Customer != nil {
+		dest["customer"] = c.Customer.Stats()
+	}
+
+	if c.Worker != nil {
+		dest["worker"] = c.Worker.Stats()
+	}
+
+	if c.Hire != nil {
+		dest["hire"] = c.Hire.Stats()
+	}
+
+	return
+}
+
+// WithMemoryStore applies a memory store reading through to the repositories to the Cache
+func WithMemoryStore(repositories *repository.Repository, ttl time.Duration) Configuration {
+	return func(c *Cache) (err error) {
+		c.Customer = memory.NewCustomerCache(repositories.Customer, ttl)
+		c.Worker = memory.NewWorkerCache(repositories.Worker, ttl)
+		c.Hire = memory.NewHireCache(repositories.Hire, ttl)
+
+		return
+	}
+}
+
+// WithRedisStore applies a redis store reading through to the repositories to the Cache
+func WithRedisStore(url string, repositories *repository.Repository, ttl time.Duration) Configuration {
+	return func(c *Cache) (err error) {
+		c.redis, err = market.NewRedis(url)
+		if err != nil {
+			return fmt.Errorf("redis store is unreachable: %w", err)
+		}
+
+		c.Customer = redis.NewCustomerCache(c.redis.Connection, repositories.Customer, ttl)
+		c.Worker = redis.NewWorkerCache(c.redis.Connection, repositories.Worker, ttl)
+		c.Hire = redis.NewHireCache(c.redis.Connection, repositories.Hire, ttl)
+
+		return
+	}
+}
@@ -0,0 +1,186 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"exchanger/internal/cache"
+	"exchanger/internal/domain/hire"
+	"exchanger/internal/repository"
+	"exchanger/pkg/market"
+	"os"
+	"testing"
+	"time"
+)
+
+// stores returns the caches the suite runs against over the memory repositories, redis runs only when
+// TEST_REDIS_URL points to a local server
+func stores(t *testing.T, repositories *repository.Repository, ttl time.Duration) map[string]*cache.Cache {
+	configs := map[string]cache.Configuration{
+		"memory": cache.WithMemoryStore(repositories, ttl),
+	}
+
+	if url := os.Getenv("TEST_REDIS_URL"); url != "" {
+		configs["redis"] = cache.WithRedisStore(url, repositories, ttl)
+	}
+
+	caches := make(map[string]*cache.Cache, len(configs))
+	for name, config := range configs {
+		c, err := cache.New(config)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		t.Cleanup(c.Close)
+		caches[name] = c
+	}
+
+	return caches
+}
+
+func newRepositories(t *testing.T) *repository.Repository {
+	t.Helper()
+
+	repositories, err := repository.New(repository.WithMemoryStore())
+	if err != nil {
+		t.Fatalf("repository.New() error = %v", err)
+	}
+
+	return repositories
+}
+
+func addHire(t *testing.T, repositories *repository.Repository, name string) string {
+	t.Helper()
+
+	id, err := repositories.Hire.Add(context.Background(), hire.Entity{JobName: &name, CustomerID: "customer", Status: hire.StatusOpen})
+	if err != nil {
+		t.Fatalf("Hire.Add() error = %v", err)
+	}
+
+	return id
+}
+
+func TestCacheReadThrough(t *testing.T) {
+	ctx := context.Background()
+	repositories := newRepositories(t)
+
+	for name, c := range stores(t, repositories, time.Minute) {
+		t.Run(name, func(t *testing.T) {
+			id := addHire(t, repositories, "first")
+
+			for i := 0; i < 2; i++ {
+				data, err := c.Hire.Get(ctx, id)
+				if err != nil {
+					t.Fatalf("Get() error = %v", err)
+				}
+				if data.ID != id || *data.JobName != "first" {
+					t.Fatalf("Get() = %s %q, want %s %q", data.ID, *data.JobName, id, "first")
+				}
+			}
+
+			if stats := c.Hire.Stats(); stats != (market.CacheStats{Hits: 1, Misses: 1}) {
+				t.Errorf("Stats() = %+v, want one hit and one miss", stats)
+			}
+
+			// the cached hire is served until it's deleted from the cache
+			second := "second"
+			if err := repositories.Hire.Update(ctx, id, hire.Entity{JobName: &second}); err != nil {
+				t.Fatalf("Hire.Update() error = %v", err)
+			}
+
+			data, err := c.Hire.Get(ctx, id)
+			if err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+			if *data.JobName != "first" {
+				t.Errorf("Get() before Delete() = %q, want the cached %q", *data.JobName, "first")
+			}
+
+			if err = c.Hire.Delete(ctx, id); err != nil {
+				t.Fatalf("Delete() error = %v", err)
+			}
+
+			data, err = c.Hire.Get(ctx, id)
+			if err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+			if *data.JobName != second {
+				t.Errorf("Get() after Delete() = %q, want %q", *data.JobName, second)
+			}
+
+			if stats := c.Hire.Stats(); stats != (market.CacheStats{Hits: 2, Misses: 2}) {
+				t.Errorf("Stats() = %+v, want two hits and two misses", stats)
+			}
+		})
+	}
+}
+
+func TestCacheMissing(t *testing.T) {
+	ctx := context.Background()
+	repositories := newRepositories(t)
+
+	for name, c := range stores(t, repositories, time.Minute) {
+		t.Run(name, func(t *testing.T) {
+			// the failed read isn't cached, every read goes to the repository again
+			for i := 0; i < 2; i++ {
+				if _, err := c.Customer.Get(ctx, "missing"); !errors.Is(err, market.ErrorNotFound) {
+					t.Fatalf("Get() error = %v, want %v", err, market.ErrorNotFound)
+				}
+			}
+
+			if stats := c.Customer.Stats(); stats != (market.CacheStats{Misses: 2}) {
+				t.Errorf("Stats() = %+v, want two misses", stats)
+			}
+
+			if err := c.Customer.Delete(ctx, "missing"); err != nil {
+				t.Errorf("Delete() of a missing entry error = %v", err)
+			}
+		})
+	}
+}
+
+func TestCacheExpiry(t *testing.T) {
+	ctx := context.Background()
+	repositories := newRepositories(t)
+
+	for name, c := range stores(t, repositories, 50*time.Millisecond) {
+		t.Run(name, func(t *testing.T) {
+			id := addHire(t, repositories, "first")
+
+			if _, err := c.Hire.Get(ctx, id); err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+
+			time.Sleep(100 * time.Millisecond)
+
+			if _, err := c.Hire.Get(ctx, id); err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+
+			if stats := c.Hire.Stats(); stats != (market.CacheStats{Misses: 2}) {
+				t.Errorf("Stats() = %+v, want the expired entry read again", stats)
+			}
+		})
+	}
+}
+
+func TestCacheStats(t *testing.T) {
+	c, err := cache.New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if stats := c.Stats(); len(stats) != 0 {
+		t.Errorf("Stats() without a store = %v, want none", stats)
+	}
+
+	c, err = cache.New(cache.WithMemoryStore(newRepositories(t), time.Minute))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	stats := c.Stats()
+	for _, name := range []string{"customer", "worker", "hire"} {
+		if _, ok := stats[name]; !ok {
+			t.Errorf("Stats() misses %q", name)
+		}
+	}
+}
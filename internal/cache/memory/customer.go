@@ -0,0 +1,16 @@
+package memory
+
+import (
+	"exchanger/internal/domain/customer"
+	"time"
+)
+
+type CustomerCache struct {
+	*store[customer.Entity]
+}
+
+func NewCustomerCache(repository customer.Repository, ttl time.Duration) *CustomerCache {
+	return &CustomerCache{
+		store: newStore(ttl, repository.Get),
+	}
+}
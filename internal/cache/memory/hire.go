@@ -0,0 +1,16 @@
+package memory
+
+import (
+	"exchanger/internal/domain/hire"
+	"time"
+)
+
+type HireCache struct {
+	*store[hire.Entity]
+}
+
+func NewHireCache(repository hire.Repository, ttl time.Duration) *HireCache {
+	return &HireCache{
+		store: newStore(ttl, repository.Get),
+	}
+}
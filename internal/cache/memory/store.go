@@ -0,0 +1,49 @@
+package memory
+
+import (
+	"context"
+	"exchanger/pkg/market"
+	"github.com/patrickmn/go-cache"
+	"time"
+)
+
+// store keeps the entities in the process memory, the entity missing in it is read by load
+type store[T any] struct {
+	cache   *cache.Cache
+	load    func(ctx context.Context, id string) (T, error)
+	counter market.CacheCounter
+}
+
+func newStore[T any](ttl time.Duration, load func(ctx context.Context, id string) (T, error)) *store[T] {
+	return &store[T]{
+		// expired entries are cleaned up twice as rarely as they expire
+		cache: cache.New(ttl, 2*ttl),
+		load:  load,
+	}
+}
+
+func (s *store[T]) Get(ctx context.Context, id string) (dest T, err error) {
+	if data, found := s.cache.Get(id); found {
+		s.counter.Hit()
+		return data.(T), nil
+	}
+	s.counter.Miss()
+
+	dest, err = s.load(ctx, id)
+	if err != nil {
+		return
+	}
+	s.cache.SetDefault(id, dest)
+
+	return
+}
+
+func (s *store[T]) Delete(ctx context.Context, id string) (err error) {
+	s.cache.Delete(id)
+
+	return
+}
+
+func (s *store[T]) Stats() market.CacheStats {
+	return s.counter.Stats()
+}
@@ -0,0 +1,16 @@
+package memory
+
+import (
+	"exchanger/internal/domain/worker"
+	"time"
+)
+
+type WorkerCache struct {
+	*store[worker.Entity]
+}
+
+func NewWorkerCache(repository worker.Repository, ttl time.Duration) *WorkerCache {
+	return &WorkerCache{
+		store: newStore(ttl, repository.Get),
+	}
+}
@@ -0,0 +1,17 @@
+package redis
+
+import (
+	"exchanger/internal/domain/customer"
+	"github.com/redis/go-redis/v9"
+	"time"
+)
+
+type CustomerCache struct {
+	*store[customer.Entity]
+}
+
+func NewCustomerCache(client *redis.Client, repository customer.Repository, ttl time.Duration) *CustomerCache {
+	return &CustomerCache{
+		store: newStore(client, "customer", ttl, repository.Get),
+	}
+}
@@ -0,0 +1,17 @@
+package redis
+
+import (
+	"exchanger/internal/domain/hire"
+	"github.com/redis/go-redis/v9"
+	"time"
+)
+
+type HireCache struct {
+	*store[hire.Entity]
+}
+
+func NewHireCache(client *redis.Client, repository hire.Repository, ttl time.Duration) *HireCache {
+	return &HireCache{
+		store: newStore(client, "hire", ttl, repository.Get),
+	}
+}
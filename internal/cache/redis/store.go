@@ -0,0 +1,66 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"exchanger/pkg/market"
+	"github.com/redis/go-redis/v9"
+	"time"
+)
+
+// store keeps the entities in redis as json under the prefixed keys, the entity missing in it is read by load.
+// The reads go to load while redis is down, so the cache never fails a read the repository can serve
+type store[T any] struct {
+	client  *redis.Client
+	prefix  string
+	ttl     time.Duration
+	load    func(ctx context.Context, id string) (T, error)
+	counter market.CacheCounter
+}
+
+func newStore[T any](client *redis.Client, prefix string, ttl time.Duration, load func(ctx context.Context, id string) (T, error)) *store[T] {
+	return &store[T]{
+		client: client,
+		prefix: prefix,
+		ttl:    ttl,
+		load:   load,
+	}
+}
+
+func (s *store[T]) Get(ctx context.Context, id string) (dest T, err error) {
+	data, err := s.client.Get(ctx, s.key(id)).Bytes()
+	if err == nil && json.Unmarshal(data, &dest) == nil {
+		s.counter.Hit()
+		return
+	}
+	s.counter.Miss()
+
+	dest, err = s.load(ctx, id)
+	if err != nil {
+		return
+	}
+
+	if data, err := json.Marshal(dest); err == nil {
+		s.client.Set(ctx, s.key(id), data, s.ttl)
+	}
+
+	return
+}
+
+func (s *store[T]) Delete(ctx context.Context, id string) (err error) {
+	err = s.client.Del(ctx, s.key(id)).Err()
+	if errors.Is(err, redis.Nil) {
+		err = nil
+	}
+
+	return
+}
+
+func (s *store[T]) Stats() market.CacheStats {
+	return s.counter.Stats()
+}
+
+func (s *store[T]) key(id string) string {
+	return s.prefix + ":" + id
+}
@@ -0,0 +1,17 @@
+package redis
+
+import (
+	"exchanger/internal/domain/worker"
+	"github.com/redis/go-redis/v9"
+	"time"
+)
+
+type WorkerCache struct {
+	*store[worker.Entity]
+}
+
+func NewWorkerCache(client *redis.Client, repository worker.Repository, ttl time.Duration) *WorkerCache {
+	return &WorkerCache{
+		store: newStore(client, "worker", ttl, repository.Get),
+	}
+}
@@ -9,13 +9,41 @@ import (
 )
 
 const (
-	defaultAppMode    = "dev"
-	defaultAppPort    = "8080"
-	defaultAppPath    = "/"
-	defaultAppTimeout = 60 * time.Second
+	defaultAppMode     = "dev"
+	defaultAppPort     = "8080"
+	defaultAppPath     = "/"
+	defaultAppTimeout  = 60 * time.Second
+	defaultAppGRPCPort = "9090"
 
 	defaultTokenSalt    = "IP03O5Ekg91g5jw=="
 	defaultTokenExpires = 3600 * time.Second
+
+	defaultCurrencyRetryAttempts    = 3
+	defaultCurrencyRetryDelay       = 500 * time.Millisecond
+	defaultCurrencyRetryMaxDelay    = 5 * time.Second
+	defaultCurrencyBreakerThreshold = 5
+	defaultCurrencyBreakerTimeout   = time.Minute
+	defaultCurrencyRefreshInterval  = 4 * time.Minute
+	defaultCurrencyECBURL           = "https://www.ecb.europa.eu/stats/eurofxref"
+
+	defaultEpayCurrency            = "KZT"
+	defaultEpayPaymentTTL          = 24 * time.Hour
+	defaultEpayReconcileInterval   = time.Minute
+	defaultEpayReconcileMaxBackoff = time.Hour
+
+	defaultCacheDriver = "memory"
+	defaultCacheTTL    = 5 * time.Minute
+
+	defaultStorageDriver         = "memory"
+	defaultStorageName           = "exchanger"
+	defaultStorageMaxOpenConns   = 20
+	defaultStorageMaxIdleConns   = 5
+	defaultStoragePurgeInterval  = time.Hour
+	defaultStoragePurgeRetention = 30 * 24 * time.Hour
+
+	defaultEventsTimeout       = 10 * time.Second
+	defaultEventsRelayInterval = time.Second
+	defaultEventsRelayBatch    = 100
 )
 
 type (
@@ -23,25 +51,112 @@ type (
 		APP      AppConfig
 		TOKEN    TokenConfig
 		CURRENCY ClientConfig
+		EPAY     EpayConfig
+		STORAGE  StorageConfig
+		CACHE    CacheConfig
+		EVENTS   EventsConfig
 		POSTGRES ExchangerConfig
+		MONGO    ExchangerConfig
 	}
 
+	// AppConfig holds the ports of the servers, the gRPC server doesn't start while GRPCPort is empty
+	// and serves no reflection unless GRPCReflection is set
 	AppConfig struct {
-		Mode    string `required:"true"`
-		Port    string
-		Path    string
-		Timeout time.Duration
+		Mode           string `required:"true"`
+		Port           string
+		GRPCPort       string `envconfig:"GRPC_PORT"`
+		GRPCReflection bool   `envconfig:"GRPC_REFLECTION"`
+		Path           string
+		Timeout        time.Duration
 	}
 
+	// TokenConfig holds the admin accounts seeded at the start as "username:password" pairs
 	TokenConfig struct {
-		Salt    string
+		Salt    string `envconfig:"KEY"`
 		Expires time.Duration
+		Admins  []string
 	}
 
 	ClientConfig struct {
 		URL      string
 		Login    string
 		Password string
+
+		// Sources are the names of the rate sources in the order of priority, the next source is asked
+		// once the previous one fails. The national bank feed of the URL is the only source when none is given
+		Sources    []string
+		ECBURL     string `envconfig:"ECB_URL"`
+		ManualFile string `split_words:"true"`
+
+		// RetryAttempts is how many times in all a request is made, the delay in between doubles
+		// from RetryDelay up to RetryMaxDelay. The requests stop for BreakerTimeout
+		// once BreakerThreshold of them in a row have failed
+		RetryAttempts    int           `split_words:"true"`
+		RetryDelay       time.Duration `split_words:"true"`
+		RetryMaxDelay    time.Duration `split_words:"true"`
+		BreakerThreshold int           `split_words:"true"`
+		BreakerTimeout   time.Duration `split_words:"true"`
+
+		// RefreshInterval is how often the rates of the Currencies are refreshed in the background
+		RefreshInterval time.Duration `split_words:"true"`
+		Currencies      []string
+	}
+
+	// EpayConfig holds the credentials of the epay payment provider,
+	// payments are disabled while the login is empty
+	EpayConfig struct {
+		URL             string
+		OAuthURL        string `envconfig:"OAUTH_URL"`
+		PaymentPageURL  string `split_words:"true"`
+		Login           string
+		Password        string
+		TerminalID      string `envconfig:"TERMINAL_ID"`
+		Currency        string
+		BackLink        string `split_words:"true"`
+		FailureBackLink string `split_words:"true"`
+		PostLink        string `split_words:"true"`
+		FailurePostLink string `split_words:"true"`
+
+		// PaymentTTL is how long a payment waits for the customer before it expires,
+		// the unsettled payments are checked every ReconcileInterval backing off up to ReconcileMaxBackoff
+		PaymentTTL          time.Duration `envconfig:"PAYMENT_TTL"`
+		ReconcileInterval   time.Duration `split_words:"true"`
+		ReconcileMaxBackoff time.Duration `split_words:"true"`
+	}
+
+	// StorageConfig selects the backend used by the repositories.
+	// Driver is one of "memory", "postgres" or "mongo". When DSN is empty
+	// it falls back to POSTGRES_DSN or MONGO_DSN of the chosen driver.
+	// The deleted customers, workers and hires are purged for good every PurgeInterval
+	// once PurgeRetention has passed, nothing is purged while the interval is zero
+	StorageConfig struct {
+		Driver         string
+		DSN            string
+		Name           string
+		MaxOpenConns   int           `split_words:"true"`
+		MaxIdleConns   int           `split_words:"true"`
+		PurgeInterval  time.Duration `split_words:"true"`
+		PurgeRetention time.Duration `split_words:"true"`
+	}
+
+	// CacheConfig selects the store of the customer, worker and hire caches.
+	// Driver is one of "memory" or "redis", the entities aren't cached when it's empty
+	CacheConfig struct {
+		Driver string
+		URL    string
+		TTL    time.Duration
+	}
+
+	// EventsConfig selects the publisher the relay delivers the events of the outbox through every RelayInterval.
+	// Driver is one of "channel" or "webhook", no event is written while it's empty.
+	// The webhook posts the events to URL signed by Secret
+	EventsConfig struct {
+		Driver        string
+		URL           string
+		Secret        string
+		Timeout       time.Duration
+		RelayInterval time.Duration `split_words:"true"`
+		RelayBatch    int           `split_words:"true"`
 	}
 
 	ExchangerConfig struct {
@@ -57,10 +172,11 @@ func New() (cfg Configs, err error) {
 	godotenv.Load(filepath.Join(root, ".env"))
 
 	cfg.APP = AppConfig{
-		Mode:    defaultAppMode,
-		Port:    defaultAppPort,
-		Path:    defaultAppPath,
-		Timeout: defaultAppTimeout,
+		Mode:     defaultAppMode,
+		Port:     defaultAppPort,
+		GRPCPort: defaultAppGRPCPort,
+		Path:     defaultAppPath,
+		Timeout:  defaultAppTimeout,
 	}
 
 	cfg.TOKEN = TokenConfig{
@@ -68,17 +184,88 @@ func New() (cfg Configs, err error) {
 		Expires: defaultTokenExpires,
 	}
 
+	cfg.CURRENCY = ClientConfig{
+		RetryAttempts:    defaultCurrencyRetryAttempts,
+		RetryDelay:       defaultCurrencyRetryDelay,
+		RetryMaxDelay:    defaultCurrencyRetryMaxDelay,
+		BreakerThreshold: defaultCurrencyBreakerThreshold,
+		BreakerTimeout:   defaultCurrencyBreakerTimeout,
+		RefreshInterval:  defaultCurrencyRefreshInterval,
+		Currencies:       []string{"USD", "EUR", "RUB"},
+		ECBURL:           defaultCurrencyECBURL,
+	}
+
+	cfg.EPAY = EpayConfig{
+		Currency:            defaultEpayCurrency,
+		PaymentTTL:          defaultEpayPaymentTTL,
+		ReconcileInterval:   defaultEpayReconcileInterval,
+		ReconcileMaxBackoff: defaultEpayReconcileMaxBackoff,
+	}
+
+	cfg.STORAGE = StorageConfig{
+		Driver:         defaultStorageDriver,
+		Name:           defaultStorageName,
+		MaxOpenConns:   defaultStorageMaxOpenConns,
+		MaxIdleConns:   defaultStorageMaxIdleConns,
+		PurgeInterval:  defaultStoragePurgeInterval,
+		PurgeRetention: defaultStoragePurgeRetention,
+	}
+
 	if err = envconfig.Process("APP", &cfg.APP); err != nil {
 		return
 	}
 
+	if err = envconfig.Process("TOKEN", &cfg.TOKEN); err != nil {
+		return
+	}
+
 	if err = envconfig.Process("CURRENCY", &cfg.CURRENCY); err != nil {
 		return
 	}
 
+	if err = envconfig.Process("EPAY", &cfg.EPAY); err != nil {
+		return
+	}
+
 	if err = envconfig.Process("POSTGRES", &cfg.POSTGRES); err != nil {
 		return
 	}
 
+	if err = envconfig.Process("MONGO", &cfg.MONGO); err != nil {
+		return
+	}
+
+	if err = envconfig.Process("STORAGE", &cfg.STORAGE); err != nil {
+		return
+	}
+
+	cfg.CACHE = CacheConfig{
+		Driver: defaultCacheDriver,
+		TTL:    defaultCacheTTL,
+	}
+
+	if err = envconfig.Process("CACHE", &cfg.CACHE); err != nil {
+		return
+	}
+
+	cfg.EVENTS = EventsConfig{
+		Timeout:       defaultEventsTimeout,
+		RelayInterval: defaultEventsRelayInterval,
+		RelayBatch:    defaultEventsRelayBatch,
+	}
+
+	if err = envconfig.Process("EVENTS", &cfg.EVENTS); err != nil {
+		return
+	}
+
+	if cfg.STORAGE.DSN == "" {
+		switch cfg.STORAGE.Driver {
+		case "postgres":
+			cfg.STORAGE.DSN = cfg.POSTGRES.DSN
+		case "mongo":
+			cfg.STORAGE.DSN = cfg.MONGO.DSN
+		}
+	}
+
 	return
 }
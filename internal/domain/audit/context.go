@@ -0,0 +1,16 @@
+package audit
+
+import "context"
+
+type requestID struct{}
+
+// ContextWithRequestID adds the id of the request the changes are made in to context
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestID{}, id)
+}
+
+// RequestIDFromContext returns the id of the request from context, blank outside of a request
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestID{}).(string)
+	return id
+}
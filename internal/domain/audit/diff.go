@@ -0,0 +1,82 @@
+package audit
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// Change is the value of the field before and after the change, nil when the field is missing
+type Change struct {
+	Before any `json:"before" bson:"before"`
+	After  any `json:"after" bson:"after"`
+}
+
+// Diff is the changes of the fields by their JSON names, it's kept as JSON in postgres
+type Diff map[string]Change
+
+// NewDiff compares the JSON of the states field by field, the nil state has no fields
+func NewDiff(before, after any) (dest Diff, err error) {
+	from, err := fields(before)
+	if err != nil {
+		return
+	}
+
+	to, err := fields(after)
+	if err != nil {
+		return
+	}
+
+	dest = make(Diff)
+	for name, value := range from {
+		if changed, ok := to[name]; !ok || !reflect.DeepEqual(value, changed) {
+			dest[name] = Change{Before: value, After: changed}
+		}
+	}
+
+	for name, value := range to {
+		if _, ok := from[name]; !ok {
+			dest[name] = Change{After: value}
+		}
+	}
+
+	return
+}
+
+func fields(state any) (dest map[string]any, err error) {
+	if state == nil {
+		return
+	}
+
+	body, err := json.Marshal(state)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(body, &dest)
+
+	return
+}
+
+func (d Diff) Value() (driver.Value, error) {
+	body, err := json.Marshal(d)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(body), nil
+}
+
+func (d *Diff) Scan(src any) error {
+	switch value := src.(type) {
+	case nil:
+		*d = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(value, d)
+	case string:
+		return json.Unmarshal([]byte(value), d)
+	default:
+		return fmt.Errorf("audit: cannot scan %T into the diff", src)
+	}
+}
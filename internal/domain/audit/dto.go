@@ -0,0 +1,38 @@
+package audit
+
+import "time"
+
+type Response struct {
+	ID        string    `json:"id"`
+	Entity    string    `json:"entity"`
+	EntityID  string    `json:"entityId"`
+	Action    string    `json:"action"`
+	ActorID   string    `json:"actorId"`
+	ActorRole string    `json:"actorRole"`
+	RequestID string    `json:"requestId"`
+	Diff      Diff      `json:"diff"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func ParseFromEntity(data Entity) (res Response) {
+	res = Response{
+		ID:        data.ID,
+		Entity:    data.Entity,
+		EntityID:  data.EntityID,
+		Action:    data.Action,
+		ActorID:   data.ActorID,
+		ActorRole: data.ActorRole,
+		RequestID: data.RequestID,
+		Diff:      data.Diff,
+		CreatedAt: data.CreatedAt,
+	}
+	return
+}
+
+func ParseFromEntities(data []Entity) (res []Response) {
+	res = make([]Response, 0)
+	for _, object := range data {
+		res = append(res, ParseFromEntity(object))
+	}
+	return
+}
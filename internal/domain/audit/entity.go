@@ -0,0 +1,42 @@
+package audit
+
+import "time"
+
+// The entities of the hiring service the changes are recorded for
+const (
+	EntityCustomer = "customer"
+	EntityWorker   = "worker"
+	EntityHire     = "hire"
+)
+
+// The actions the entity is changed by
+const (
+	ActionAdd     = "add"
+	ActionUpdate  = "update"
+	ActionDelete  = "delete"
+	ActionRestore = "restore"
+	ActionPurge   = "purge"
+)
+
+// Entity records a change of the entity made by the actor in the request,
+// the changes made in the background have neither of them
+type Entity struct {
+	ID        string    `db:"id" bson:"_id"`
+	Entity    string    `db:"entity" bson:"entity"`
+	EntityID  string    `db:"entity_id" bson:"entity_id"`
+	Action    string    `db:"action" bson:"action"`
+	ActorID   string    `db:"actor_id" bson:"actor_id"`
+	ActorRole string    `db:"actor_role" bson:"actor_role"`
+	RequestID string    `db:"request_id" bson:"request_id"`
+	Diff      Diff      `db:"diff" bson:"diff"`
+	CreatedAt time.Time `db:"created_at" bson:"created_at"`
+}
+
+// IsEntity reports whether the changes of the entity are recorded
+func IsEntity(entity string) bool {
+	switch entity {
+	case EntityCustomer, EntityWorker, EntityHire:
+		return true
+	}
+	return false
+}
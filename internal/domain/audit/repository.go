@@ -0,0 +1,9 @@
+package audit
+
+import "context"
+
+type Repository interface {
+	// List returns the changes of the entity from the oldest one
+	List(ctx context.Context, entity, entityID string) (dest []Entity, err error)
+	Add(ctx context.Context, data Entity) (id string, err error)
+}
@@ -0,0 +1,36 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+)
+
+type Request struct {
+	Name string `json:"name"`
+}
+
+func (s *Request) Bind(r *http.Request) error {
+	if s.Name == "" {
+		return errors.New("name: cannot be blank")
+	}
+
+	return nil
+}
+
+type Response struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Secret   string `json:"secret,omitempty"`
+	UserID   string `json:"userId"`
+	IsActive bool   `json:"isActive"`
+}
+
+func ParseFromEntity(data Entity) (res Response) {
+	res = Response{
+		ID:       data.ID,
+		Name:     *data.Name,
+		UserID:   data.UserID,
+		IsActive: *data.IsActive,
+	}
+	return
+}
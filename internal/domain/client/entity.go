@@ -0,0 +1,9 @@
+package client
+
+type Entity struct {
+	ID         string  `db:"id" bson:"_id"`
+	Name       *string `db:"name" bson:"name"`
+	SecretHash *string `db:"secret_hash" bson:"secret_hash"`
+	UserID     string  `db:"user_id" bson:"user_id"`
+	IsActive   *bool   `db:"is_active" bson:"is_active"`
+}
@@ -0,0 +1,9 @@
+package client
+
+import "context"
+
+type Repository interface {
+	Add(ctx context.Context, data Entity) (id string, err error)
+	Get(ctx context.Context, id string) (dest Entity, err error)
+	Update(ctx context.Context, id string, data Entity) (err error)
+}
@@ -1,7 +1,14 @@
 package customer
 
-import "context"
+import (
+	"context"
+	"exchanger/pkg/market"
+)
 
+// Cache reads the customers through to the repository, the customer missing in the cache is read from it and cached
 type Cache interface {
 	Get(ctx context.Context, id string) (dest Entity, err error)
+	// Delete drops the customer from the cache once it has changed
+	Delete(ctx context.Context, id string) (err error)
+	Stats() market.CacheStats
 }
@@ -2,9 +2,20 @@ package customer
 
 import (
 	"errors"
+	"exchanger/pkg/market"
 	"net/http"
+	"reflect"
+	"time"
 )
 
+// Fields are the request fields the list can be sorted and filtered by
+var Fields = map[string]market.Field{
+	"id":        {Column: "id", Kind: reflect.String},
+	"fullName":  {Column: "full_name", Kind: reflect.String},
+	"pseudonym": {Column: "pseudonym", Kind: reflect.String},
+	"userId":    {Column: "user_id", Kind: reflect.String},
+}
+
 type Request struct {
 	FullName  string `json:"fullName"`
 	Pseudonym string `json:"pseudonym"`
@@ -22,10 +33,39 @@ func (s *Request) Bind(r *http.Request) error {
 	return nil
 }
 
+// Patch returns the request as the patch setting every field
+func (s Request) Patch() Patch {
+	return Patch{
+		FullName:  &s.FullName,
+		Pseudonym: &s.Pseudonym,
+	}
+}
+
+// Patch is the merge patch of the customer, the fields it leaves nil are kept
+type Patch struct {
+	FullName  *string `json:"fullName"`
+	Pseudonym *string `json:"pseudonym"`
+}
+
+func (s *Patch) Bind(r *http.Request) error {
+	if s.FullName != nil && *s.FullName == "" {
+		return errors.New("fullname: cannot be blank")
+	}
+
+	if s.Pseudonym != nil && *s.Pseudonym == "" {
+		return errors.New("pseudonym: cannot be blank")
+	}
+
+	return nil
+}
+
 type Response struct {
-	ID        string `json:"id"`
-	FullName  string `json:"fullName"`
-	Pseudonym string `json:"pseudonym"`
+	ID        string     `json:"id"`
+	FullName  string     `json:"fullName"`
+	Pseudonym string     `json:"pseudonym"`
+	UserID    string     `json:"userId"`
+	Version   int        `json:"version"`
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
 }
 
 func ParseFromEntity(data Entity) (res Response) {
@@ -33,6 +73,9 @@ func ParseFromEntity(data Entity) (res Response) {
 		ID:        data.ID,
 		FullName:  *data.FullName,
 		Pseudonym: *data.Pseudonym,
+		UserID:    data.UserID,
+		Version:   data.Version,
+		DeletedAt: data.DeletedAt,
 	}
 	return
 }
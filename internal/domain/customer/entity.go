@@ -1,7 +1,12 @@
 package customer
 
+import "time"
+
 type Entity struct {
-	ID        string  `db:"id" bson:"_id"`
-	FullName  *string `db:"full_name" bson:"full_name"`
-	Pseudonym *string `db:"pseudonym" bson:"pseudonym"`
+	ID        string     `db:"id" bson:"_id"`
+	FullName  *string    `db:"full_name" bson:"full_name"`
+	Pseudonym *string    `db:"pseudonym" bson:"pseudonym"`
+	UserID    string     `db:"user_id" bson:"user_id"`
+	Version   int        `db:"version" bson:"version"`
+	DeletedAt *time.Time `db:"deleted_at" bson:"deleted_at"`
 }
@@ -1,11 +1,24 @@
 package customer
 
-import "context"
+import (
+	"context"
+	"exchanger/pkg/market"
+	"time"
+)
 
+// Repository keeps the entities, Update and Delete are made for the version the entity is expected at,
+// zero version matches any and the mismatch is market.ErrorConflict. Delete only marks the entity deleted,
+// List and Get skip the deleted entities unless the context is market.WithDeleted
 type Repository interface {
-	List(ctx context.Context) (dest []Entity, err error)
+	List(ctx context.Context, q market.Query) (dest []Entity, total int, err error)
 	Add(ctx context.Context, data Entity) (id string, err error)
 	Get(ctx context.Context, id string) (dest Entity, err error)
 	Update(ctx context.Context, id string, data Entity) (err error)
-	Delete(ctx context.Context, id string) (err error)
+	Delete(ctx context.Context, id string, version int) (err error)
+	// Restore brings the deleted entity back
+	Restore(ctx context.Context, id string) (err error)
+	// ListDeleted returns the entities deleted before the time
+	ListDeleted(ctx context.Context, before time.Time) (dest []Entity, err error)
+	// Purge removes the deleted entity for good
+	Purge(ctx context.Context, id string) (err error)
 }
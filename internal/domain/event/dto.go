@@ -0,0 +1,26 @@
+package event
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Message is the event as it's published
+type Message struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	EntityID  string          `json:"entityId"`
+	Payload   json.RawMessage `json:"payload" swaggertype:"object"`
+	CreatedAt time.Time       `json:"createdAt"`
+}
+
+func ParseFromEntity(data Entity) (res Message) {
+	res = Message{
+		ID:        data.ID,
+		Type:      data.Type,
+		EntityID:  data.EntityID,
+		Payload:   json.RawMessage(data.Payload),
+		CreatedAt: data.CreatedAt,
+	}
+	return
+}
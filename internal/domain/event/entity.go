@@ -0,0 +1,31 @@
+package event
+
+import "time"
+
+// The domain events of the customers, workers and hires, the payload of each one is the entity after the change
+const (
+	CustomerCreated  = "CustomerCreated"
+	CustomerUpdated  = "CustomerUpdated"
+	CustomerDeleted  = "CustomerDeleted"
+	CustomerRestored = "CustomerRestored"
+	WorkerRegistered = "WorkerRegistered"
+	WorkerUpdated    = "WorkerUpdated"
+	WorkerDeleted    = "WorkerDeleted"
+	WorkerRestored   = "WorkerRestored"
+	HireCreated      = "HireCreated"
+	HireUpdated      = "HireUpdated"
+	HireDeleted      = "HireDeleted"
+	HireRestored     = "HireRestored"
+)
+
+// Entity is the event kept in the outbox until it's published, Attempts counts the failed publishes
+type Entity struct {
+	ID          string     `db:"id" bson:"_id"`
+	Type        string     `db:"type" bson:"type"`
+	EntityID    string     `db:"entity_id" bson:"entity_id"`
+	Payload     string     `db:"payload" bson:"payload"`
+	Attempts    int        `db:"attempts" bson:"attempts"`
+	LastError   string     `db:"last_error" bson:"last_error"`
+	CreatedAt   time.Time  `db:"created_at" bson:"created_at"`
+	PublishedAt *time.Time `db:"published_at" bson:"published_at"`
+}
@@ -0,0 +1,9 @@
+package event
+
+import "context"
+
+// Publisher delivers the events to their subscribers. The event is published at least once,
+// the one that failed is published again, so the subscribers must tolerate duplicates by the event id
+type Publisher interface {
+	Publish(ctx context.Context, data Message) (err error)
+}
@@ -0,0 +1,18 @@
+package event
+
+import (
+	"context"
+	"time"
+)
+
+// Repository is the outbox, the events are added in the unit of work of the change they are about
+// and stay pending until the relay publishes them
+type Repository interface {
+	Add(ctx context.Context, data Entity) (id string, err error)
+	// ListPending returns up to limit events that aren't published yet from the oldest one
+	ListPending(ctx context.Context, limit int) (dest []Entity, err error)
+	// MarkPublished marks the event published at the time
+	MarkPublished(ctx context.Context, id string, at time.Time) (err error)
+	// MarkFailed counts the failed publish of the event with its reason
+	MarkFailed(ctx context.Context, id, reason string) (err error)
+}
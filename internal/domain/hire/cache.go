@@ -0,0 +1,14 @@
+package hire
+
+import (
+	"context"
+	"exchanger/pkg/market"
+)
+
+// Cache reads the hires through to the repository, the hire missing in the cache is read from it and cached
+type Cache interface {
+	Get(ctx context.Context, id string) (dest Entity, err error)
+	// Delete drops the hire from the cache once it has changed
+	Delete(ctx context.Context, id string) (err error)
+	Stats() market.CacheStats
+}
@@ -0,0 +1,50 @@
+package hire
+
+import (
+	"errors"
+	"github.com/shopspring/decimal"
+	"strings"
+	"time"
+)
+
+// DefaultCurrency is the currency of the budgets given without one
+const DefaultCurrency = "KZT"
+
+// RateDateLayout is the format of the date of the rate the amount was converted by
+const RateDateLayout = "2006-01-02"
+
+// Conversion is the budget of the hire in the currency asked for,
+// the rate is the price of a unit of the hire currency on the rate date.
+// Stale conversion is made by the last known rates while the feed is unavailable
+type Conversion struct {
+	Amount   decimal.Decimal `json:"amount" swaggertype:"string"`
+	Currency string          `json:"currency"`
+	Rate     decimal.Decimal `json:"rate" swaggertype:"string"`
+	RateDate string          `json:"rateDate"`
+	Stale    bool            `json:"stale,omitempty"`
+}
+
+func NewConversion(amount decimal.Decimal, currency string, rate decimal.Decimal, rateDate time.Time) *Conversion {
+	return &Conversion{
+		Amount:   amount,
+		Currency: currency,
+		Rate:     rate,
+		RateDate: rateDate.Format(RateDateLayout),
+	}
+}
+
+// ParseCurrency returns the upper-cased ISO 4217 code of the currency
+func ParseCurrency(code string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if len(code) != 3 {
+		return "", errors.New("currency: must be an ISO 4217 code")
+	}
+
+	for _, letter := range code {
+		if letter < 'A' || letter > 'Z' {
+			return "", errors.New("currency: must be an ISO 4217 code")
+		}
+	}
+
+	return code, nil
+}
@@ -2,15 +2,32 @@ package hire
 
 import (
 	"errors"
+	"exchanger/pkg/market"
+	"github.com/shopspring/decimal"
 	"net/http"
+	"reflect"
+	"strings"
+	"time"
 )
 
+// Fields are the request fields the list can be sorted and filtered by
+var Fields = map[string]market.Field{
+	"id":          {Column: "id", Kind: reflect.String},
+	"jobname":     {Column: "job_name", Kind: reflect.String},
+	"amount":      {Column: "amount", Kind: reflect.Float64},
+	"description": {Column: "description", Kind: reflect.String},
+	"position":    {Column: "position", Kind: reflect.String},
+	"customerid":  {Column: "customer_id", Kind: reflect.String},
+	"status":      {Column: "status", Kind: reflect.String},
+}
+
 type Request struct {
-	JobName     string `json:"jobname"`
-	Amount      int    `json:"amount"`
-	Description string `json:"description"`
-	Position    string `json:"position"`
-	CustomerID  string `json:"customerid"`
+	JobName     string          `json:"jobname"`
+	Amount      decimal.Decimal `json:"amount" swaggertype:"string" example:"1500.50"`
+	Currency    string          `json:"currency" example:"KZT"`
+	Description string          `json:"description"`
+	Position    string          `json:"position"`
+	CustomerID  string          `json:"customerid"`
 }
 
 func (s *Request) Bind(r *http.Request) error {
@@ -18,10 +35,24 @@ func (s *Request) Bind(r *http.Request) error {
 		return errors.New("jobname: cannot be blank")
 	}
 
-	if s.Amount == 0 {
+	if s.Amount.IsZero() {
 		return errors.New("amount: cannot be blank")
 	}
 
+	if s.Amount.IsNegative() {
+		return errors.New("amount: cannot be negative")
+	}
+
+	if s.Currency == "" {
+		s.Currency = DefaultCurrency
+	}
+
+	currency, err := ParseCurrency(s.Currency)
+	if err != nil {
+		return err
+	}
+	s.Currency = currency
+
 	if s.Description == "" {
 		return errors.New("description: cannot be blank")
 	}
@@ -30,16 +61,83 @@ func (s *Request) Bind(r *http.Request) error {
 		return errors.New("position: cannot be blank")
 	}
 
+	if s.CustomerID == "" {
+		return errors.New("customerid: cannot be blank")
+	}
+
+	return nil
+}
+
+// Patch returns the request as the patch setting every field
+func (s Request) Patch() Patch {
+	return Patch{
+		JobName:     &s.JobName,
+		Amount:      &s.Amount,
+		Currency:    &s.Currency,
+		Description: &s.Description,
+		Position:    &s.Position,
+		CustomerID:  &s.CustomerID,
+	}
+}
+
+// Patch is the merge patch of the hire, the fields it leaves nil are kept
+type Patch struct {
+	JobName     *string          `json:"jobname"`
+	Amount      *decimal.Decimal `json:"amount" swaggertype:"string" example:"1500.50"`
+	Currency    *string          `json:"currency" example:"KZT"`
+	Description *string          `json:"description"`
+	Position    *string          `json:"position"`
+	CustomerID  *string          `json:"customerid"`
+}
+
+func (s *Patch) Bind(r *http.Request) error {
+	if s.JobName != nil && *s.JobName == "" {
+		return errors.New("jobname: cannot be blank")
+	}
+
+	if s.Amount != nil && s.Amount.IsZero() {
+		return errors.New("amount: cannot be blank")
+	}
+
+	if s.Amount != nil && s.Amount.IsNegative() {
+		return errors.New("amount: cannot be negative")
+	}
+
+	if s.Currency != nil {
+		currency, err := ParseCurrency(*s.Currency)
+		if err != nil {
+			return err
+		}
+		s.Currency = &currency
+	}
+
+	if s.Description != nil && *s.Description == "" {
+		return errors.New("description: cannot be blank")
+	}
+
+	if s.Position != nil && *s.Position == "" {
+		return errors.New("position: cannot be blank")
+	}
+
+	if s.CustomerID != nil && *s.CustomerID == "" {
+		return errors.New("customerid: cannot be blank")
+	}
+
 	return nil
 }
 
 type Response struct {
-	ID          string `json:"id"`
-	JobName     string `json:"jobname"`
-	Amount      int    `json:"amount"`
-	Description string `json:"description"`
-	Position    string `json:"position"`
-	CustomerID  string `json:"customerid"`
+	ID          string          `json:"id"`
+	JobName     string          `json:"jobname"`
+	Amount      decimal.Decimal `json:"amount" swaggertype:"string"`
+	Currency    string          `json:"currency"`
+	Description string          `json:"description"`
+	Position    string          `json:"position"`
+	CustomerID  string          `json:"customerid"`
+	Status      string          `json:"status"`
+	Version     int             `json:"version"`
+	DeletedAt   *time.Time      `json:"deletedAt,omitempty"`
+	Converted   *Conversion     `json:"converted,omitempty"`
 }
 
 func ParseFromEntity(data Entity) (res Response) {
@@ -47,9 +145,48 @@ func ParseFromEntity(data Entity) (res Response) {
 		ID:          data.ID,
 		JobName:     *data.JobName,
 		Amount:      *data.Amount,
+		Currency:    *data.Currency,
 		Description: *data.Description,
 		Position:    *data.Position,
 		CustomerID:  data.CustomerID,
+		Status:      data.Status,
+		Version:     data.Version,
+		DeletedAt:   data.DeletedAt,
+	}
+	return
+}
+
+// SearchResponse is a hire found by the search, the highlights hold the matched fields only
+type SearchResponse struct {
+	Response
+	Rank       float64           `json:"rank"`
+	Highlights map[string]string `json:"highlights,omitempty"`
+}
+
+func ParseFromSearchEntity(data SearchEntity) (res SearchResponse) {
+	res = SearchResponse{
+		Response:   ParseFromEntity(data.Entity),
+		Rank:       data.Rank,
+		Highlights: make(map[string]string),
+	}
+
+	highlights := map[string]string{
+		"jobname":     data.JobNameHighlight,
+		"description": data.DescriptionHighlight,
+		"position":    data.PositionHighlight,
+	}
+	for field, value := range highlights {
+		if strings.Contains(value, HighlightStart) {
+			res.Highlights[field] = value
+		}
+	}
+	return
+}
+
+func ParseFromSearchEntities(data []SearchEntity) (res []SearchResponse) {
+	res = make([]SearchResponse, 0)
+	for _, object := range data {
+		res = append(res, ParseFromSearchEntity(object))
 	}
 	return
 }
@@ -1,10 +1,19 @@
 package hire
 
+import (
+	"github.com/shopspring/decimal"
+	"time"
+)
+
 type Entity struct {
-	ID          string  `db:"id" bson:"_id"`
-	JobName     *string `db:"job_name" bson:"job_name"`
-	Amount      *int    `db:"amount" bson:"amount"`
-	Description *string `db:"description" bson:"description"`
-	Position    *string `db:"position" bson:"position"`
-	CustomerID  string  `db:"customer_id" bson:"customer_id"`
+	ID          string           `db:"id" bson:"_id"`
+	JobName     *string          `db:"job_name" bson:"job_name"`
+	Amount      *decimal.Decimal `db:"amount" bson:"amount"`
+	Currency    *string          `db:"currency" bson:"currency"`
+	Description *string          `db:"description" bson:"description"`
+	Position    *string          `db:"position" bson:"position"`
+	CustomerID  string           `db:"customer_id" bson:"customer_id"`
+	Status      string           `db:"status" bson:"status"`
+	Version     int              `db:"version" bson:"version"`
+	DeletedAt   *time.Time       `db:"deleted_at" bson:"deleted_at"`
 }
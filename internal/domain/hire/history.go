@@ -0,0 +1,51 @@
+package hire
+
+import (
+	"context"
+	"time"
+)
+
+// HistoryEntity records a change of the hire status made by the user
+type HistoryEntity struct {
+	ID         string    `db:"id" bson:"_id"`
+	HireID     string    `db:"hire_id" bson:"hire_id"`
+	FromStatus string    `db:"from_status" bson:"from_status"`
+	ToStatus   string    `db:"to_status" bson:"to_status"`
+	UserID     string    `db:"user_id" bson:"user_id"`
+	CreatedAt  time.Time `db:"created_at" bson:"created_at"`
+}
+
+type HistoryRepository interface {
+	// List returns the status changes of the hire from the oldest one
+	List(ctx context.Context, hireID string) (dest []HistoryEntity, err error)
+	Add(ctx context.Context, data HistoryEntity) (id string, err error)
+	// DeleteByHire deletes the status changes of the hire
+	DeleteByHire(ctx context.Context, hireID string) (err error)
+}
+
+type HistoryResponse struct {
+	ID         string    `json:"id"`
+	FromStatus string    `json:"fromStatus"`
+	ToStatus   string    `json:"toStatus"`
+	UserID     string    `json:"userId"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+func ParseFromHistoryEntity(data HistoryEntity) (res HistoryResponse) {
+	res = HistoryResponse{
+		ID:         data.ID,
+		FromStatus: data.FromStatus,
+		ToStatus:   data.ToStatus,
+		UserID:     data.UserID,
+		CreatedAt:  data.CreatedAt,
+	}
+	return
+}
+
+func ParseFromHistoryEntities(data []HistoryEntity) (res []HistoryResponse) {
+	res = make([]HistoryResponse, 0)
+	for _, object := range data {
+		res = append(res, ParseFromHistoryEntity(object))
+	}
+	return
+}
@@ -0,0 +1,150 @@
+package hire
+
+import (
+	"exchanger/pkg/market"
+	"github.com/shopspring/decimal"
+	"strings"
+	"unicode"
+)
+
+const (
+	// HighlightStart and HighlightStop wrap the matched words in the highlighted fields
+	HighlightStart = "<b>"
+	HighlightStop  = "</b>"
+)
+
+// Weights rank a match in the job name above the position and the position above the description
+var Weights = map[string]float64{
+	"job_name":    1.0,
+	"position":    0.4,
+	"description": 0.2,
+}
+
+// Search is a full-text query over the job name, description and position of the hires.
+//
+// Every store follows the same grammar: the text is split by Terms into lower-cased words of letters and digits,
+// everything else separates the words and no operators or phrases are recognized. A hire matches when each of
+// the words starts some word of its job name, description or position. The rank is the number of the words of
+// a field starting with any of the terms times the weight of the field summed over the fields, the hires are
+// ordered by the rank and then by id.
+//
+// The amounts are compared within the currency only, so that the range is set together with the Currency
+type Search struct {
+	Text       string
+	Currency   string
+	AmountFrom *decimal.Decimal
+	AmountTo   *decimal.Decimal
+	CustomerID string
+	market.Query
+}
+
+// SearchEntity is a hire matching the search with its rank and highlighted fields
+type SearchEntity struct {
+	Entity               `bson:",inline"`
+	Rank                 float64 `db:"rank" bson:"rank"`
+	JobNameHighlight     string  `db:"job_name_highlight" bson:"-"`
+	DescriptionHighlight string  `db:"description_highlight" bson:"-"`
+	PositionHighlight    string  `db:"position_highlight" bson:"-"`
+}
+
+// Terms splits the text into the distinct lower-cased words to search for
+func Terms(text string) (terms []string) {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	seen := make(map[string]bool, len(words))
+	for _, word := range words {
+		if !seen[word] {
+			seen[word] = true
+			terms = append(terms, word)
+		}
+	}
+
+	return
+}
+
+// Match ranks and highlights the hire when every term starts a word of its job name, description or position
+func Match(data Entity, terms []string) (dest SearchEntity, ok bool) {
+	dest = SearchEntity{Entity: data}
+
+	fields := []struct {
+		column    string
+		value     *string
+		highlight *string
+	}{
+		{"job_name", data.JobName, &dest.JobNameHighlight},
+		{"description", data.Description, &dest.DescriptionHighlight},
+		{"position", data.Position, &dest.PositionHighlight},
+	}
+
+	found := make(map[string]bool, len(terms))
+	for _, field := range fields {
+		if field.value == nil {
+			continue
+		}
+
+		var matches int
+		*field.highlight, matches = Highlight(*field.value, terms)
+		dest.Rank += Weights[field.column] * float64(matches)
+
+		for _, word := range Terms(*field.value) {
+			for _, term := range terms {
+				if strings.HasPrefix(word, term) {
+					found[term] = true
+				}
+			}
+		}
+	}
+
+	return dest, len(terms) > 0 && len(found) == len(terms)
+}
+
+// Highlight wraps the words of the value that start with one of the terms,
+// it returns the number of wrapped words along with the highlighted value
+func Highlight(value string, terms []string) (res string, matches int) {
+	var builder strings.Builder
+
+	start := -1
+	flush := func(end int) {
+		word := value[start:end]
+		if matchTerms(word, terms) {
+			builder.WriteString(HighlightStart + word + HighlightStop)
+			matches++
+		} else {
+			builder.WriteString(word)
+		}
+		start = -1
+	}
+
+	for i, r := range value {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+
+		if start >= 0 {
+			flush(i)
+		}
+		builder.WriteRune(r)
+	}
+
+	if start >= 0 {
+		flush(len(value))
+	}
+
+	return builder.String(), matches
+}
+
+func matchTerms(word string, terms []string) bool {
+	word = strings.ToLower(word)
+	for _, term := range terms {
+		if strings.HasPrefix(word, term) {
+			return true
+		}
+	}
+
+	return false
+}
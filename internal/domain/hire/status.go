@@ -0,0 +1,69 @@
+package hire
+
+const (
+	// StatusDraft hires are being prepared by the customer and are not visible to bids
+	StatusDraft = "draft"
+	// StatusOpen hires accept new proposals
+	StatusOpen = "open"
+	// StatusInProgress hires have an accepted proposal and take no new ones
+	StatusInProgress = "in_progress"
+	// StatusCompleted hires are done
+	StatusCompleted = "completed"
+	// StatusCancelled hires are called off
+	StatusCancelled = "cancelled"
+	// StatusDisputed hires wait for an admin to resolve the disagreement on the work
+	StatusDisputed = "disputed"
+)
+
+// transitions are the statuses each status can legally change to,
+// the open hire goes back to draft when its escrow payment is cancelled by the provider
+var transitions = map[string][]string{
+	StatusDraft:      {StatusOpen, StatusCancelled},
+	StatusOpen:       {StatusDraft, StatusInProgress, StatusCancelled},
+	StatusInProgress: {StatusCompleted, StatusCancelled, StatusDisputed},
+	StatusDisputed:   {StatusInProgress, StatusCompleted, StatusCancelled},
+}
+
+// editable are the request fields that can still change in each status
+var editable = map[string]map[string]bool{
+	StatusDraft:      {"jobname": true, "amount": true, "currency": true, "description": true, "position": true},
+	StatusOpen:       {"jobname": true, "description": true, "position": true},
+	StatusInProgress: {"description": true},
+	StatusDisputed:   {},
+}
+
+// CanTransition checks that the hire can move from one status to the other
+func CanTransition(from, to string) bool {
+	for _, status := range transitions[from] {
+		if status == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsEditable checks that the request field of the hire can change in the status
+func IsEditable(status, field string) bool {
+	return editable[status][field]
+}
+
+// LockedField returns the first field the patch changes but the status of the hire locks
+func LockedField(data Entity, req Patch) (field string, locked bool) {
+	changes := map[string]bool{
+		"jobname":     req.JobName != nil && (data.JobName == nil || *data.JobName != *req.JobName),
+		"amount":      req.Amount != nil && (data.Amount == nil || !data.Amount.Equal(*req.Amount)),
+		"currency":    req.Currency != nil && (data.Currency == nil || *data.Currency != *req.Currency),
+		"description": req.Description != nil && (data.Description == nil || *data.Description != *req.Description),
+		"position":    req.Position != nil && (data.Position == nil || *data.Position != *req.Position),
+		"customerid":  req.CustomerID != nil && data.CustomerID != *req.CustomerID,
+	}
+
+	for _, field = range []string{"jobname", "amount", "currency", "description", "position", "customerid"} {
+		if changes[field] && !IsEditable(data.Status, field) {
+			return field, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,21 @@
+package payment
+
+import (
+	"context"
+	"time"
+)
+
+// CallbackEntity is a raw notification of the provider kept for audit
+type CallbackEntity struct {
+	ID         string    `db:"id" bson:"_id"`
+	InvoiceID  string    `db:"invoice_id" bson:"invoice_id"`
+	Body       string    `db:"body" bson:"body"`
+	RemoteAddr string    `db:"remote_addr" bson:"remote_addr"`
+	ReceivedAt time.Time `db:"received_at" bson:"received_at"`
+}
+
+type CallbackRepository interface {
+	// List returns the callbacks of the invoice from the oldest one
+	List(ctx context.Context, invoiceID string) (dest []CallbackEntity, err error)
+	Add(ctx context.Context, data CallbackEntity) (id string, err error)
+}
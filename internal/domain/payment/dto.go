@@ -0,0 +1,63 @@
+package payment
+
+import (
+	"errors"
+	"net/http"
+	"time"
+)
+
+// OperationRequest is the amount of the charge or of the refund, zero amount takes the whole transaction
+type OperationRequest struct {
+	Amount int `json:"amount"`
+}
+
+func (s *OperationRequest) Bind(r *http.Request) error {
+	if s.Amount < 0 {
+		return errors.New("amount: cannot be negative")
+	}
+
+	return nil
+}
+
+type Response struct {
+	InvoiceID      string     `json:"invoiceId"`
+	HireID         string     `json:"hireid"`
+	CustomerID     string     `json:"customerid"`
+	WorkerID       string     `json:"workerid,omitempty"`
+	Amount         int        `json:"amount"`
+	Currency       string     `json:"currency"`
+	ChargedAmount  *int       `json:"chargedAmount,omitempty"`
+	RefundedAmount *int       `json:"refundedAmount,omitempty"`
+	Status         string     `json:"status"`
+	ProviderStatus string     `json:"providerStatus,omitempty"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	ExpiresAt      time.Time  `json:"expiresAt"`
+	ReleasedAt     *time.Time `json:"releasedAt,omitempty"`
+}
+
+func ParseFromEntity(data Entity) (res Response) {
+	res = Response{
+		InvoiceID:      data.InvoiceID,
+		HireID:         data.HireID,
+		CustomerID:     data.CustomerID,
+		WorkerID:       data.WorkerID,
+		Amount:         *data.Amount,
+		Currency:       *data.Currency,
+		ChargedAmount:  data.ChargedAmount,
+		RefundedAmount: data.RefundedAmount,
+		Status:         data.Status,
+		ProviderStatus: data.ProviderStatus,
+		CreatedAt:      data.CreatedAt,
+		ExpiresAt:      data.ExpiresAt,
+		ReleasedAt:     data.ReleasedAt,
+	}
+	return
+}
+
+func ParseFromEntities(data []Entity) (res []Response) {
+	res = make([]Response, 0)
+	for _, object := range data {
+		res = append(res, ParseFromEntity(object))
+	}
+	return
+}
@@ -0,0 +1,19 @@
+package payment
+
+import "time"
+
+type Entity struct {
+	InvoiceID      string     `db:"invoice_id" bson:"_id"`
+	HireID         string     `db:"hire_id" bson:"hire_id"`
+	CustomerID     string     `db:"customer_id" bson:"customer_id"`
+	WorkerID       string     `db:"worker_id" bson:"worker_id"`
+	Amount         *int       `db:"amount" bson:"amount"`
+	Currency       *string    `db:"currency" bson:"currency"`
+	ChargedAmount  *int       `db:"charged_amount" bson:"charged_amount"`
+	RefundedAmount *int       `db:"refunded_amount" bson:"refunded_amount"`
+	Status         string     `db:"status" bson:"status"`
+	ProviderStatus string     `db:"provider_status" bson:"provider_status"`
+	CreatedAt      time.Time  `db:"created_at" bson:"created_at"`
+	ExpiresAt      time.Time  `db:"expires_at" bson:"expires_at"`
+	ReleasedAt     *time.Time `db:"released_at" bson:"released_at"`
+}
@@ -0,0 +1,64 @@
+package payment
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// OperationCharge captures the authorized amount
+	OperationCharge = "charge"
+	// OperationCancel releases the authorized amount
+	OperationCancel = "cancel"
+	// OperationRefund returns the charged amount
+	OperationRefund = "refund"
+)
+
+// HistoryEntity records an operation made on the transaction of the payment by the user
+type HistoryEntity struct {
+	ID         string    `db:"id" bson:"_id"`
+	InvoiceID  string    `db:"invoice_id" bson:"invoice_id"`
+	Operation  string    `db:"operation" bson:"operation"`
+	Amount     int       `db:"amount" bson:"amount"`
+	FromStatus string    `db:"from_status" bson:"from_status"`
+	ToStatus   string    `db:"to_status" bson:"to_status"`
+	UserID     string    `db:"user_id" bson:"user_id"`
+	CreatedAt  time.Time `db:"created_at" bson:"created_at"`
+}
+
+type HistoryRepository interface {
+	// List returns the operations of the payment from the oldest one
+	List(ctx context.Context, invoiceID string) (dest []HistoryEntity, err error)
+	Add(ctx context.Context, data HistoryEntity) (id string, err error)
+}
+
+type HistoryResponse struct {
+	ID         string    `json:"id"`
+	Operation  string    `json:"operation"`
+	Amount     int       `json:"amount"`
+	FromStatus string    `json:"fromStatus"`
+	ToStatus   string    `json:"toStatus"`
+	UserID     string    `json:"userId"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+func ParseFromHistoryEntity(data HistoryEntity) (res HistoryResponse) {
+	res = HistoryResponse{
+		ID:         data.ID,
+		Operation:  data.Operation,
+		Amount:     data.Amount,
+		FromStatus: data.FromStatus,
+		ToStatus:   data.ToStatus,
+		UserID:     data.UserID,
+		CreatedAt:  data.CreatedAt,
+	}
+	return
+}
+
+func ParseFromHistoryEntities(data []HistoryEntity) (res []HistoryResponse) {
+	res = make([]HistoryResponse, 0)
+	for _, object := range data {
+		res = append(res, ParseFromHistoryEntity(object))
+	}
+	return
+}
@@ -0,0 +1,18 @@
+package payment
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// NewInvoiceID returns a 15 digit invoice id, the provider accepts from 6 to 15 digits
+func NewInvoiceID() (id string, err error) {
+	suffix, err := rand.Int(rand.Reader, big.NewInt(100000))
+	if err != nil {
+		return
+	}
+
+	return fmt.Sprintf("%010d%05d", time.Now().Unix(), suffix.Int64()), nil
+}
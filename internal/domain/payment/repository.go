@@ -0,0 +1,21 @@
+package payment
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrorActivePayment is returned on the add of the second pending or funded payment of the hire
+var ErrorActivePayment = errors.New("hire already has an active payment")
+
+type Repository interface {
+	// List returns the payments of the hire from the oldest one
+	List(ctx context.Context, hireID string) (dest []Entity, err error)
+	// ListByStatus returns the payments of any of the statuses from the oldest one
+	ListByStatus(ctx context.Context, statuses ...string) (dest []Entity, err error)
+	// Add stores the payment, the taken invoice id is market.ErrorAlreadyExists and the second active
+	// payment of the hire is ErrorActivePayment
+	Add(ctx context.Context, data Entity) (err error)
+	Get(ctx context.Context, invoiceID string) (dest Entity, err error)
+	Update(ctx context.Context, invoiceID string, data Entity) (err error)
+}
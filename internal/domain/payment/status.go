@@ -0,0 +1,60 @@
+package payment
+
+import "time"
+
+const (
+	// StatusPending payments wait for the customer on the payment page
+	StatusPending = "pending"
+	// StatusFunded payments hold the amount of the hire in escrow
+	StatusFunded = "funded"
+	// StatusReleased payments are handed over to the worker of the completed hire
+	StatusReleased = "released"
+	// StatusFailed payments were declined or cancelled by the provider
+	StatusFailed = "failed"
+	// StatusRefunded payments were cancelled or refunded to the customer in full
+	StatusRefunded = "refunded"
+	// StatusExpired payments weren't paid before they were due
+	StatusExpired = "expired"
+
+	// ProviderStatusExpired is set on the payments expired by the service, the provider has no such status
+	ProviderStatusExpired = "EXPIRED"
+)
+
+// StatusOf maps the transaction status of the provider to the escrow status of the payment
+func StatusOf(providerStatus string) string {
+	switch providerStatus {
+	case "", "NEW":
+		return StatusPending
+	case "AUTH", "CHARGE":
+		return StatusFunded
+	case "CANCEL", "REFUND":
+		return StatusRefunded
+	case ProviderStatusExpired:
+		return StatusExpired
+	default:
+		return StatusFailed
+	}
+}
+
+// IsActive checks that the payment still holds or is about to hold the amount of the hire
+func IsActive(status string) bool {
+	return status == StatusPending || status == StatusFunded
+}
+
+// IsUnsettled checks that the provider can still change the transaction of the payment,
+// the pending payment can be paid and the authorized amount can be charged or cancelled
+func IsUnsettled(data Entity) bool {
+	switch data.Status {
+	case StatusPending:
+		return true
+	case StatusFunded:
+		return data.ProviderStatus == "AUTH"
+	default:
+		return false
+	}
+}
+
+// IsOverdue checks that the payment is still pending after it was due
+func IsOverdue(data Entity, now time.Time) bool {
+	return data.Status == StatusPending && !data.ExpiresAt.IsZero() && now.After(data.ExpiresAt)
+}
@@ -0,0 +1,67 @@
+package proposal
+
+import (
+	"errors"
+	"exchanger/pkg/market"
+	"net/http"
+	"reflect"
+)
+
+// Fields are the request fields the list can be sorted and filtered by
+var Fields = map[string]market.Field{
+	"id":       {Column: "id", Kind: reflect.String},
+	"workerid": {Column: "worker_id", Kind: reflect.String},
+	"amount":   {Column: "amount", Kind: reflect.Int},
+	"status":   {Column: "status", Kind: reflect.String},
+}
+
+type Request struct {
+	WorkerID    string `json:"workerid"`
+	CoverLetter string `json:"coverLetter"`
+	Amount      int    `json:"amount"`
+}
+
+func (s *Request) Bind(r *http.Request) error {
+	if s.WorkerID == "" {
+		return errors.New("workerid: cannot be blank")
+	}
+
+	if s.CoverLetter == "" {
+		return errors.New("coverLetter: cannot be blank")
+	}
+
+	if s.Amount <= 0 {
+		return errors.New("amount: must be positive")
+	}
+
+	return nil
+}
+
+type Response struct {
+	ID          string `json:"id"`
+	HireID      string `json:"hireid"`
+	WorkerID    string `json:"workerid"`
+	CoverLetter string `json:"coverLetter"`
+	Amount      int    `json:"amount"`
+	Status      string `json:"status"`
+}
+
+func ParseFromEntity(data Entity) (res Response) {
+	res = Response{
+		ID:          data.ID,
+		HireID:      data.HireID,
+		WorkerID:    data.WorkerID,
+		CoverLetter: *data.CoverLetter,
+		Amount:      *data.Amount,
+		Status:      data.Status,
+	}
+	return
+}
+
+func ParseFromEntities(data []Entity) (res []Response) {
+	res = make([]Response, 0)
+	for _, object := range data {
+		res = append(res, ParseFromEntity(object))
+	}
+	return
+}
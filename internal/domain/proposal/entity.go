@@ -0,0 +1,10 @@
+package proposal
+
+type Entity struct {
+	ID          string  `db:"id" bson:"_id"`
+	HireID      string  `db:"hire_id" bson:"hire_id"`
+	WorkerID    string  `db:"worker_id" bson:"worker_id"`
+	CoverLetter *string `db:"cover_letter" bson:"cover_letter"`
+	Amount      *int    `db:"amount" bson:"amount"`
+	Status      string  `db:"status" bson:"status"`
+}
@@ -0,0 +1,17 @@
+package proposal
+
+import (
+	"context"
+	"exchanger/pkg/market"
+)
+
+type Repository interface {
+	List(ctx context.Context, hireID string, q market.Query) (dest []Entity, total int, err error)
+	Add(ctx context.Context, data Entity) (id string, err error)
+	Get(ctx context.Context, id string) (dest Entity, err error)
+	Update(ctx context.Context, id string, data Entity) (err error)
+	// DeleteByHire deletes all the proposals of the hire
+	DeleteByHire(ctx context.Context, hireID string) (err error)
+	// CountByWorker returns how many proposals the worker made to any hire
+	CountByWorker(ctx context.Context, workerID string) (count int, err error)
+}
@@ -0,0 +1,10 @@
+package proposal
+
+const (
+	// StatusPending proposals wait for the customer of the hire
+	StatusPending = "pending"
+	// StatusAccepted proposal is the one the customer has chosen for the hire
+	StatusAccepted = "accepted"
+	// StatusRejected proposals are turned down by the customer or by accepting another one
+	StatusRejected = "rejected"
+)
@@ -0,0 +1,80 @@
+package rate
+
+import (
+	"errors"
+	"github.com/shopspring/decimal"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ConvertRequest is the amount of one currency to convert into the other one by the rates of the date
+type ConvertRequest struct {
+	From   string
+	To     string
+	Amount decimal.Decimal
+	Date   time.Time
+}
+
+func (s *ConvertRequest) Bind(r *http.Request) error {
+	s.From = strings.ToUpper(s.From)
+	s.To = strings.ToUpper(s.To)
+
+	if s.From == "" {
+		return errors.New("from: cannot be blank")
+	}
+
+	if s.To == "" {
+		return errors.New("to: cannot be blank")
+	}
+
+	if s.Amount.IsNegative() {
+		return errors.New("amount: cannot be negative")
+	}
+
+	return nil
+}
+
+type Response struct {
+	Code   string          `json:"code"`
+	Name   string          `json:"name"`
+	Value  decimal.Decimal `json:"value" swaggertype:"string"`
+	Quant  int             `json:"quant"`
+	Base   string          `json:"base"`
+	Source string          `json:"source"`
+	Date   string          `json:"date"`
+	Stale  bool            `json:"stale,omitempty"`
+}
+
+func ParseFromEntity(data Entity) (res Response) {
+	res = Response{
+		Code:   data.Code,
+		Name:   data.Name,
+		Value:  data.Value,
+		Quant:  data.Quant,
+		Base:   data.Base,
+		Source: data.Source,
+		Date:   data.Date.Format(DateLayout),
+	}
+	return
+}
+
+func ParseFromEntities(data []Entity) (res []Response) {
+	res = make([]Response, 0)
+	for _, object := range data {
+		res = append(res, ParseFromEntity(object))
+	}
+	return
+}
+
+// ConvertResponse is the converted amount with the rate of a unit of the currency converted from
+type ConvertResponse struct {
+	From   string          `json:"from"`
+	To     string          `json:"to"`
+	Amount decimal.Decimal `json:"amount" swaggertype:"string"`
+	Result decimal.Decimal `json:"result" swaggertype:"string"`
+	Rate   decimal.Decimal `json:"rate" swaggertype:"string"`
+	Base   string          `json:"base"`
+	Date   string          `json:"date"`
+	Stale  bool            `json:"stale,omitempty"`
+}
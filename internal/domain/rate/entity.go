@@ -0,0 +1,24 @@
+package rate
+
+import (
+	"github.com/shopspring/decimal"
+	"time"
+)
+
+// Entity is the rate of the currency on the date, the value is the price of the quant of it in the base currency.
+// Source is the name of the rate source the rate was given by
+type Entity struct {
+	ID     string          `db:"id" bson:"_id"`
+	Date   time.Time       `db:"date" bson:"date"`
+	Code   string          `db:"code" bson:"code"`
+	Name   string          `db:"name" bson:"name"`
+	Value  decimal.Decimal `db:"value" bson:"value"`
+	Quant  int             `db:"quant" bson:"quant"`
+	Base   string          `db:"base" bson:"base"`
+	Source string          `db:"source" bson:"source"`
+}
+
+// NewID returns the id of the rate of the currency on the date
+func NewID(date time.Time, code string) string {
+	return date.Format(DateLayout) + ":" + code
+}
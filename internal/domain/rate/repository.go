@@ -0,0 +1,13 @@
+package rate
+
+import (
+	"context"
+	"time"
+)
+
+type Repository interface {
+	// List returns the rates of the date ordered by the currency code, none when the date isn't stored
+	List(ctx context.Context, date time.Time) (dest []Entity, err error)
+	// Add stores the rates of a date, the rates already stored are kept
+	Add(ctx context.Context, data []Entity) (err error)
+}
@@ -0,0 +1,75 @@
+package rate
+
+import (
+	"errors"
+	"fmt"
+	"github.com/shopspring/decimal"
+	"strings"
+	"time"
+)
+
+// BaseCurrency is the currency the national bank gives the rates in, the tables without a base are in it
+const BaseCurrency = "KZT"
+
+// DateLayout is the format of the rate dates in the requests and responses
+const DateLayout = "2006-01-02"
+
+var ErrorUnknownCurrency = errors.New("currency has no rate")
+
+// Table holds the rates of a single date in the base currency, the cross rates go through it.
+// Stale rates are the last known ones served while the sources are unavailable
+type Table struct {
+	Date  time.Time
+	Base  string
+	Items []Entity
+	Stale bool
+}
+
+// Find returns the price in the base currency of a single unit of the currency
+func (t Table) Find(code string) (dest decimal.Decimal, err error) {
+	base := t.Base
+	if base == "" {
+		base = BaseCurrency
+	}
+
+	if strings.EqualFold(code, base) {
+		return decimal.NewFromInt(1), nil
+	}
+
+	for _, data := range t.Items {
+		if !strings.EqualFold(code, data.Code) {
+			continue
+		}
+
+		if data.Value.IsZero() {
+			return dest, errors.New("rate: cannot be blank")
+		}
+
+		quant := data.Quant
+		if quant <= 0 {
+			quant = 1
+		}
+		return data.Value.Div(decimal.NewFromInt(int64(quant))), nil
+	}
+
+	return dest, fmt.Errorf("%s: %w", code, ErrorUnknownCurrency)
+}
+
+// Convert returns the amount of one currency in the other one with the rate it was converted by,
+// the rate is the price of a single unit of the currency converted from
+func (t Table) Convert(amount decimal.Decimal, from, to string) (dest, rate decimal.Decimal, err error) {
+	fromRate, err := t.Find(from)
+	if err != nil {
+		return
+	}
+
+	toRate, err := t.Find(to)
+	if err != nil {
+		return
+	}
+
+	rate = fromRate.DivRound(toRate, 8)
+	dest = amount.Mul(fromRate).DivRound(toRate, 2)
+
+	return
+}
@@ -0,0 +1,79 @@
+package rate
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func newTable() Table {
+	return Table{
+		Base: BaseCurrency,
+		Items: []Entity{
+			{Code: "USD", Value: decimal.RequireFromString("450"), Quant: 1},
+			{Code: "EUR", Value: decimal.RequireFromString("500"), Quant: 1},
+			{Code: "JPY", Value: decimal.RequireFromString("300"), Quant: 100},
+			{Code: "GBP", Value: decimal.RequireFromString("570"), Quant: 0},
+			{Code: "XAU", Value: decimal.Zero, Quant: 1},
+		},
+	}
+}
+
+func TestTableConvert(t *testing.T) {
+	tests := []struct {
+		name     string
+		table    Table
+		amount   string
+		from, to string
+		want     string
+		rate     string
+		wantErr  error
+		anyErr   bool
+	}{
+		{name: "base to currency", amount: "1000", from: "KZT", to: "USD", want: "2.22", rate: "0.00222222"},
+		{name: "currency to base", amount: "100", from: "USD", to: "KZT", want: "45000", rate: "450"},
+		{name: "cross rate", amount: "100", from: "USD", to: "EUR", want: "90", rate: "0.9"},
+		{name: "cross rate back", amount: "90", from: "EUR", to: "USD", want: "100", rate: "1.11111111"},
+		{name: "quant divides the rate", amount: "1000", from: "JPY", to: "KZT", want: "3000", rate: "3"},
+		{name: "cross rate by quant", amount: "1", from: "USD", to: "JPY", want: "150", rate: "150"},
+		{name: "zero quant counts as one", amount: "1", from: "GBP", to: "KZT", want: "570", rate: "570"},
+		{name: "same currency", amount: "12.345", from: "USD", to: "USD", want: "12.35", rate: "1"},
+		{name: "codes in any case", amount: "100", from: "usd", to: "kzt", want: "45000", rate: "450"},
+		{name: "blank base stands for tenge", table: Table{Items: newTable().Items}, amount: "100", from: "USD", to: "KZT", want: "45000", rate: "450"},
+		{name: "unknown currency converted from", amount: "1", from: "XXX", to: "KZT", wantErr: ErrorUnknownCurrency},
+		{name: "unknown currency converted to", amount: "1", from: "KZT", to: "XXX", wantErr: ErrorUnknownCurrency},
+		{name: "base of another table", table: Table{Base: "EUR", Items: newTable().Items}, amount: "1", from: "KZT", to: "USD", wantErr: ErrorUnknownCurrency},
+		{name: "zero rate", amount: "1", from: "XAU", to: "KZT", anyErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := tt.table
+			if table.Items == nil {
+				table = newTable()
+			}
+
+			got, rate, err := table.Convert(decimal.RequireFromString(tt.amount), tt.from, tt.to)
+			if tt.wantErr != nil || tt.anyErr {
+				if err == nil || (tt.wantErr != nil && !errors.Is(err, tt.wantErr)) {
+					t.Fatalf("Convert() error = %v, want %v", err, tt.wantErr)
+				}
+				if tt.anyErr && errors.Is(err, ErrorUnknownCurrency) {
+					t.Fatalf("Convert() error = %v, want other than %v", err, ErrorUnknownCurrency)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Convert() error = %v", err)
+			}
+
+			if !got.Equal(decimal.RequireFromString(tt.want)) {
+				t.Errorf("Convert() = %s, want %s", got, tt.want)
+			}
+			if !rate.Equal(decimal.RequireFromString(tt.rate)) {
+				t.Errorf("Convert() rate = %s, want %s", rate, tt.rate)
+			}
+		})
+	}
+}
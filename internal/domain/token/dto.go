@@ -0,0 +1,6 @@
+package token
+
+type RevokeResponse struct {
+	Credential string `json:"credential"`
+	Revoked    int64  `json:"revoked"`
+}
@@ -0,0 +1,13 @@
+package token
+
+import "time"
+
+type Entity struct {
+	ID             string     `db:"id" bson:"_id"`
+	RefreshTokenID string     `db:"refresh_token_id" bson:"refresh_token_id"`
+	Credential     string     `db:"credential" bson:"credential"`
+	TokenType      string     `db:"token_type" bson:"token_type"`
+	CreatedAt      time.Time  `db:"created_at" bson:"created_at"`
+	RotatedAt      *time.Time `db:"rotated_at" bson:"rotated_at"`
+	RevokedAt      *time.Time `db:"revoked_at" bson:"revoked_at"`
+}
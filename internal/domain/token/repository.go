@@ -0,0 +1,13 @@
+package token
+
+import "context"
+
+type Repository interface {
+	Add(ctx context.Context, data Entity) (err error)
+	Get(ctx context.Context, id string) (dest Entity, err error)
+	// Rotate marks the refresh token as used, it returns market.ErrorNotFound
+	// when the pair is unknown, already rotated or revoked
+	Rotate(ctx context.Context, id, refreshTokenID string) (err error)
+	Revoke(ctx context.Context, id string) (err error)
+	RevokeByCredential(ctx context.Context, credential string) (count int64, err error)
+}
@@ -0,0 +1,33 @@
+package user
+
+import "context"
+
+// Actor is the authenticated user on whose behalf the request is made
+type Actor struct {
+	ID         string
+	Credential string
+	Role       string
+}
+
+// IsAdmin reports whether the actor has the admin role
+func (a Actor) IsAdmin() bool {
+	return a.Role == RoleAdmin
+}
+
+// Owns reports whether the actor may change an object of the user, admins own everything
+func (a Actor) Owns(userID string) bool {
+	return a.IsAdmin() || (a.ID != "" && a.ID == userID)
+}
+
+type actor struct{}
+
+// ContextWithActor adds actor to context
+func ContextWithActor(ctx context.Context, a Actor) context.Context {
+	return context.WithValue(ctx, actor{}, a)
+}
+
+// ActorFromContext return actor from context
+func ActorFromContext(ctx context.Context) (a Actor, ok bool) {
+	a, ok = ctx.Value(actor{}).(Actor)
+	return
+}
@@ -0,0 +1,81 @@
+package user
+
+import (
+	"errors"
+	"net/http"
+)
+
+// MinPasswordLength is the shortest password of the users and the admins
+const MinPasswordLength = 8
+
+type Request struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Role     string `json:"role"`
+}
+
+func (s *Request) Bind(r *http.Request) error {
+	if s.Username == "" {
+		return errors.New("username: cannot be blank")
+	}
+
+	if len(s.Password) < MinPasswordLength {
+		return errors.New("password: must be at least 8 characters")
+	}
+
+	if s.Role == "" {
+		s.Role = RoleCustomer
+	}
+
+	if s.Role != RoleCustomer && s.Role != RoleWorker {
+		return errors.New("role: must be customer or worker")
+	}
+
+	return nil
+}
+
+type RoleRequest struct {
+	Role string `json:"role"`
+}
+
+func (s *RoleRequest) Bind(r *http.Request) error {
+	if !IsValidRole(s.Role) {
+		return errors.New("role: must be customer, worker or admin")
+	}
+
+	return nil
+}
+
+type PasswordRequest struct {
+	OldPassword string `json:"oldPassword"`
+	NewPassword string `json:"newPassword"`
+}
+
+func (s *PasswordRequest) Bind(r *http.Request) error {
+	if s.OldPassword == "" {
+		return errors.New("oldPassword: cannot be blank")
+	}
+
+	if len(s.NewPassword) < MinPasswordLength {
+		return errors.New("newPassword: must be at least 8 characters")
+	}
+
+	return nil
+}
+
+type Response struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+	Role     string `json:"role"`
+	IsActive bool   `json:"isActive"`
+}
+
+func ParseFromEntity(data Entity) (res Response) {
+	res = Response{
+		ID:       data.ID,
+		Username: *data.Username,
+		Role:     *data.Role,
+		IsActive: *data.IsActive,
+	}
+	return
+}
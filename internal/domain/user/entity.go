@@ -0,0 +1,9 @@
+package user
+
+type Entity struct {
+	ID           string  `db:"id" bson:"_id"`
+	Username     *string `db:"username" bson:"username"`
+	PasswordHash *string `db:"password_hash" bson:"password_hash"`
+	Role         *string `db:"role" bson:"role"`
+	IsActive     *bool   `db:"is_active" bson:"is_active"`
+}
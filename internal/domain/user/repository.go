@@ -0,0 +1,10 @@
+package user
+
+import "context"
+
+type Repository interface {
+	Add(ctx context.Context, data Entity) (id string, err error)
+	Get(ctx context.Context, id string) (dest Entity, err error)
+	GetByUsername(ctx context.Context, username string) (dest Entity, err error)
+	Update(ctx context.Context, id string, data Entity) (err error)
+}
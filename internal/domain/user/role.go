@@ -0,0 +1,16 @@
+package user
+
+const (
+	RoleCustomer = "customer"
+	RoleWorker   = "worker"
+	RoleAdmin    = "admin"
+)
+
+// IsValidRole reports whether the role is known
+func IsValidRole(role string) bool {
+	switch role {
+	case RoleCustomer, RoleWorker, RoleAdmin:
+		return true
+	}
+	return false
+}
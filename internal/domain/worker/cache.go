@@ -0,0 +1,14 @@
+package worker
+
+import (
+	"context"
+	"exchanger/pkg/market"
+)
+
+// Cache reads the workers through to the repository, the worker missing in the cache is read from it and cached
+type Cache interface {
+	Get(ctx context.Context, id string) (dest Entity, err error)
+	// Delete drops the worker from the cache once it has changed
+	Delete(ctx context.Context, id string) (err error)
+	Stats() market.CacheStats
+}
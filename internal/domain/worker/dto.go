@@ -2,9 +2,21 @@ package worker
 
 import (
 	"errors"
+	"exchanger/pkg/market"
 	"net/http"
+	"reflect"
+	"time"
 )
 
+// Fields are the request fields the list can be sorted and filtered by
+var Fields = map[string]market.Field{
+	"id":          {Column: "id", Kind: reflect.String},
+	"fullname":    {Column: "full_name", Kind: reflect.String},
+	"description": {Column: "description", Kind: reflect.String},
+	"position":    {Column: "position", Kind: reflect.String},
+	"userId":      {Column: "user_id", Kind: reflect.String},
+}
+
 type Request struct {
 	FullName    string `json:"fullname"`
 	Pseudonym   string `json:"pseudonym"`
@@ -32,12 +44,53 @@ func (s *Request) Bind(r *http.Request) error {
 	return nil
 }
 
+// Patch returns the request as the patch setting every field
+func (s Request) Patch() Patch {
+	return Patch{
+		FullName:    &s.FullName,
+		Pseudonym:   &s.Pseudonym,
+		Description: &s.Description,
+		Position:    &s.Position,
+	}
+}
+
+// Patch is the merge patch of the worker, the fields it leaves nil are kept
+type Patch struct {
+	FullName    *string `json:"fullname"`
+	Pseudonym   *string `json:"pseudonym"`
+	Description *string `json:"description"`
+	Position    *string `json:"position"`
+}
+
+func (s *Patch) Bind(r *http.Request) error {
+	if s.FullName != nil && *s.FullName == "" {
+		return errors.New("fullname: cannot be blank")
+	}
+
+	if s.Pseudonym != nil && *s.Pseudonym == "" {
+		return errors.New("pseudonym: cannot be blank")
+	}
+
+	if s.Description != nil && *s.Description == "" {
+		return errors.New("description: cannot be blank")
+	}
+
+	if s.Position != nil && *s.Position == "" {
+		return errors.New("position: cannot be blank")
+	}
+
+	return nil
+}
+
 type Response struct {
-	ID          string `json:"id"`
-	FullName    string `json:"fullname"`
-	Pseudonym   string `json:"pseudonym"`
-	Description string `json:"description"`
-	Position    string `json:"position"`
+	ID          string     `json:"id"`
+	FullName    string     `json:"fullname"`
+	Pseudonym   string     `json:"pseudonym"`
+	Description string     `json:"description"`
+	Position    string     `json:"position"`
+	UserID      string     `json:"userId"`
+	Version     int        `json:"version"`
+	DeletedAt   *time.Time `json:"deletedAt,omitempty"`
 }
 
 func ParseFromEntity(data Entity) (res Response) {
@@ -45,8 +98,11 @@ func ParseFromEntity(data Entity) (res Response) {
 		ID:          data.ID,
 		FullName:    *data.FullName,
 		Pseudonym:   *data.Pseudonym,
-		Description: *data.Position,
+		Description: *data.Description,
 		Position:    *data.Position,
+		UserID:      data.UserID,
+		Version:     data.Version,
+		DeletedAt:   data.DeletedAt,
 	}
 	return
 }
@@ -1,9 +1,14 @@
 package worker
 
+import "time"
+
 type Entity struct {
-	ID          string  `db:"id" bson:"_id"`
-	FullName    *string `db:"full_name" bson:"full_name"`
-	Pseudonym   *string `db:"pseudonym" bson:"pseudonym"`
-	Description *string `db:"description" bson:"description"`
-	Position    *string `db:"position" bson:"position"`
+	ID          string     `db:"id" bson:"_id"`
+	FullName    *string    `db:"full_name" bson:"full_name"`
+	Pseudonym   *string    `db:"pseudonym" bson:"pseudonym"`
+	Description *string    `db:"description" bson:"description"`
+	Position    *string    `db:"position" bson:"position"`
+	UserID      string     `db:"user_id" bson:"user_id"`
+	Version     int        `db:"version" bson:"version"`
+	DeletedAt   *time.Time `db:"deleted_at" bson:"deleted_at"`
 }
@@ -0,0 +1,114 @@
+package grpc
+
+import (
+	"context"
+	"exchanger/internal/domain/customer"
+	"exchanger/internal/domain/user"
+	"exchanger/internal/service/hiring"
+	"exchanger/pkg/pb"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type CustomerServer struct {
+	pb.UnimplementedCustomerServiceServer
+
+	hiringService *hiring.Service
+}
+
+func NewCustomerServer(s *hiring.Service) *CustomerServer {
+	return &CustomerServer{hiringService: s}
+}
+
+func (s *CustomerServer) ListCustomers(ctx context.Context, req *pb.ListRequest) (*pb.ListCustomersResponse, error) {
+	q, err := parseQuery(req, customer.Fields)
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	res, page, err := s.hiringService.ListCustomers(ctx, q)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	dest := &pb.ListCustomersResponse{Page: toPage(page)}
+	for _, object := range res {
+		dest.Customers = append(dest.Customers, toCustomer(object))
+	}
+
+	return dest, nil
+}
+
+func (s *CustomerServer) GetCustomer(ctx context.Context, req *pb.GetRequest) (*pb.Customer, error) {
+	res, err := s.hiringService.GetCustomer(ctx, req.GetId())
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return toCustomer(res), nil
+}
+
+func (s *CustomerServer) AddCustomer(ctx context.Context, req *pb.CustomerRequest) (*pb.Customer, error) {
+	if err := requireRole(ctx, user.RoleCustomer, user.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	dest := parseCustomerRequest(req)
+	if err := dest.Bind(nil); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	res, err := s.hiringService.AddCustomer(ctx, dest)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return toCustomer(res), nil
+}
+
+func (s *CustomerServer) UpdateCustomer(ctx context.Context, req *pb.UpdateCustomerRequest) (*emptypb.Empty, error) {
+	dest := parseCustomerRequest(req.GetCustomer())
+	if err := dest.Bind(nil); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	version, err := requireVersion(req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.hiringService.UpdateCustomer(ctx, req.GetId(), dest, version); err != nil {
+		return nil, statusError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *CustomerServer) DeleteCustomer(ctx context.Context, req *pb.DeleteRequest) (*emptypb.Empty, error) {
+	version, err := requireVersion(req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.hiringService.DeleteCustomer(ctx, req.GetId(), version); err != nil {
+		return nil, statusError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func parseCustomerRequest(req *pb.CustomerRequest) customer.Request {
+	return customer.Request{
+		FullName:  req.GetFullName(),
+		Pseudonym: req.GetPseudonym(),
+	}
+}
+
+func toCustomer(res customer.Response) *pb.Customer {
+	return &pb.Customer{
+		Id:        res.ID,
+		FullName:  res.FullName,
+		Pseudonym: res.Pseudonym,
+		UserId:    res.UserID,
+		Version:   int64(res.Version),
+	}
+}
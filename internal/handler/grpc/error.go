@@ -0,0 +1,46 @@
+package grpc
+
+import (
+	"errors"
+	"exchanger/internal/service/hiring"
+	"exchanger/pkg/market"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// statusError returns the status of the service error, the codes follow the statuses of the HTTP handlers
+func statusError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, market.ErrorNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, market.ErrorForbidden):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, market.ErrorConflict):
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, hiring.ErrorHireLocked), errors.Is(err, hiring.ErrorPaymentExists):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, hiring.ErrorUnknownCurrency):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, hiring.ErrorCurrencyDisabled), errors.Is(err, hiring.ErrorRatesUnavailable):
+		return status.Error(codes.Unavailable, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+// requireVersion returns the version the change is made for, the request missing it is refused
+// the way the HTTP handlers refuse the one without If-Match
+func requireVersion(version int64) (int, error) {
+	if version < 1 {
+		return 0, status.Error(codes.FailedPrecondition, "version: is required")
+	}
+
+	return int(version), nil
+}
+
+// invalidArgument returns the status of the request that didn't pass the validation
+func invalidArgument(err error) error {
+	return status.Error(codes.InvalidArgument, err.Error())
+}
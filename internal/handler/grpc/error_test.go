@@ -0,0 +1,98 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"exchanger/internal/domain/user"
+	"exchanger/internal/service/hiring"
+	"exchanger/pkg/market"
+	"fmt"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestStatusError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want codes.Code
+	}{
+		{err: nil, want: codes.OK},
+		{err: market.ErrorNotFound, want: codes.NotFound},
+		{err: market.ErrorForbidden, want: codes.PermissionDenied},
+		{err: market.ErrorConflict, want: codes.Aborted},
+		{err: hiring.ErrorHireLocked, want: codes.FailedPrecondition},
+		{err: hiring.ErrorPaymentExists, want: codes.FailedPrecondition},
+		{err: hiring.ErrorUnknownCurrency, want: codes.InvalidArgument},
+		{err: hiring.ErrorCurrencyDisabled, want: codes.Unavailable},
+		{err: hiring.ErrorRatesUnavailable, want: codes.Unavailable},
+		{err: fmt.Errorf("get: %w", market.ErrorNotFound), want: codes.NotFound},
+		{err: errors.New("connection refused"), want: codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.err), func(t *testing.T) {
+			err := statusError(tt.err)
+			if code := status.Code(err); code != tt.want {
+				t.Errorf("statusError() code = %v, want %v", code, tt.want)
+			}
+			if tt.err != nil && status.Convert(err).Message() != tt.err.Error() {
+				t.Errorf("statusError() message = %q, want %q", status.Convert(err).Message(), tt.err.Error())
+			}
+		})
+	}
+}
+
+func TestRequireVersion(t *testing.T) {
+	tests := []struct {
+		version int64
+		want    int
+		code    codes.Code
+	}{
+		{version: -1, code: codes.FailedPrecondition},
+		{version: 0, code: codes.FailedPrecondition},
+		{version: 1, want: 1},
+		{version: 7, want: 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.version), func(t *testing.T) {
+			version, err := requireVersion(tt.version)
+			if code := status.Code(err); code != tt.code {
+				t.Fatalf("requireVersion() code = %v, want %v", code, tt.code)
+			}
+			if version != tt.want {
+				t.Errorf("requireVersion() = %d, want %d", version, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequireRole(t *testing.T) {
+	tests := []struct {
+		name  string
+		actor *user.Actor
+		roles []string
+		want  codes.Code
+	}{
+		{name: "allowed", actor: &user.Actor{Role: user.RoleCustomer}, roles: []string{user.RoleCustomer, user.RoleAdmin}, want: codes.OK},
+		{name: "admin", actor: &user.Actor{Role: user.RoleAdmin}, roles: []string{user.RoleCustomer, user.RoleAdmin}, want: codes.OK},
+		{name: "other role", actor: &user.Actor{Role: user.RoleWorker}, roles: []string{user.RoleCustomer, user.RoleAdmin}, want: codes.PermissionDenied},
+		{name: "no actor", roles: []string{user.RoleCustomer}, want: codes.PermissionDenied},
+		{name: "no roles", actor: &user.Actor{Role: user.RoleAdmin}, want: codes.PermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.actor != nil {
+				ctx = user.ContextWithActor(ctx, *tt.actor)
+			}
+
+			if code := status.Code(requireRole(ctx, tt.roles...)); code != tt.want {
+				t.Errorf("requireRole() code = %v, want %v", code, tt.want)
+			}
+		})
+	}
+}
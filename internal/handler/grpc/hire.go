@@ -0,0 +1,165 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"exchanger/internal/domain/hire"
+	"exchanger/internal/domain/user"
+	"exchanger/internal/service/hiring"
+	"exchanger/pkg/pb"
+	"github.com/shopspring/decimal"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type HireServer struct {
+	pb.UnimplementedHireServiceServer
+
+	hiringService *hiring.Service
+}
+
+func NewHireServer(s *hiring.Service) *HireServer {
+	return &HireServer{hiringService: s}
+}
+
+func (s *HireServer) ListHires(ctx context.Context, req *pb.ListHiresRequest) (*pb.ListHiresResponse, error) {
+	q, err := parseQuery(req.GetQuery(), hire.Fields)
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	currency, err := parseCurrency(req.GetCurrency())
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	res, page, err := s.hiringService.ListHires(ctx, q, currency)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	dest := &pb.ListHiresResponse{Page: toPage(page)}
+	for _, object := range res {
+		dest.Hires = append(dest.Hires, toHire(object))
+	}
+
+	return dest, nil
+}
+
+func (s *HireServer) GetHire(ctx context.Context, req *pb.GetHireRequest) (*pb.Hire, error) {
+	currency, err := parseCurrency(req.GetCurrency())
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	res, err := s.hiringService.GetHire(ctx, req.GetId(), currency)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return toHire(res), nil
+}
+
+func (s *HireServer) AddHire(ctx context.Context, req *pb.HireRequest) (*pb.Hire, error) {
+	if err := requireRole(ctx, user.RoleCustomer, user.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	dest, err := parseHireRequest(req)
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	res, err := s.hiringService.AddHire(ctx, dest)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return toHire(res), nil
+}
+
+func (s *HireServer) UpdateHire(ctx context.Context, req *pb.UpdateHireRequest) (*emptypb.Empty, error) {
+	dest, err := parseHireRequest(req.GetHire())
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	version, err := requireVersion(req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.hiringService.UpdateHire(ctx, req.GetId(), dest, version); err != nil {
+		return nil, statusError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *HireServer) DeleteHire(ctx context.Context, req *pb.DeleteRequest) (*emptypb.Empty, error) {
+	version, err := requireVersion(req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.hiringService.DeleteHire(ctx, req.GetId(), version); err != nil {
+		return nil, statusError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// parseHireRequest reads the decimal amount of the hire and validates the request as the HTTP handlers do
+func parseHireRequest(req *pb.HireRequest) (dest hire.Request, err error) {
+	dest = hire.Request{
+		JobName:     req.GetJobName(),
+		Currency:    req.GetCurrency(),
+		Description: req.GetDescription(),
+		Position:    req.GetPosition(),
+		CustomerID:  req.GetCustomerId(),
+	}
+
+	if req.GetAmount() != "" {
+		if dest.Amount, err = decimal.NewFromString(req.GetAmount()); err != nil {
+			return dest, errors.New("amount: must be a number")
+		}
+	}
+
+	err = dest.Bind(nil)
+
+	return
+}
+
+// parseCurrency returns the ISO 4217 code to convert the budgets into, the budgets aren't converted without it
+func parseCurrency(value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+
+	return hire.ParseCurrency(value)
+}
+
+func toHire(res hire.Response) *pb.Hire {
+	dest := &pb.Hire{
+		Id:          res.ID,
+		JobName:     res.JobName,
+		Amount:      res.Amount.String(),
+		Currency:    res.Currency,
+		Description: res.Description,
+		Position:    res.Position,
+		CustomerId:  res.CustomerID,
+		Status:      res.Status,
+		Version:     int64(res.Version),
+	}
+
+	if res.Converted != nil {
+		dest.Converted = &pb.Conversion{
+			Amount:   res.Converted.Amount.String(),
+			Currency: res.Converted.Currency,
+			Rate:     res.Converted.Rate.String(),
+			RateDate: res.Converted.RateDate,
+			Stale:    res.Converted.Stale,
+		}
+	}
+
+	return dest
+}
@@ -0,0 +1,91 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"exchanger/internal/domain/audit"
+	"exchanger/internal/domain/user"
+	"exchanger/internal/service/auth"
+	"exchanger/pkg/market"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Authenticate verifies the bearer token of the "authorization" metadata and adds the actor it's issued to
+// to the context of the call, the token is checked by the auth service the same way for HTTP.
+// The "x-request-id" metadata is added as well for the changes to be audited by
+func Authenticate(s *auth.Service) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := authenticate(ctx, s)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// AuthenticateStream verifies the token of the stream calls the way Authenticate does for the unary ones,
+// so that no stream service such as the reflection is left open to the clients
+func AuthenticateStream(s *auth.Service) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(ss.Context(), s)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authenticatedStream is the stream of the call with the actor in its context
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+func authenticate(ctx context.Context, s *auth.Service) (context.Context, error) {
+	var header, requestID string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("authorization"); len(values) > 0 {
+			header = values[0]
+		}
+		if values := md.Get("x-request-id"); len(values) > 0 {
+			requestID = values[0]
+		}
+	}
+
+	_, actor, err := s.Authenticate(ctx, header)
+	if err != nil {
+		switch {
+		case errors.Is(err, auth.ErrorInvalidToken), errors.Is(err, auth.ErrorTokenExpired), errors.Is(err, auth.ErrorTokenRevoked):
+			return ctx, status.Error(codes.Unauthenticated, err.Error())
+		default:
+			return ctx, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	ctx = user.ContextWithActor(ctx, actor)
+	ctx = audit.ContextWithRequestID(ctx, requestID)
+
+	return ctx, nil
+}
+
+// requireRole allows the call only for the actors with one of the roles, it must run after Authenticate
+func requireRole(ctx context.Context, roles ...string) error {
+	actor, _ := user.ActorFromContext(ctx)
+
+	for _, role := range roles {
+		if actor.Role == role {
+			return nil
+		}
+	}
+
+	return status.Error(codes.PermissionDenied, market.ErrorForbidden.Error())
+}
@@ -0,0 +1,208 @@
+package grpc
+
+import (
+	"context"
+	"exchanger/internal/domain/user"
+	"exchanger/internal/repository"
+	"exchanger/internal/service/auth"
+	"exchanger/internal/service/hiring"
+	"exchanger/pkg/pb"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/go-chi/oauth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const salt = "salt"
+
+// newMemoryRepositories returns the repositories of a fresh memory store
+func newMemoryRepositories(t *testing.T) *repository.Repository {
+	t.Helper()
+
+	repositories, err := repository.New(repository.WithMemoryStore())
+	if err != nil {
+		t.Fatalf("repository.New() error = %v", err)
+	}
+
+	return repositories
+}
+
+// newAuthService returns the auth service over the repositories with the tokens encrypted with salt
+func newAuthService(t *testing.T, r *repository.Repository) *auth.Service {
+	t.Helper()
+
+	s, err := auth.New(
+		auth.WithUserRepository(r.User),
+		auth.WithClientRepository(r.Client),
+		auth.WithTokenRepository(r.Token),
+		auth.WithTokenSalt(salt))
+	if err != nil {
+		t.Fatalf("auth.New() error = %v", err)
+	}
+
+	return s
+}
+
+// issueToken stores the token of a new user in the role and returns its authorization header,
+// the token was created the age ago and lasts an hour
+func issueToken(t *testing.T, r *repository.Repository, s *auth.Service, id, role string, age time.Duration) string {
+	t.Helper()
+
+	userID, err := r.User.Add(context.Background(), user.Entity{Username: &id, Role: &role})
+	if err != nil {
+		t.Fatalf("User.Add() error = %v", err)
+	}
+
+	if err = s.StoreTokenID(oauth.UserToken, id, id, id+"-refresh"); err != nil {
+		t.Fatalf("StoreTokenID() error = %v", err)
+	}
+
+	token, err := oauth.NewTokenProvider(oauth.NewSHA256RC4TokenSecurityProvider([]byte(salt))).CryptToken(&oauth.Token{
+		ID:           id,
+		CreationDate: time.Now().UTC().Add(-age),
+		ExpiresIn:    time.Hour,
+		Credential:   id,
+		Claims:       map[string]string{"user_id": userID, "token_id": id},
+		TokenType:    oauth.UserToken,
+	})
+	if err != nil {
+		t.Fatalf("CryptToken() error = %v", err)
+	}
+
+	return "Bearer " + token
+}
+
+// dial serves the hire service and the reflection behind the interceptors and returns the connection to them
+func dial(t *testing.T, r *repository.Repository, s *auth.Service) *grpc.ClientConn {
+	t.Helper()
+
+	hiringService, err := hiring.New(hiring.WithHireRepository(r.Hire))
+	if err != nil {
+		t.Fatalf("hiring.New() error = %v", err)
+	}
+
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(Authenticate(s)),
+		grpc.StreamInterceptor(AuthenticateStream(s)))
+	pb.RegisterHireServiceServer(server, NewHireServer(hiringService))
+	reflection.Register(server)
+
+	listener := bufconn.Listen(1 << 20)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("grpc.Dial() error = %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
+
+// authTests are the tokens every call is checked with, the unauthenticated ones never reach the service
+func authTests(t *testing.T, r *repository.Repository, s *auth.Service) []struct {
+	name   string
+	header string
+	want   codes.Code
+} {
+	valid := issueToken(t, r, s, "valid", user.RoleAdmin, 0)
+	expired := issueToken(t, r, s, "expired", user.RoleAdmin, 2*time.Hour)
+	revoked := issueToken(t, r, s, "revoked", user.RoleAdmin, 0)
+	if err := s.Logout(context.Background(), "revoked"); err != nil {
+		t.Fatalf("Logout() error = %v", err)
+	}
+
+	return []struct {
+		name   string
+		header string
+		want   codes.Code
+	}{
+		{name: "missing", want: codes.Unauthenticated},
+		{name: "malformed", header: "Bearer nonsense", want: codes.Unauthenticated},
+		{name: "expired", header: expired, want: codes.Unauthenticated},
+		{name: "revoked", header: revoked, want: codes.Unauthenticated},
+		{name: "valid", header: valid, want: codes.OK},
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	r := newMemoryRepositories(t)
+	s := newAuthService(t, r)
+	client := pb.NewHireServiceClient(dial(t, r, s))
+
+	for _, tt := range authTests(t, r, s) {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.header != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, "authorization", tt.header)
+			}
+
+			// the authenticated call reaches the service and finds no hire
+			want := tt.want
+			if want == codes.OK {
+				want = codes.NotFound
+			}
+
+			_, err := client.GetHire(ctx, &pb.GetHireRequest{Id: "missing"})
+			if code := status.Code(err); code != want {
+				t.Errorf("GetHire() code = %v, want %v, error = %v", code, want, err)
+			}
+		})
+	}
+}
+
+func TestAuthenticateStream(t *testing.T) {
+	r := newMemoryRepositories(t)
+	s := newAuthService(t, r)
+	client := reflectionpb.NewServerReflectionClient(dial(t, r, s))
+
+	for _, tt := range authTests(t, r, s) {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.header != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, "authorization", tt.header)
+			}
+
+			stream, err := client.ServerReflectionInfo(ctx)
+			if err != nil {
+				t.Fatalf("ServerReflectionInfo() error = %v", err)
+			}
+			if err = stream.Send(&reflectionpb.ServerReflectionRequest{
+				MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+			}); err != nil {
+				t.Fatalf("Send() error = %v", err)
+			}
+
+			_, err = stream.Recv()
+			if code := status.Code(err); code != tt.want {
+				t.Errorf("Recv() code = %v, want %v, error = %v", code, tt.want, err)
+			}
+		})
+	}
+}
+
+func TestAuthenticateRole(t *testing.T) {
+	r := newMemoryRepositories(t)
+	s := newAuthService(t, r)
+	client := pb.NewHireServiceClient(dial(t, r, s))
+
+	// the worker is authenticated but isn't allowed to add a hire
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", issueToken(t, r, s, "worker", user.RoleWorker, 0))
+	if _, err := client.AddHire(ctx, &pb.HireRequest{}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("AddHire() code = %v, want %v, error = %v", status.Code(err), codes.PermissionDenied, err)
+	}
+}
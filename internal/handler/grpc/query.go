@@ -0,0 +1,47 @@
+package grpc
+
+import (
+	"exchanger/pkg/market"
+	"exchanger/pkg/pb"
+	"net/url"
+	"strconv"
+)
+
+// parseQuery reads the list query of the request the same way as the query params of the HTTP lists,
+// the filters that aren't fields of the list are ignored
+func parseQuery(req *pb.ListRequest, fields map[string]market.Field) (market.Query, error) {
+	params := make(url.Values)
+
+	for key, value := range req.GetFilters() {
+		if _, ok := fields[key]; ok {
+			params.Set(key, value)
+		}
+	}
+
+	if req.GetLimit() != 0 {
+		params.Set("limit", strconv.Itoa(int(req.GetLimit())))
+	}
+	if req.GetOffset() != 0 {
+		params.Set("offset", strconv.Itoa(int(req.GetOffset())))
+	}
+	if req.GetCursor() != "" {
+		params.Set("cursor", req.GetCursor())
+	}
+	if req.GetSort() != "" {
+		params.Set("sort", req.GetSort())
+	}
+	if req.GetOrder() != "" {
+		params.Set("order", req.GetOrder())
+	}
+
+	return market.ParseQuery(params, fields)
+}
+
+func toPage(page market.Page) *pb.Page {
+	return &pb.Page{
+		Total:      int64(page.Total),
+		Limit:      int32(page.Limit),
+		Offset:     int32(page.Offset),
+		NextCursor: page.NextCursor,
+	}
+}
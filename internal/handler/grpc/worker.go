@@ -0,0 +1,118 @@
+package grpc
+
+import (
+	"context"
+	"exchanger/internal/domain/user"
+	"exchanger/internal/domain/worker"
+	"exchanger/internal/service/hiring"
+	"exchanger/pkg/pb"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type WorkerServer struct {
+	pb.UnimplementedWorkerServiceServer
+
+	hiringService *hiring.Service
+}
+
+func NewWorkerServer(s *hiring.Service) *WorkerServer {
+	return &WorkerServer{hiringService: s}
+}
+
+func (s *WorkerServer) ListWorkers(ctx context.Context, req *pb.ListRequest) (*pb.ListWorkersResponse, error) {
+	q, err := parseQuery(req, worker.Fields)
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	res, page, err := s.hiringService.ListWorkers(ctx, q)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	dest := &pb.ListWorkersResponse{Page: toPage(page)}
+	for _, object := range res {
+		dest.Workers = append(dest.Workers, toWorker(object))
+	}
+
+	return dest, nil
+}
+
+func (s *WorkerServer) GetWorker(ctx context.Context, req *pb.GetRequest) (*pb.Worker, error) {
+	res, err := s.hiringService.GetWorker(ctx, req.GetId())
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return toWorker(res), nil
+}
+
+func (s *WorkerServer) AddWorker(ctx context.Context, req *pb.WorkerRequest) (*pb.Worker, error) {
+	if err := requireRole(ctx, user.RoleWorker, user.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	dest := parseWorkerRequest(req)
+	if err := dest.Bind(nil); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	res, err := s.hiringService.AddWorker(ctx, dest)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return toWorker(res), nil
+}
+
+func (s *WorkerServer) UpdateWorker(ctx context.Context, req *pb.UpdateWorkerRequest) (*emptypb.Empty, error) {
+	dest := parseWorkerRequest(req.GetWorker())
+	if err := dest.Bind(nil); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	version, err := requireVersion(req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.hiringService.UpdateWorker(ctx, req.GetId(), dest, version); err != nil {
+		return nil, statusError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *WorkerServer) DeleteWorker(ctx context.Context, req *pb.DeleteRequest) (*emptypb.Empty, error) {
+	version, err := requireVersion(req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.hiringService.DeleteWorker(ctx, req.GetId(), version); err != nil {
+		return nil, statusError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func parseWorkerRequest(req *pb.WorkerRequest) worker.Request {
+	return worker.Request{
+		FullName:    req.GetFullName(),
+		Pseudonym:   req.GetPseudonym(),
+		Description: req.GetDescription(),
+		Position:    req.GetPosition(),
+	}
+}
+
+func toWorker(res worker.Response) *pb.Worker {
+	return &pb.Worker{
+		Id:          res.ID,
+		FullName:    res.FullName,
+		Pseudonym:   res.Pseudonym,
+		Description: res.Description,
+		Position:    res.Position,
+		UserId:      res.UserID,
+		Version:     int64(res.Version),
+	}
+}
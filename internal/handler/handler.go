@@ -3,20 +3,28 @@ package handler
 import (
 	"exchanger/docs"
 	"exchanger/internal/config"
+	"exchanger/internal/domain/user"
+	"exchanger/internal/handler/grpc"
 	"exchanger/internal/handler/http"
 	"exchanger/internal/service/auth"
+	"exchanger/internal/service/exchange"
 	"exchanger/internal/service/hiring"
+	"exchanger/pkg/pb"
 	"exchanger/pkg/server/router"
+	"expvar"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/oauth"
 	httpSwagger "github.com/swaggo/http-swagger/v2"
+	rpc "google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
 )
 
 type Dependencies struct {
-	Configs       config.Configs
-	AuthService   *auth.Service
-	HiringService *hiring.Service
+	Configs         config.Configs
+	AuthService     *auth.Service
+	HiringService   *hiring.Service
+	ExchangeService *exchange.Service
 }
 
 // Configuration is an alias for a function that will take in a pointer to a Handler and modify it
@@ -27,6 +35,7 @@ type Handler struct {
 	dependencies Dependencies
 
 	HTTP *chi.Mux
+	GRPC *rpc.Server
 }
 
 // New takes a variable amount of Configuration functions and returns a new Handler
@@ -68,21 +77,69 @@ func WithHTTPHandler() Configuration {
 		h.HTTP.Post("/token", authHandler.UserCredentials)
 		h.HTTP.Post("/auth", authHandler.ClientCredentials)
 
+		// Init account handlers
+		userHandler := http.NewUserHandler(h.dependencies.AuthService)
+		clientHandler := http.NewClientHandler(h.dependencies.AuthService)
+		tokenHandler := http.NewTokenHandler(h.dependencies.AuthService)
+
+		h.HTTP.Mount("/register", userHandler.PublicRoutes())
+
 		// Init service handlers
 		customerHandler := http.NewCustomerHandler(h.dependencies.HiringService)
 		hireHandler := http.NewHireHandler(h.dependencies.HiringService)
 		workerHandler := http.NewWorkerService(h.dependencies.HiringService)
+		paymentHandler := http.NewPaymentHandler(h.dependencies.HiringService)
+		auditHandler := http.NewAuditHandler(h.dependencies.HiringService)
+		rateHandler := http.NewRateHandler(h.dependencies.ExchangeService)
+
+		// the provider callbacks can't carry a token, they are verified by the service
+		h.HTTP.Post("/callbacks/epay", paymentHandler.Callback)
 
 		h.HTTP.Route("/", func(r chi.Router) {
 			// use the Bearer Authentication middleware
-			r.Use(oauth.Authorize(h.dependencies.Configs.TOKEN.Salt, nil))
+			r.Use(http.Authenticate(h.dependencies.AuthService))
+
+			r.Post("/logout", tokenHandler.Logout)
 
 			r.Mount("/customers", customerHandler.Routes())
 			r.Mount("/hires", hireHandler.Routes())
 			r.Mount("/workers", workerHandler.Routes())
+			r.Mount("/payments", paymentHandler.Routes())
+			r.Mount("/rates", rateHandler.Routes())
+			r.Mount("/users", userHandler.Routes())
+			r.Mount("/clients", clientHandler.Routes())
+			r.Mount("/audit", auditHandler.Routes())
+
+			r.Route("/admin", func(r chi.Router) {
+				r.Use(http.RequireRole(user.RoleAdmin))
+
+				r.Post("/tokens/{credential}/revoke", tokenHandler.Revoke)
+				r.Put("/users/{id}/role", userHandler.SetRole)
+				r.Get("/metrics", expvar.Handler().ServeHTTP)
+			})
 		})
 
 		return
 	}
+}
+
+// WithGRPCHandler applies a gRPC server with the customer, hire and worker services to the Handler,
+// the calls are authenticated by the same bearer tokens as the HTTP routes. The server reflection
+// is registered only when asked for and is authenticated like any other call
+func WithGRPCHandler(serveReflection bool) Configuration {
+	return func(h *Handler) (err error) {
+		h.GRPC = rpc.NewServer(
+			rpc.UnaryInterceptor(grpc.Authenticate(h.dependencies.AuthService)),
+			rpc.StreamInterceptor(grpc.AuthenticateStream(h.dependencies.AuthService)))
+
+		pb.RegisterCustomerServiceServer(h.GRPC, grpc.NewCustomerServer(h.dependencies.HiringService))
+		pb.RegisterHireServiceServer(h.GRPC, grpc.NewHireServer(h.dependencies.HiringService))
+		pb.RegisterWorkerServiceServer(h.GRPC, grpc.NewWorkerServer(h.dependencies.HiringService))
+
+		if serveReflection {
+			reflection.Register(h.GRPC)
+		}
 
+		return
+	}
 }
@@ -0,0 +1,70 @@
+package http
+
+import (
+	"errors"
+	"exchanger/internal/domain/audit"
+	"exchanger/internal/domain/user"
+	"exchanger/internal/service/hiring"
+	"exchanger/pkg/market"
+	"exchanger/pkg/server/response"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+)
+
+type AuditHandler struct {
+	hiringService *hiring.Service
+}
+
+func NewAuditHandler(s *hiring.Service) *AuditHandler {
+	return &AuditHandler{hiringService: s}
+}
+
+func (h *AuditHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+	r.Use(RequireRole(user.RoleAdmin))
+
+	r.Get("/", h.list)
+
+	return r
+}
+
+// @Summary	list of the changes made to the customer, worker or hire, admins only
+// @Tags		audit
+// @Accept		json
+// @Produce	json
+// @Param		entity	query		string	true	"customer, worker or hire"
+// @Param		id		query		string	true	"id of the entity"
+// @Success	200		{array}		audit.Response
+// @Failure	400		{object}	response.Object
+// @Failure	403		{object}	response.Object
+// @Failure	500		{object}	response.Object
+// @Failure	503		{object}	response.Object
+// @Router		/audit [get]
+func (h *AuditHandler) list(w http.ResponseWriter, r *http.Request) {
+	entity, id := r.URL.Query().Get("entity"), r.URL.Query().Get("id")
+
+	if !audit.IsEntity(entity) {
+		response.BadRequest(w, r, errors.New("entity: must be customer, worker or hire"), nil)
+		return
+	}
+
+	if id == "" {
+		response.BadRequest(w, r, errors.New("id: cannot be blank"), nil)
+		return
+	}
+
+	res, err := h.hiringService.ListAudit(r.Context(), entity, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, market.ErrorForbidden):
+			response.Forbidden(w, r, err)
+		case errors.Is(err, hiring.ErrorAuditDisabled):
+			response.ServiceUnavailable(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	response.OK(w, r, res)
+}
@@ -0,0 +1,89 @@
+package http
+
+import (
+	"errors"
+	"exchanger/internal/domain/client"
+	"exchanger/internal/service/auth"
+	"exchanger/pkg/market"
+	"exchanger/pkg/server/response"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"net/http"
+)
+
+type ClientHandler struct {
+	authService *auth.Service
+}
+
+func NewClientHandler(s *auth.Service) *ClientHandler {
+	return &ClientHandler{authService: s}
+}
+
+func (h *ClientHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Post("/", h.add)
+
+	r.Route("/{id}", func(r chi.Router) {
+		r.Post("/deactivate", h.deactivate)
+	})
+
+	return r
+}
+
+// @Summary	register a new api client of the user, the secret is returned only once
+// @Tags		clients
+// @Accept		json
+// @Produce	json
+// @Param		request	body		client.Request	true	"body param"
+// @Success	200		{object}	client.Response
+// @Failure	400		{object}	response.Object
+// @Failure	403		{object}	response.Object
+// @Failure	500		{object}	response.Object
+// @Router		/clients [post]
+func (h *ClientHandler) add(w http.ResponseWriter, r *http.Request) {
+	req := client.Request{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	res, err := h.authService.AddClient(r.Context(), userFromContext(r), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, market.ErrorForbidden):
+			response.Forbidden(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	deactivate the api client
+// @Tags		clients
+// @Accept		json
+// @Produce	json
+// @Param		id	path	string	true	"path param"
+// @Success	200
+// @Failure	403	{object}	response.Object
+// @Failure	404	{object}	response.Object
+// @Failure	500	{object}	response.Object
+// @Router		/clients/{id}/deactivate [post]
+func (h *ClientHandler) deactivate(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	if err := h.authService.DeactivateClient(r.Context(), id, userFromContext(r)); err != nil {
+		switch {
+		case errors.Is(err, market.ErrorNotFound):
+			response.NotFound(w, r, err)
+		case errors.Is(err, market.ErrorForbidden):
+			response.Forbidden(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+}
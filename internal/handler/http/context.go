@@ -0,0 +1,23 @@
+package http
+
+import (
+	"github.com/go-chi/oauth"
+	"net/http"
+)
+
+// userFromContext returns the username of the bearer token, client tokens have no user
+func userFromContext(r *http.Request) string {
+	if tokenType, _ := r.Context().Value(oauth.TokenTypeContext).(oauth.TokenType); tokenType != oauth.UserToken {
+		return ""
+	}
+	credential, _ := r.Context().Value(oauth.CredentialContext).(string)
+
+	return credential
+}
+
+// tokenIDFromContext returns the id of the bearer token stored in its claims
+func tokenIDFromContext(r *http.Request) string {
+	claims, _ := r.Context().Value(oauth.ClaimsContext).(map[string]string)
+
+	return claims["token_id"]
+}
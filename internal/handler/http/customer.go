@@ -3,6 +3,7 @@ package http
 import (
 	"errors"
 	"exchanger/internal/domain/customer"
+	"exchanger/internal/domain/user"
 	"exchanger/internal/service/hiring"
 	"exchanger/pkg/market"
 	"exchanger/pkg/server/response"
@@ -23,12 +24,14 @@ func (h *CustomerHandler) Routes() chi.Router {
 	r := chi.NewRouter()
 
 	r.Get("/", h.list)
-	r.Post("/", h.add)
+	r.With(RequireRole(user.RoleCustomer, user.RoleAdmin)).Post("/", h.add)
 
 	r.Route("/{id}", func(r chi.Router) {
 		r.Get("/", h.get)
 		r.Put("/", h.update)
+		r.Patch("/", h.patch)
 		r.Delete("/", h.delete)
+		r.With(RequireRole(user.RoleAdmin)).Post("/restore", h.restore)
 	})
 
 	return r
@@ -38,17 +41,37 @@ func (h *CustomerHandler) Routes() chi.Router {
 // @Tags		customers
 // @Accept		json
 // @Produce	json
-// @Success	200			{array}		customer.Response
-// @Failure	500			{object}	response.Object
-// @Router		/customers 	[get]
+// @Param		limit	query		int		false	"page size, 20 by default and 100 at most"
+// @Param		offset	query		int		false	"number of items to skip"
+// @Param		cursor	query		string	false	"next cursor of the previous page"
+// @Param		sort	query		string	false	"field to sort by"
+// @Param		order	query		string	false	"asc or desc"
+// @Param		include_deleted	query	bool	false	"list the deleted ones as well, admins only"
+// @Success	200		{array}		customer.Response
+// @Failure	400		{object}	response.Object
+// @Failure	403	{object}	response.Object
+// @Failure	500		{object}	response.Object
+// @Router		/customers [get]
 func (h *CustomerHandler) list(w http.ResponseWriter, r *http.Request) {
-	res, err := h.hiringService.ListCustomers(r.Context())
+	ctx, err := withDeleted(r)
+	if err != nil {
+		writeDeletedError(w, r, err)
+		return
+	}
+
+	q, err := parseQuery(r, customer.Fields)
+	if err != nil {
+		response.BadRequest(w, r, err, nil)
+		return
+	}
+
+	res, page, err := h.hiringService.ListCustomers(ctx, q)
 	if err != nil {
 		response.InternalServerError(w, r, err)
 		return
 	}
 
-	response.OK(w, r, res)
+	response.OKWithMeta(w, r, res, page)
 }
 
 // @Summary	add a new customer to the repository
@@ -58,6 +81,7 @@ func (h *CustomerHandler) list(w http.ResponseWriter, r *http.Request) {
 // @Param		request	body		customer.Request	true	"body param"
 // @Success	200		{object}	customer.Response
 // @Failure	400		{object}	response.Object
+// @Failure	403		{object}	response.Object
 // @Failure	500		{object}	response.Object
 // @Router		/customers [post]
 func (h *CustomerHandler) add(w http.ResponseWriter, r *http.Request) {
@@ -69,7 +93,14 @@ func (h *CustomerHandler) add(w http.ResponseWriter, r *http.Request) {
 
 	res, err := h.hiringService.AddCustomer(r.Context(), req)
 	if err != nil {
-		response.InternalServerError(w, r, err)
+		switch {
+		case errors.Is(err, market.ErrorNotFound):
+			response.NotFound(w, r, err)
+		case errors.Is(err, market.ErrorForbidden):
+			response.Forbidden(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
 		return
 	}
 	response.OK(w, r, res)
@@ -80,14 +111,23 @@ func (h *CustomerHandler) add(w http.ResponseWriter, r *http.Request) {
 // @Accept		json
 // @Produce	json
 // @Param		id	path		int	true	"path param"
+// @Param		include_deleted	query	bool	false	"get the deleted one as well, admins only"
 // @Success	200	{object}	customer.Response
+// @Header	200	{string}	ETag	"version of the customer"
+// @Failure	403	{object}	response.Object
 // @Failure	404	{object}	response.Object
 // @Failure	500	{object}	response.Object
 // @Router		/customers/{id} [get]
 func (h *CustomerHandler) get(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 
-	res, err := h.hiringService.GetCustomer(r.Context(), id)
+	ctx, err := withDeleted(r)
+	if err != nil {
+		writeDeletedError(w, r, err)
+		return
+	}
+
+	res, err := h.hiringService.GetCustomer(ctx, id)
 	if err != nil {
 		switch {
 		case errors.Is(err, market.ErrorNotFound):
@@ -98,6 +138,7 @@ func (h *CustomerHandler) get(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	setETag(w, res.Version)
 	response.OK(w, r, res)
 }
 
@@ -107,24 +148,84 @@ func (h *CustomerHandler) get(w http.ResponseWriter, r *http.Request) {
 // @Produce	json
 // @Param		id		path	int				true	"path param"
 // @Param		request	body	customer.Request	true	"body param"
+// @Param		If-Match	header	string	true	"ETag of the customer it was read at"
 // @Success	200
 // @Failure	400	{object}	response.Object
+// @Failure	403	{object}	response.Object
 // @Failure	404	{object}	response.Object
+// @Failure	412	{object}	response.Object
+// @Failure	428	{object}	response.Object
 // @Failure	500	{object}	response.Object
 // @Router		/customers/{id} [put]
 func (h *CustomerHandler) update(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 
+	version, err := parseIfMatch(r)
+	if err != nil {
+		writeIfMatchError(w, r, err)
+		return
+	}
+
 	req := customer.Request{}
-	if err := render.Bind(r, &req); err != nil {
+	if err = render.Bind(r, &req); err != nil {
 		response.BadRequest(w, r, err, req)
 		return
 	}
 
-	if err := h.hiringService.UpdateCustomer(r.Context(), id, req); err != nil {
+	if err = h.hiringService.UpdateCustomer(r.Context(), id, req, version); err != nil {
 		switch {
 		case errors.Is(err, market.ErrorNotFound):
 			response.NotFound(w, r, err)
+		case errors.Is(err, market.ErrorConflict):
+			response.PreconditionFailed(w, r, err)
+		case errors.Is(err, market.ErrorForbidden):
+			response.Forbidden(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+}
+
+// @Summary	change the fields of the customer the merge patch sets
+// @Tags		customers
+// @Accept		application/merge-patch+json
+// @Produce	json
+// @Param		id		path	int				true	"path param"
+// @Param		request	body	customer.Patch	true	"body param"
+// @Param		If-Match	header	string	true	"ETag of the customer it was read at"
+// @Success	200
+// @Failure	400	{object}	response.Object
+// @Failure	403	{object}	response.Object
+// @Failure	404	{object}	response.Object
+// @Failure	412	{object}	response.Object
+// @Failure	415	{object}	response.Object
+// @Failure	428	{object}	response.Object
+// @Failure	500	{object}	response.Object
+// @Router		/customers/{id} [patch]
+func (h *CustomerHandler) patch(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	version, err := parseIfMatch(r)
+	if err != nil {
+		writeIfMatchError(w, r, err)
+		return
+	}
+
+	req := customer.Patch{}
+	if err = decodePatch(r, &req); err != nil {
+		writePatchError(w, r, err)
+		return
+	}
+
+	if err = h.hiringService.PatchCustomer(r.Context(), id, req, version); err != nil {
+		switch {
+		case errors.Is(err, market.ErrorNotFound):
+			response.NotFound(w, r, err)
+		case errors.Is(err, market.ErrorConflict):
+			response.PreconditionFailed(w, r, err)
+		case errors.Is(err, market.ErrorForbidden):
+			response.Forbidden(w, r, err)
 		default:
 			response.InternalServerError(w, r, err)
 		}
@@ -137,17 +238,57 @@ func (h *CustomerHandler) update(w http.ResponseWriter, r *http.Request) {
 // @Accept		json
 // @Produce	json
 // @Param		id	path	int	true	"path param"
+// @Param		If-Match	header	string	true	"ETag of the customer it was read at"
 // @Success	200
+// @Failure	403	{object}	response.Object
 // @Failure	404	{object}	response.Object
+// @Failure	412	{object}	response.Object
+// @Failure	428	{object}	response.Object
 // @Failure	500	{object}	response.Object
 // @Router		/customers/{id} [delete]
 func (h *CustomerHandler) delete(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 
-	if err := h.hiringService.DeleteCustomer(r.Context(), id); err != nil {
+	version, err := parseIfMatch(r)
+	if err != nil {
+		writeIfMatchError(w, r, err)
+		return
+	}
+
+	if err = h.hiringService.DeleteCustomer(r.Context(), id, version); err != nil {
+		switch {
+		case errors.Is(err, market.ErrorNotFound):
+			response.NotFound(w, r, err)
+		case errors.Is(err, market.ErrorConflict):
+			response.PreconditionFailed(w, r, err)
+		case errors.Is(err, market.ErrorForbidden):
+			response.Forbidden(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+}
+
+// @Summary	restore the deleted customer
+// @Tags		customers
+// @Accept		json
+// @Produce	json
+// @Param		id	path	string	true	"path param"
+// @Success	200
+// @Failure	403	{object}	response.Object
+// @Failure	404	{object}	response.Object
+// @Failure	500	{object}	response.Object
+// @Router		/customers/{id}/restore [post]
+func (h *CustomerHandler) restore(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	if err := h.hiringService.RestoreCustomer(r.Context(), id); err != nil {
 		switch {
 		case errors.Is(err, market.ErrorNotFound):
 			response.NotFound(w, r, err)
+		case errors.Is(err, market.ErrorForbidden):
+			response.Forbidden(w, r, err)
 		default:
 			response.InternalServerError(w, r, err)
 		}
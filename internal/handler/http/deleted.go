@@ -0,0 +1,46 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"exchanger/internal/domain/user"
+	"exchanger/pkg/market"
+	"exchanger/pkg/server/response"
+	"net/http"
+	"strconv"
+)
+
+// withDeleted returns the context of the request, which asks for the deleted entities as well
+// when include_deleted is set. Only admins see the deleted entities
+func withDeleted(r *http.Request) (ctx context.Context, err error) {
+	ctx = r.Context()
+
+	value := r.URL.Query().Get("include_deleted")
+	if value == "" {
+		return
+	}
+
+	included, err := strconv.ParseBool(value)
+	if err != nil {
+		return ctx, errors.New("include_deleted: must be true or false")
+	}
+
+	if !included {
+		return
+	}
+
+	if actor, ok := user.ActorFromContext(ctx); !ok || !actor.IsAdmin() {
+		return ctx, market.ErrorForbidden
+	}
+
+	return market.WithDeleted(ctx), nil
+}
+
+// writeDeletedError responds with 403 to the deleted entities asked for by a non-admin and with 400 to the malformed flag
+func writeDeletedError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, market.ErrorForbidden) {
+		response.Forbidden(w, r, err)
+		return
+	}
+	response.BadRequest(w, r, err, nil)
+}
@@ -0,0 +1,47 @@
+package http
+
+import (
+	"errors"
+	"exchanger/pkg/server/response"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+var (
+	errorIfMatchRequired = errors.New("If-Match: header is required")
+	errorIfMatchInvalid  = errors.New("If-Match: must be the ETag of the entity")
+)
+
+// setETag writes the version of the entity as its ETag
+func setETag(w http.ResponseWriter, version int) {
+	w.Header().Set("ETag", strconv.Quote(strconv.Itoa(version)))
+}
+
+// parseIfMatch returns the version the change is made for, the "*" matches any version and reads as zero
+func parseIfMatch(r *http.Request) (version int, err error) {
+	value := strings.TrimSpace(r.Header.Get("If-Match"))
+	if value == "" {
+		return 0, errorIfMatchRequired
+	}
+
+	if value == "*" {
+		return 0, nil
+	}
+
+	value = strings.Trim(strings.TrimPrefix(value, "W/"), `"`)
+	if version, err = strconv.Atoi(value); err != nil || version < 1 {
+		return 0, errorIfMatchInvalid
+	}
+
+	return
+}
+
+// writeIfMatchError responds with 428 while the If-Match is missing and with 400 while it's malformed
+func writeIfMatchError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, errorIfMatchRequired) {
+		response.PreconditionRequired(w, r, err)
+		return
+	}
+	response.BadRequest(w, r, err, nil)
+}
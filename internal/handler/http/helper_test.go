@@ -0,0 +1,51 @@
+package http
+
+import (
+	"encoding/json"
+	"exchanger/internal/repository"
+	"io"
+	nethttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newMemoryRepositories returns the repositories of a fresh memory store
+func newMemoryRepositories(t *testing.T) *repository.Repository {
+	t.Helper()
+
+	repositories, err := repository.New(repository.WithMemoryStore())
+	if err != nil {
+		t.Fatalf("repository.New() error = %v", err)
+	}
+
+	return repositories
+}
+
+// serve sends the request to the handler and records the response, the blank body sends none
+func serve(handler nethttp.Handler, method, target, body string) *httptest.ResponseRecorder {
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(method, target, reader))
+
+	return w
+}
+
+// readData decodes the data of the response envelope into out
+func readData(t *testing.T, w *httptest.ResponseRecorder, out any) {
+	t.Helper()
+
+	body := struct {
+		Data json.RawMessage `json:"data"`
+	}{}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v, body = %s", err, w.Body.String())
+	}
+	if err := json.Unmarshal(body.Data, out); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v, body = %s", err, w.Body.String())
+	}
+}
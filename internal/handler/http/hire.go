@@ -1,14 +1,18 @@
 package http
 
 import (
+	"context"
 	"errors"
 	"exchanger/internal/domain/hire"
+	"exchanger/internal/domain/user"
 	"exchanger/internal/service/hiring"
 	"exchanger/pkg/market"
 	"exchanger/pkg/server/response"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
+	"github.com/shopspring/decimal"
 	"net/http"
+	"strings"
 )
 
 type HireHandler struct {
@@ -23,12 +27,25 @@ func (h *HireHandler) Routes() chi.Router {
 	r := chi.NewRouter()
 
 	r.Get("/", h.list)
-	r.Post("/", h.add)
+	r.Get("/search", h.search)
+	r.With(RequireRole(user.RoleCustomer, user.RoleAdmin)).Post("/", h.add)
 
 	r.Route("/{id}", func(r chi.Router) {
 		r.Get("/", h.get)
 		r.Put("/", h.update)
+		r.Patch("/", h.patch)
 		r.Delete("/", h.delete)
+		r.With(RequireRole(user.RoleAdmin)).Post("/restore", h.restore)
+		r.Get("/history", h.history)
+
+		r.Post("/publish", h.publish)
+		r.Post("/complete", h.complete)
+		r.Post("/cancel", h.cancel)
+		r.Post("/dispute", h.dispute)
+		r.With(RequireRole(user.RoleAdmin)).Post("/resolve", h.resolve)
+
+		r.Mount("/proposals", NewProposalHandler(h.hiringService).Routes())
+		r.Mount("/payments", NewPaymentHandler(h.hiringService).HireRoutes())
 	})
 
 	return r
@@ -38,17 +55,84 @@ func (h *HireHandler) Routes() chi.Router {
 // @Tags		hires
 // @Accept		json
 // @Produce	json
+// @Param		limit	query		int		false	"page size, 20 by default and 100 at most"
+// @Param		offset	query		int		false	"number of items to skip"
+// @Param		cursor	query		string	false	"next cursor of the previous page"
+// @Param		sort	query		string	false	"field to sort by"
+// @Param		order		query		string	false	"asc or desc"
+// @Param		currency	query		string	false	"ISO 4217 code to convert the budgets into"
+// @Param		include_deleted	query	bool	false	"list the deleted ones as well, admins only"
 // @Success	200			{array}		hire.Response
+// @Failure	400			{object}	response.Object
+// @Failure	403	{object}	response.Object
 // @Failure	500			{object}	response.Object
-// @Router		/hires 	[get]
+// @Failure	503			{object}	response.Object
+// @Router		/hires [get]
 func (h *HireHandler) list(w http.ResponseWriter, r *http.Request) {
-	res, err := h.hiringService.ListHires(r.Context())
+	ctx, err := withDeleted(r)
+	if err != nil {
+		writeDeletedError(w, r, err)
+		return
+	}
+
+	q, err := parseQuery(r, hire.Fields)
+	if err != nil {
+		response.BadRequest(w, r, err, nil)
+		return
+	}
+
+	currency, err := parseCurrency(r)
+	if err != nil {
+		response.BadRequest(w, r, err, nil)
+		return
+	}
+
+	res, page, err := h.hiringService.ListHires(ctx, q, currency)
+	if err != nil {
+		switch {
+		case errors.Is(err, hiring.ErrorUnknownCurrency):
+			response.BadRequest(w, r, err, nil)
+		case errors.Is(err, hiring.ErrorCurrencyDisabled), errors.Is(err, hiring.ErrorRatesUnavailable):
+			response.ServiceUnavailable(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	response.OKWithMeta(w, r, res, page)
+}
+
+// @Summary	search hires by job name, description and position
+// @Tags		hires
+// @Accept		json
+// @Produce	json
+// @Param		q			query		string	true	"words every one of which starts a word of the hire"
+// @Param		currency	query		string	false	"ISO 4217 code of the hires, required with the amount range"
+// @Param		amountFrom	query		int		false	"minimal amount in the currency"
+// @Param		amountTo	query		int		false	"maximal amount in the currency"
+// @Param		customerid	query		string	false	"customer of the hires"
+// @Param		limit		query		int		false	"page size, 20 by default and 100 at most"
+// @Param		offset		query		int		false	"number of items to skip"
+// @Param		cursor		query		string	false	"next cursor of the previous page"
+// @Success	200			{array}		hire.SearchResponse
+// @Failure	400			{object}	response.Object
+// @Failure	500			{object}	response.Object
+// @Router		/hires/search [get]
+func (h *HireHandler) search(w http.ResponseWriter, r *http.Request) {
+	req, err := parseSearch(r)
+	if err != nil {
+		response.BadRequest(w, r, err, nil)
+		return
+	}
+
+	res, page, err := h.hiringService.SearchHires(r.Context(), req)
 	if err != nil {
 		response.InternalServerError(w, r, err)
 		return
 	}
 
-	response.OK(w, r, res)
+	response.OKWithMeta(w, r, res, page)
 }
 
 // @Summary	add a new hire to the repository
@@ -58,6 +142,7 @@ func (h *HireHandler) list(w http.ResponseWriter, r *http.Request) {
 // @Param		request	body		hire.Request	true	"body param"
 // @Success	200		{object}	hire.Response
 // @Failure	400		{object}	response.Object
+// @Failure	403		{object}	response.Object
 // @Failure	500		{object}	response.Object
 // @Router		/hires [post]
 func (h *HireHandler) add(w http.ResponseWriter, r *http.Request) {
@@ -69,7 +154,14 @@ func (h *HireHandler) add(w http.ResponseWriter, r *http.Request) {
 
 	res, err := h.hiringService.AddHire(r.Context(), req)
 	if err != nil {
-		response.InternalServerError(w, r, err)
+		switch {
+		case errors.Is(err, market.ErrorNotFound):
+			response.NotFound(w, r, err)
+		case errors.Is(err, market.ErrorForbidden):
+			response.Forbidden(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
 		return
 	}
 	response.OK(w, r, res)
@@ -79,25 +171,48 @@ func (h *HireHandler) add(w http.ResponseWriter, r *http.Request) {
 // @Tags		hires
 // @Accept		json
 // @Produce	json
-// @Param		id	path		int	true	"path param"
-// @Success	200	{object}	hire.Response
-// @Failure	404	{object}	response.Object
-// @Failure	500	{object}	response.Object
+// @Param		id			path		int		true	"path param"
+// @Param		currency	query		string	false	"ISO 4217 code to convert the budget into"
+// @Param		include_deleted	query	bool	false	"get the deleted one as well, admins only"
+// @Success	200			{object}	hire.Response
+// @Header	200			{string}	ETag	"version of the hire"
+// @Failure	400			{object}	response.Object
+// @Failure	403	{object}	response.Object
+// @Failure	404			{object}	response.Object
+// @Failure	500			{object}	response.Object
+// @Failure	503			{object}	response.Object
 // @Router		/hires/{id} [get]
 func (h *HireHandler) get(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 
-	res, err := h.hiringService.GetHire(r.Context(), id)
+	ctx, err := withDeleted(r)
+	if err != nil {
+		writeDeletedError(w, r, err)
+		return
+	}
+
+	currency, err := parseCurrency(r)
+	if err != nil {
+		response.BadRequest(w, r, err, nil)
+		return
+	}
+
+	res, err := h.hiringService.GetHire(ctx, id, currency)
 	if err != nil {
 		switch {
 		case errors.Is(err, market.ErrorNotFound):
 			response.NotFound(w, r, err)
+		case errors.Is(err, hiring.ErrorUnknownCurrency):
+			response.BadRequest(w, r, err, nil)
+		case errors.Is(err, hiring.ErrorCurrencyDisabled), errors.Is(err, hiring.ErrorRatesUnavailable):
+			response.ServiceUnavailable(w, r, err)
 		default:
 			response.InternalServerError(w, r, err)
 		}
 		return
 	}
 
+	setETag(w, res.Version)
 	response.OK(w, r, res)
 }
 
@@ -107,24 +222,90 @@ func (h *HireHandler) get(w http.ResponseWriter, r *http.Request) {
 // @Produce	json
 // @Param		id		path	int				true	"path param"
 // @Param		request	body	hire.Request	true	"body param"
+// @Param		If-Match	header	string	true	"ETag of the hire it was read at"
 // @Success	200
 // @Failure	400	{object}	response.Object
+// @Failure	403	{object}	response.Object
 // @Failure	404	{object}	response.Object
+// @Failure	409	{object}	response.Object
+// @Failure	412	{object}	response.Object
+// @Failure	428	{object}	response.Object
 // @Failure	500	{object}	response.Object
 // @Router		/hires/{id} [put]
 func (h *HireHandler) update(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 
+	version, err := parseIfMatch(r)
+	if err != nil {
+		writeIfMatchError(w, r, err)
+		return
+	}
+
 	req := hire.Request{}
-	if err := render.Bind(r, &req); err != nil {
+	if err = render.Bind(r, &req); err != nil {
 		response.BadRequest(w, r, err, req)
 		return
 	}
 
-	if err := h.hiringService.UpdateHire(r.Context(), id, req); err != nil {
+	if err = h.hiringService.UpdateHire(r.Context(), id, req, version); err != nil {
 		switch {
 		case errors.Is(err, market.ErrorNotFound):
 			response.NotFound(w, r, err)
+		case errors.Is(err, market.ErrorConflict):
+			response.PreconditionFailed(w, r, err)
+		case errors.Is(err, market.ErrorForbidden):
+			response.Forbidden(w, r, err)
+		case errors.Is(err, hiring.ErrorHireLocked):
+			response.Conflict(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+}
+
+// @Summary	change the fields of the hire the merge patch sets
+// @Tags		hires
+// @Accept		application/merge-patch+json
+// @Produce	json
+// @Param		id		path	int				true	"path param"
+// @Param		request	body	hire.Patch	true	"body param"
+// @Param		If-Match	header	string	true	"ETag of the hire it was read at"
+// @Success	200
+// @Failure	400	{object}	response.Object
+// @Failure	403	{object}	response.Object
+// @Failure	404	{object}	response.Object
+// @Failure	409	{object}	response.Object
+// @Failure	412	{object}	response.Object
+// @Failure	415	{object}	response.Object
+// @Failure	428	{object}	response.Object
+// @Failure	500	{object}	response.Object
+// @Router		/hires/{id} [patch]
+func (h *HireHandler) patch(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	version, err := parseIfMatch(r)
+	if err != nil {
+		writeIfMatchError(w, r, err)
+		return
+	}
+
+	req := hire.Patch{}
+	if err = decodePatch(r, &req); err != nil {
+		writePatchError(w, r, err)
+		return
+	}
+
+	if err = h.hiringService.PatchHire(r.Context(), id, req, version); err != nil {
+		switch {
+		case errors.Is(err, market.ErrorNotFound):
+			response.NotFound(w, r, err)
+		case errors.Is(err, market.ErrorConflict):
+			response.PreconditionFailed(w, r, err)
+		case errors.Is(err, market.ErrorForbidden):
+			response.Forbidden(w, r, err)
+		case errors.Is(err, hiring.ErrorHireLocked):
+			response.Conflict(w, r, err)
 		default:
 			response.InternalServerError(w, r, err)
 		}
@@ -137,20 +318,247 @@ func (h *HireHandler) update(w http.ResponseWriter, r *http.Request) {
 // @Accept		json
 // @Produce	json
 // @Param		id	path	int	true	"path param"
+// @Param		If-Match	header	string	true	"ETag of the hire it was read at"
 // @Success	200
+// @Failure	403	{object}	response.Object
 // @Failure	404	{object}	response.Object
+// @Failure	409	{object}	response.Object
+// @Failure	412	{object}	response.Object
+// @Failure	428	{object}	response.Object
 // @Failure	500	{object}	response.Object
 // @Router		/hires/{id} [delete]
 func (h *HireHandler) delete(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 
-	if err := h.hiringService.DeleteHire(r.Context(), id); err != nil {
+	version, err := parseIfMatch(r)
+	if err != nil {
+		writeIfMatchError(w, r, err)
+		return
+	}
+
+	if err = h.hiringService.DeleteHire(r.Context(), id, version); err != nil {
+		switch {
+		case errors.Is(err, market.ErrorNotFound):
+			response.NotFound(w, r, err)
+		case errors.Is(err, market.ErrorConflict):
+			response.PreconditionFailed(w, r, err)
+		case errors.Is(err, market.ErrorForbidden):
+			response.Forbidden(w, r, err)
+		case errors.Is(err, hiring.ErrorPaymentExists):
+			response.Conflict(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+}
+
+// @Summary	restore the deleted hire
+// @Tags		hires
+// @Accept		json
+// @Produce	json
+// @Param		id	path	string	true	"path param"
+// @Success	200
+// @Failure	403	{object}	response.Object
+// @Failure	404	{object}	response.Object
+// @Failure	500	{object}	response.Object
+// @Router		/hires/{id}/restore [post]
+func (h *HireHandler) restore(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	if err := h.hiringService.RestoreHire(r.Context(), id); err != nil {
 		switch {
 		case errors.Is(err, market.ErrorNotFound):
 			response.NotFound(w, r, err)
+		case errors.Is(err, market.ErrorForbidden):
+			response.Forbidden(w, r, err)
 		default:
 			response.InternalServerError(w, r, err)
 		}
 		return
 	}
 }
+
+// @Summary	open the draft hire to proposals
+// @Tags		hires
+// @Accept		json
+// @Produce	json
+// @Param		id	path		string	true	"path param"
+// @Success	200	{object}	hire.Response
+// @Failure	403	{object}	response.Object
+// @Failure	404	{object}	response.Object
+// @Failure	409	{object}	response.Object
+// @Failure	500	{object}	response.Object
+// @Router		/hires/{id}/publish [post]
+func (h *HireHandler) publish(w http.ResponseWriter, r *http.Request) {
+	h.transition(w, r, h.hiringService.PublishHire)
+}
+
+// @Summary	mark the hire as completed
+// @Tags		hires
+// @Accept		json
+// @Produce	json
+// @Param		id	path		string	true	"path param"
+// @Success	200	{object}	hire.Response
+// @Failure	403	{object}	response.Object
+// @Failure	404	{object}	response.Object
+// @Failure	409	{object}	response.Object
+// @Failure	500	{object}	response.Object
+// @Router		/hires/{id}/complete [post]
+func (h *HireHandler) complete(w http.ResponseWriter, r *http.Request) {
+	h.transition(w, r, h.hiringService.CompleteHire)
+}
+
+// @Summary	cancel the hire
+// @Tags		hires
+// @Accept		json
+// @Produce	json
+// @Param		id	path		string	true	"path param"
+// @Success	200	{object}	hire.Response
+// @Failure	403	{object}	response.Object
+// @Failure	404	{object}	response.Object
+// @Failure	409	{object}	response.Object
+// @Failure	500	{object}	response.Object
+// @Router		/hires/{id}/cancel [post]
+func (h *HireHandler) cancel(w http.ResponseWriter, r *http.Request) {
+	h.transition(w, r, h.hiringService.CancelHire)
+}
+
+// @Summary	dispute the work on the hire
+// @Tags		hires
+// @Accept		json
+// @Produce	json
+// @Param		id	path		string	true	"path param"
+// @Success	200	{object}	hire.Response
+// @Failure	403	{object}	response.Object
+// @Failure	404	{object}	response.Object
+// @Failure	409	{object}	response.Object
+// @Failure	500	{object}	response.Object
+// @Router		/hires/{id}/dispute [post]
+func (h *HireHandler) dispute(w http.ResponseWriter, r *http.Request) {
+	h.transition(w, r, h.hiringService.DisputeHire)
+}
+
+// @Summary	resolve the dispute and return the hire to work
+// @Tags		hires
+// @Accept		json
+// @Produce	json
+// @Param		id	path		string	true	"path param"
+// @Success	200	{object}	hire.Response
+// @Failure	403	{object}	response.Object
+// @Failure	404	{object}	response.Object
+// @Failure	409	{object}	response.Object
+// @Failure	500	{object}	response.Object
+// @Router		/hires/{id}/resolve [post]
+func (h *HireHandler) resolve(w http.ResponseWriter, r *http.Request) {
+	h.transition(w, r, h.hiringService.ResolveHire)
+}
+
+// transition changes the status of the hire of the path with the service action
+func (h *HireHandler) transition(w http.ResponseWriter, r *http.Request, action func(context.Context, string) (hire.Response, error)) {
+	id := chi.URLParam(r, "id")
+
+	res, err := action(r.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, market.ErrorNotFound):
+			response.NotFound(w, r, err)
+		case errors.Is(err, market.ErrorForbidden):
+			response.Forbidden(w, r, err)
+		case errors.Is(err, hiring.ErrorHireTransition), errors.Is(err, market.ErrorConflict):
+			response.Conflict(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	status changes of the hire
+// @Tags		hires
+// @Accept		json
+// @Produce	json
+// @Param		id	path		string	true	"path param"
+// @Success	200	{array}		hire.HistoryResponse
+// @Failure	403	{object}	response.Object
+// @Failure	404	{object}	response.Object
+// @Failure	500	{object}	response.Object
+// @Router		/hires/{id}/history [get]
+func (h *HireHandler) history(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	res, err := h.hiringService.ListHireHistory(r.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, market.ErrorNotFound):
+			response.NotFound(w, r, err)
+		case errors.Is(err, market.ErrorForbidden):
+			response.Forbidden(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// parseSearch reads the search text, the amount range and the customer of the search request
+func parseSearch(r *http.Request) (req hire.Search, err error) {
+	if req.Query, err = parseQuery(r, nil); err != nil {
+		return
+	}
+
+	values := r.URL.Query()
+
+	req.Text = strings.TrimSpace(values.Get("q"))
+	if len(hire.Terms(req.Text)) == 0 {
+		return req, errors.New("q: cannot be blank")
+	}
+	req.CustomerID = values.Get("customerid")
+
+	if value := values.Get("amountFrom"); value != "" {
+		amount, err := decimal.NewFromString(value)
+		if err != nil {
+			return req, errors.New("amountFrom: must be a number")
+		}
+		req.AmountFrom = &amount
+	}
+
+	if value := values.Get("amountTo"); value != "" {
+		amount, err := decimal.NewFromString(value)
+		if err != nil {
+			return req, errors.New("amountTo: must be a number")
+		}
+		req.AmountTo = &amount
+	}
+
+	if req.AmountFrom != nil && req.AmountTo != nil && req.AmountFrom.GreaterThan(*req.AmountTo) {
+		return req, errors.New("amountTo: cannot be less than amountFrom")
+	}
+
+	if value := values.Get("currency"); value != "" {
+		if req.Currency, err = hire.ParseCurrency(value); err != nil {
+			return
+		}
+	}
+
+	// the amounts of different currencies can't be compared
+	if (req.AmountFrom != nil || req.AmountTo != nil) && req.Currency == "" {
+		return req, errors.New("currency: cannot be blank with amountFrom or amountTo")
+	}
+
+	return
+}
+
+// parseCurrency reads the currency the budgets are converted into, blank when it isn't asked for
+func parseCurrency(r *http.Request) (currency string, err error) {
+	value := r.URL.Query().Get("currency")
+	if value == "" {
+		return
+	}
+
+	return hire.ParseCurrency(value)
+}
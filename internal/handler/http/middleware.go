@@ -0,0 +1,65 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"exchanger/internal/domain/audit"
+	"exchanger/internal/domain/user"
+	"exchanger/internal/service/auth"
+	"exchanger/pkg/market"
+	"exchanger/pkg/server/response"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/oauth"
+	"net/http"
+)
+
+// Authenticate verifies the bearer token of the request and adds the token with the actor it's issued to
+// to the request context, the token is checked by the auth service the same way for gRPC.
+// The id middleware.RequestID gives the request is added as well for the changes to be audited by
+func Authenticate(s *auth.Service) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := r.Header.Get("Authorization")
+
+			token, actor, err := s.Authenticate(r.Context(), header)
+			if err != nil {
+				switch {
+				case errors.Is(err, auth.ErrorInvalidToken), errors.Is(err, auth.ErrorTokenExpired), errors.Is(err, auth.ErrorTokenRevoked):
+					response.Unauthorized(w, r, err)
+				default:
+					response.InternalServerError(w, r, err)
+				}
+				return
+			}
+
+			ctx := r.Context()
+			ctx = context.WithValue(ctx, oauth.CredentialContext, token.Credential)
+			ctx = context.WithValue(ctx, oauth.ClaimsContext, token.Claims)
+			ctx = context.WithValue(ctx, oauth.ScopeContext, token.Scope)
+			ctx = context.WithValue(ctx, oauth.TokenTypeContext, token.TokenType)
+			ctx = context.WithValue(ctx, oauth.AccessTokenContext, header[7:])
+			ctx = user.ContextWithActor(ctx, actor)
+			ctx = audit.ContextWithRequestID(ctx, middleware.GetReqID(ctx))
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// RequireRole allows the request only for the actors with one of the roles, it must run after Authenticate
+func RequireRole(roles ...string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			actor, _ := user.ActorFromContext(r.Context())
+
+			for _, role := range roles {
+				if actor.Role == role {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+
+			response.Forbidden(w, r, market.ErrorForbidden)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package http
+
+import (
+	"errors"
+	"exchanger/pkg/market"
+	"exchanger/pkg/server/response"
+	"github.com/go-chi/render"
+	"mime"
+	"net/http"
+)
+
+const mediaTypeMergePatch = "application/merge-patch+json"
+
+var errorPatchMediaType = errors.New("Content-Type: must be " + mediaTypeMergePatch)
+
+// decodePatch reads the merge patch of the request and validates the fields it sets,
+// the patch sent as plain JSON is read the same way
+func decodePatch(r *http.Request, dest render.Binder) error {
+	if value := r.Header.Get("Content-Type"); value != "" {
+		mediaType, _, err := mime.ParseMediaType(value)
+		if err != nil || mediaType != mediaTypeMergePatch && mediaType != "application/json" {
+			return errorPatchMediaType
+		}
+	}
+
+	if err := market.DecodeMergePatch(r.Body, dest); err != nil {
+		return err
+	}
+
+	return dest.Bind(r)
+}
+
+// writePatchError responds with 415 to the patch of another media type and with 400 to the malformed one
+func writePatchError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, errorPatchMediaType) {
+		response.UnsupportedMediaType(w, r, err)
+		return
+	}
+	response.BadRequest(w, r, err, nil)
+}
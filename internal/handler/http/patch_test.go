@@ -0,0 +1,200 @@
+package http
+
+import (
+	"context"
+	"exchanger/internal/domain/customer"
+	"exchanger/internal/domain/hire"
+	"exchanger/internal/domain/user"
+	"exchanger/internal/domain/worker"
+	"exchanger/internal/service/hiring"
+	"github.com/go-chi/chi/v5"
+	"github.com/shopspring/decimal"
+	nethttp "net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// the repositories below record the updates the patches reach them with
+
+type customerUpdates struct {
+	customer.Repository
+	updates []customer.Entity
+}
+
+func (r *customerUpdates) Update(ctx context.Context, id string, data customer.Entity) error {
+	r.updates = append(r.updates, data)
+	return r.Repository.Update(ctx, id, data)
+}
+
+type workerUpdates struct {
+	worker.Repository
+	updates []worker.Entity
+}
+
+func (r *workerUpdates) Update(ctx context.Context, id string, data worker.Entity) error {
+	r.updates = append(r.updates, data)
+	return r.Repository.Update(ctx, id, data)
+}
+
+type hireUpdates struct {
+	hire.Repository
+	updates []hire.Entity
+}
+
+func (r *hireUpdates) Update(ctx context.Context, id string, data hire.Entity) error {
+	r.updates = append(r.updates, data)
+	return r.Repository.Update(ctx, id, data)
+}
+
+type patchFixture struct {
+	router    chi.Router
+	customers *customerUpdates
+	workers   *workerUpdates
+	hires     *hireUpdates
+
+	customerID, workerID, hireID string
+}
+
+// newPatchFixture serves the customers, the workers and the hires to an admin, one of each is added
+func newPatchFixture(t *testing.T) (f patchFixture) {
+	t.Helper()
+
+	repositories := newMemoryRepositories(t)
+	f.customers = &customerUpdates{Repository: repositories.Customer}
+	f.workers = &workerUpdates{Repository: repositories.Worker}
+	f.hires = &hireUpdates{Repository: repositories.Hire}
+
+	hiringService, err := hiring.New(
+		hiring.WithCustomerRepository(f.customers),
+		hiring.WithWorkerRepository(f.workers),
+		hiring.WithHireRepository(f.hires))
+	if err != nil {
+		t.Fatalf("hiring.New() error = %v", err)
+	}
+
+	ctx := context.Background()
+	name, position, amount, currency := "name", "developer", decimal.NewFromInt(500), "KZT"
+
+	if f.customerID, err = repositories.Customer.Add(ctx, customer.Entity{FullName: &name, Pseudonym: &name}); err != nil {
+		t.Fatalf("Customer.Add() error = %v", err)
+	}
+
+	if f.workerID, err = repositories.Worker.Add(ctx, worker.Entity{FullName: &name, Pseudonym: &name, Description: &name, Position: &position}); err != nil {
+		t.Fatalf("Worker.Add() error = %v", err)
+	}
+
+	f.hireID, err = repositories.Hire.Add(ctx, hire.Entity{
+		JobName:     &name,
+		Amount:      &amount,
+		Currency:    &currency,
+		Description: &name,
+		Position:    &position,
+		CustomerID:  f.customerID,
+		Status:      hire.StatusDraft,
+	})
+	if err != nil {
+		t.Fatalf("Hire.Add() error = %v", err)
+	}
+
+	f.router = chi.NewRouter()
+	f.router.Use(func(next nethttp.Handler) nethttp.Handler {
+		return nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+			ctx := user.ContextWithActor(r.Context(), user.Actor{ID: "admin", Role: user.RoleAdmin})
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	})
+	f.router.Mount("/customers", NewCustomerHandler(hiringService).Routes())
+	f.router.Mount("/workers", NewWorkerService(hiringService).Routes())
+	f.router.Mount("/hires", NewHireHandler(hiringService).Routes())
+
+	return
+}
+
+// patch sends the patch of the media type for the first version, the blank media type sends none
+func (f patchFixture) patch(target, mediaType, body string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(nethttp.MethodPatch, target, strings.NewReader(body))
+	r.Header.Set("If-Match", `"1"`)
+	if mediaType != "" {
+		r.Header.Set("Content-Type", mediaType)
+	}
+
+	w := httptest.NewRecorder()
+	f.router.ServeHTTP(w, r)
+
+	return w
+}
+
+func TestPatchSuppliedFields(t *testing.T) {
+	f := newPatchFixture(t)
+	value := "changed"
+
+	if w := f.patch("/customers/"+f.customerID, mediaTypeMergePatch, `{"pseudonym": "changed"}`); w.Code != nethttp.StatusOK {
+		t.Fatalf("customer status = %d, want %d, body = %s", w.Code, nethttp.StatusOK, w.Body.String())
+	}
+	if want := []customer.Entity{{Pseudonym: &value, Version: 1}}; !reflect.DeepEqual(f.customers.updates, want) {
+		t.Errorf("customer updates = %+v, want %+v", f.customers.updates, want)
+	}
+
+	if w := f.patch("/workers/"+f.workerID, mediaTypeMergePatch, `{"position": "changed"}`); w.Code != nethttp.StatusOK {
+		t.Fatalf("worker status = %d, want %d, body = %s", w.Code, nethttp.StatusOK, w.Body.String())
+	}
+	if want := []worker.Entity{{Position: &value, Version: 1}}; !reflect.DeepEqual(f.workers.updates, want) {
+		t.Errorf("worker updates = %+v, want %+v", f.workers.updates, want)
+	}
+
+	if w := f.patch("/hires/"+f.hireID, mediaTypeMergePatch, `{"description": "changed"}`); w.Code != nethttp.StatusOK {
+		t.Fatalf("hire status = %d, want %d, body = %s", w.Code, nethttp.StatusOK, w.Body.String())
+	}
+	if want := []hire.Entity{{Description: &value, Version: 1}}; !reflect.DeepEqual(f.hires.updates, want) {
+		t.Errorf("hire updates = %+v, want %+v", f.hires.updates, want)
+	}
+
+	// the fields the patches didn't set are kept
+	ctx := context.Background()
+	if data, _ := f.customers.Get(ctx, f.customerID); *data.FullName != "name" || *data.Pseudonym != value {
+		t.Errorf("customer = %q %q, want %q %q", *data.FullName, *data.Pseudonym, "name", value)
+	}
+	if data, _ := f.workers.Get(ctx, f.workerID); *data.Description != "name" || *data.Position != value {
+		t.Errorf("worker = %q %q, want %q %q", *data.Description, *data.Position, "name", value)
+	}
+	if data, _ := f.hires.Get(ctx, f.hireID); *data.JobName != "name" || data.CustomerID != f.customerID || *data.Description != value {
+		t.Errorf("hire = %q %q %q, want %q %q %q", *data.JobName, data.CustomerID, *data.Description, "name", f.customerID, value)
+	}
+}
+
+func TestPatchValidation(t *testing.T) {
+	tests := []struct {
+		name      string
+		mediaType string
+		body      string
+		want      int
+	}{
+		{name: "plain json", mediaType: "application/json", body: `{"pseudonym": "changed"}`, want: nethttp.StatusOK},
+		{name: "charset", mediaType: mediaTypeMergePatch + "; charset=utf-8", body: `{"pseudonym": "changed"}`, want: nethttp.StatusOK},
+		{name: "no media type", body: `{"pseudonym": "changed"}`, want: nethttp.StatusOK},
+		{name: "wrong media type", mediaType: "text/plain", body: `{"pseudonym": "changed"}`, want: nethttp.StatusUnsupportedMediaType},
+		{name: "json patch", mediaType: "application/json-patch+json", body: `[{"op": "replace", "path": "/pseudonym", "value": "changed"}]`, want: nethttp.StatusUnsupportedMediaType},
+		{name: "null member", mediaType: mediaTypeMergePatch, body: `{"pseudonym": null}`, want: nethttp.StatusBadRequest},
+		{name: "unknown field", mediaType: mediaTypeMergePatch, body: `{"userId": "other"}`, want: nethttp.StatusBadRequest},
+		{name: "empty", mediaType: mediaTypeMergePatch, body: `{}`, want: nethttp.StatusBadRequest},
+		{name: "blank", mediaType: mediaTypeMergePatch, body: `{"pseudonym": ""}`, want: nethttp.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newPatchFixture(t)
+
+			w := f.patch("/customers/"+f.customerID, tt.mediaType, tt.body)
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d, body = %s", w.Code, tt.want, w.Body.String())
+			}
+
+			// the refused patch never reaches the repository
+			if tt.want != nethttp.StatusOK && len(f.customers.updates) != 0 {
+				t.Errorf("updates = %+v, want none", f.customers.updates)
+			}
+		})
+	}
+}
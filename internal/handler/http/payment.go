@@ -0,0 +1,309 @@
+package http
+
+import (
+	"errors"
+	"exchanger/internal/domain/payment"
+	"exchanger/internal/domain/user"
+	"exchanger/internal/service/hiring"
+	"exchanger/pkg/market"
+	"exchanger/pkg/server/response"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"io"
+	"net/http"
+)
+
+// maxCallbackSize limits the body of the provider callbacks
+const maxCallbackSize = 1 << 20
+
+type PaymentHandler struct {
+	hiringService *hiring.Service
+}
+
+func NewPaymentHandler(s *hiring.Service) *PaymentHandler {
+	return &PaymentHandler{hiringService: s}
+}
+
+func (h *PaymentHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Route("/{invoiceId}", func(r chi.Router) {
+		r.Get("/", h.get)
+		r.Get("/page", h.page)
+		r.Get("/history", h.history)
+		r.With(RequireRole(user.RoleAdmin)).Post("/charge", h.charge)
+		r.With(RequireRole(user.RoleCustomer, user.RoleAdmin)).Post("/cancel", h.cancel)
+		r.With(RequireRole(user.RoleCustomer, user.RoleAdmin)).Post("/refund", h.refund)
+	})
+
+	return r
+}
+
+// HireRoutes are mounted under the hire, the id path param is the id of the hire
+func (h *PaymentHandler) HireRoutes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.list)
+	r.With(RequireRole(user.RoleCustomer, user.RoleAdmin)).Post("/", h.fund)
+
+	return r
+}
+
+// @Summary	fund the hire into escrow
+// @Tags		payments
+// @Accept		json
+// @Produce	json
+// @Param		id	path		string	true	"path param"
+// @Success	200	{object}	payment.Response
+// @Failure	403	{object}	response.Object
+// @Failure	404	{object}	response.Object
+// @Failure	409	{object}	response.Object
+// @Failure	500	{object}	response.Object
+// @Failure	503	{object}	response.Object
+// @Router		/hires/{id}/payments [post]
+func (h *PaymentHandler) fund(w http.ResponseWriter, r *http.Request) {
+	hireID := chi.URLParam(r, "id")
+
+	res, err := h.hiringService.FundHire(r.Context(), hireID)
+	if err != nil {
+		h.error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	list of payments of the hire
+// @Tags		payments
+// @Accept		json
+// @Produce	json
+// @Param		id	path		string	true	"path param"
+// @Success	200	{array}		payment.Response
+// @Failure	403	{object}	response.Object
+// @Failure	404	{object}	response.Object
+// @Failure	500	{object}	response.Object
+// @Router		/hires/{id}/payments [get]
+func (h *PaymentHandler) list(w http.ResponseWriter, r *http.Request) {
+	hireID := chi.URLParam(r, "id")
+
+	res, err := h.hiringService.ListPayments(r.Context(), hireID)
+	if err != nil {
+		h.error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	get the payment with its status
+// @Tags		payments
+// @Accept		json
+// @Produce	json
+// @Param		invoiceId	path		string	true	"path param"
+// @Success	200			{object}	payment.Response
+// @Failure	403			{object}	response.Object
+// @Failure	404			{object}	response.Object
+// @Failure	500			{object}	response.Object
+// @Router		/payments/{invoiceId} [get]
+func (h *PaymentHandler) get(w http.ResponseWriter, r *http.Request) {
+	invoiceID := chi.URLParam(r, "invoiceId")
+
+	res, err := h.hiringService.GetPayment(r.Context(), invoiceID)
+	if err != nil {
+		h.error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	payment page of the provider
+// @Tags		payments
+// @Produce	html
+// @Param		invoiceId	path	string	true	"path param"
+// @Success	200
+// @Failure	403	{object}	response.Object
+// @Failure	404	{object}	response.Object
+// @Failure	500	{object}	response.Object
+// @Failure	503	{object}	response.Object
+// @Router		/payments/{invoiceId}/page [get]
+func (h *PaymentHandler) page(w http.ResponseWriter, r *http.Request) {
+	invoiceID := chi.URLParam(r, "invoiceId")
+
+	if err := h.hiringService.RenderPaymentPage(r.Context(), w, invoiceID); err != nil {
+		h.error(w, r, err)
+		return
+	}
+}
+
+// @Summary	operations made on the transaction of the payment
+// @Tags		payments
+// @Accept		json
+// @Produce	json
+// @Param		invoiceId	path		string	true	"path param"
+// @Success	200			{array}		payment.HistoryResponse
+// @Failure	403			{object}	response.Object
+// @Failure	404			{object}	response.Object
+// @Failure	500			{object}	response.Object
+// @Router		/payments/{invoiceId}/history [get]
+func (h *PaymentHandler) history(w http.ResponseWriter, r *http.Request) {
+	invoiceID := chi.URLParam(r, "invoiceId")
+
+	res, err := h.hiringService.ListPaymentHistory(r.Context(), invoiceID)
+	if err != nil {
+		h.error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	capture the authorized amount of the payment
+// @Tags		payments
+// @Accept		json
+// @Produce	json
+// @Param		invoiceId	path		string						true	"path param"
+// @Param		request		body		payment.OperationRequest	false	"body param"
+// @Success	200			{object}	payment.Response
+// @Failure	400			{object}	response.Object
+// @Failure	403			{object}	response.Object
+// @Failure	404			{object}	response.Object
+// @Failure	409			{object}	response.Object
+// @Failure	500			{object}	response.Object
+// @Failure	503			{object}	response.Object
+// @Router		/payments/{invoiceId}/charge [post]
+func (h *PaymentHandler) charge(w http.ResponseWriter, r *http.Request) {
+	invoiceID := chi.URLParam(r, "invoiceId")
+
+	req := payment.OperationRequest{}
+	if err := bindOptional(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	res, err := h.hiringService.ChargePayment(r.Context(), invoiceID, req.Amount)
+	if err != nil {
+		h.error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	release the authorized amount of the payment to the customer
+// @Tags		payments
+// @Accept		json
+// @Produce	json
+// @Param		invoiceId	path		string	true	"path param"
+// @Success	200			{object}	payment.Response
+// @Failure	403			{object}	response.Object
+// @Failure	404			{object}	response.Object
+// @Failure	409			{object}	response.Object
+// @Failure	500			{object}	response.Object
+// @Failure	503			{object}	response.Object
+// @Router		/payments/{invoiceId}/cancel [post]
+func (h *PaymentHandler) cancel(w http.ResponseWriter, r *http.Request) {
+	invoiceID := chi.URLParam(r, "invoiceId")
+
+	res, err := h.hiringService.CancelPayment(r.Context(), invoiceID)
+	if err != nil {
+		h.error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	refund the charged amount of the payment to the customer
+// @Tags		payments
+// @Accept		json
+// @Produce	json
+// @Param		invoiceId	path		string						true	"path param"
+// @Param		request		body		payment.OperationRequest	false	"body param"
+// @Success	200			{object}	payment.Response
+// @Failure	400			{object}	response.Object
+// @Failure	403			{object}	response.Object
+// @Failure	404			{object}	response.Object
+// @Failure	409			{object}	response.Object
+// @Failure	500			{object}	response.Object
+// @Failure	503			{object}	response.Object
+// @Router		/payments/{invoiceId}/refund [post]
+func (h *PaymentHandler) refund(w http.ResponseWriter, r *http.Request) {
+	invoiceID := chi.URLParam(r, "invoiceId")
+
+	req := payment.OperationRequest{}
+	if err := bindOptional(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	res, err := h.hiringService.RefundPayment(r.Context(), invoiceID, req.Amount)
+	if err != nil {
+		h.error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	postLink callback of epay
+// @Description	the callback is answered without a body, every rejected callback gets the same 400
+// @Tags		payments
+// @Accept		json
+// @Param		request	body	epay.Notification	true	"body param"
+// @Success	200
+// @Failure	400
+// @Failure	500
+// @Failure	503
+// @Router		/callbacks/epay [post]
+func (h *PaymentHandler) Callback(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxCallbackSize))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// the caller isn't authenticated, so the rejection doesn't tell a missing invoice from a wrong one
+	if err = h.hiringService.HandleEpayCallback(r.Context(), body, r.RemoteAddr); err != nil {
+		switch {
+		case errors.Is(err, hiring.ErrorInvalidCallback), errors.Is(err, hiring.ErrorPaymentMismatch),
+			errors.Is(err, market.ErrorNotFound):
+			w.WriteHeader(http.StatusBadRequest)
+		case errors.Is(err, hiring.ErrorPaymentDisabled):
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *PaymentHandler) error(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, market.ErrorNotFound):
+		response.NotFound(w, r, err)
+	case errors.Is(err, market.ErrorForbidden):
+		response.Forbidden(w, r, err)
+	case errors.Is(err, hiring.ErrorPaymentAmount):
+		response.BadRequest(w, r, err, nil)
+	case errors.Is(err, hiring.ErrorHireUnfundable), errors.Is(err, hiring.ErrorPaymentExists),
+		errors.Is(err, hiring.ErrorPaymentState), errors.Is(err, hiring.ErrorPaymentInWork),
+		errors.Is(err, hiring.ErrorPaymentMismatch):
+		response.Conflict(w, r, err)
+	case errors.Is(err, hiring.ErrorPaymentDisabled):
+		response.ServiceUnavailable(w, r, err)
+	default:
+		response.InternalServerError(w, r, err)
+	}
+}
+
+// bindOptional binds the request body unless the body is empty
+func bindOptional(r *http.Request, v render.Binder) error {
+	if err := render.Bind(r, v); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,269 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"exchanger/internal/domain/customer"
+	"exchanger/internal/domain/hire"
+	"exchanger/internal/domain/payment"
+	"exchanger/internal/provider/epay"
+	"exchanger/internal/provider/epay/epaytest"
+	"exchanger/internal/repository"
+	"exchanger/internal/service/hiring"
+	"github.com/go-chi/chi/v5"
+	"github.com/shopspring/decimal"
+	nethttp "net/http"
+	"testing"
+	"time"
+)
+
+type callbackFixture struct {
+	epay         *epaytest.Server
+	repositories *repository.Repository
+	router       chi.Router
+	hireID       string
+	invoiceID    string
+}
+
+func newCallbackFixture(t *testing.T) (f callbackFixture) {
+	t.Helper()
+
+	f.epay = epaytest.NewServer()
+	t.Cleanup(f.epay.Close)
+
+	epayClient, err := epay.New(f.epay.Credentials())
+	if err != nil {
+		t.Fatalf("epay.New() error = %v", err)
+	}
+
+	f.repositories = newMemoryRepositories(t)
+
+	hiringService, err := hiring.New(
+		hiring.WithCustomerRepository(f.repositories.Customer),
+		hiring.WithHireRepository(f.repositories.Hire),
+		hiring.WithHireHistoryRepository(f.repositories.HireHistory),
+		hiring.WithWorkerRepository(f.repositories.Worker),
+		hiring.WithProposalRepository(f.repositories.Proposal),
+		hiring.WithPaymentRepository(f.repositories.Payment),
+		hiring.WithPaymentCallbackRepository(f.repositories.PaymentCallback),
+		hiring.WithEpayClient(epayClient))
+	if err != nil {
+		t.Fatalf("hiring.New() error = %v", err)
+	}
+
+	ctx := context.Background()
+	name, amount, currency := "name", 500, "KZT"
+
+	customerID, err := f.repositories.Customer.Add(ctx, customer.Entity{FullName: &name, Pseudonym: &name})
+	if err != nil {
+		t.Fatalf("Customer.Add() error = %v", err)
+	}
+
+	budget := decimal.NewFromInt(int64(amount))
+	f.hireID, err = f.repositories.Hire.Add(ctx, hire.Entity{
+		JobName:     &name,
+		Amount:      &budget,
+		Currency:    &currency,
+		Description: &name,
+		Position:    &name,
+		CustomerID:  customerID,
+		Status:      hire.StatusDraft,
+	})
+	if err != nil {
+		t.Fatalf("Hire.Add() error = %v", err)
+	}
+
+	f.invoiceID = "000000000000001"
+	err = f.repositories.Payment.Add(ctx, payment.Entity{
+		InvoiceID:  f.invoiceID,
+		HireID:     f.hireID,
+		CustomerID: customerID,
+		Amount:     &amount,
+		Currency:   &currency,
+		Status:     payment.StatusPending,
+		CreatedAt:  time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("Payment.Add() error = %v", err)
+	}
+
+	f.router = chi.NewRouter()
+	f.router.Post("/callbacks/epay", NewPaymentHandler(hiringService).Callback)
+
+	return
+}
+
+// post sends the callback and returns its status, the callback is never answered with a body
+func (f callbackFixture) post(t *testing.T, body string) int {
+	t.Helper()
+
+	w := serve(f.router, nethttp.MethodPost, "/callbacks/epay", body)
+	if w.Body.Len() != 0 {
+		t.Errorf("callback body = %q, want none", w.Body.String())
+	}
+
+	return w.Code
+}
+
+func (f callbackFixture) notification(code string) string {
+	data, _ := json.Marshal(epay.Notification{
+		InvoiceID: f.invoiceID,
+		Amount:    500,
+		Currency:  "KZT",
+		Terminal:  "terminal",
+		Code:      code,
+	})
+
+	return string(data)
+}
+
+func (f callbackFixture) state(t *testing.T) (payment.Entity, hire.Entity) {
+	t.Helper()
+
+	paymentData, err := f.repositories.Payment.Get(context.Background(), f.invoiceID)
+	if err != nil {
+		t.Fatalf("Payment.Get() error = %v", err)
+	}
+
+	hireData, err := f.repositories.Hire.Get(context.Background(), f.hireID)
+	if err != nil {
+		t.Fatalf("Hire.Get() error = %v", err)
+	}
+
+	return paymentData, hireData
+}
+
+func TestCallbackFundsPaymentVerifiedByStatus(t *testing.T) {
+	f := newCallbackFixture(t)
+	f.epay.SetTransaction(epay.TransactionResponse{InvoiceID: f.invoiceID, Amount: 500, Currency: "KZT", Terminal: "terminal", StatusName: "AUTH"})
+
+	if code := f.post(t, f.notification("ok")); code != nethttp.StatusOK {
+		t.Fatalf("callback status = %d, want %d", code, nethttp.StatusOK)
+	}
+
+	paymentData, hireData := f.state(t)
+	if paymentData.Status != payment.StatusFunded || paymentData.ProviderStatus != "AUTH" {
+		t.Errorf("payment status = %s/%s, want %s/AUTH", paymentData.Status, paymentData.ProviderStatus, payment.StatusFunded)
+	}
+	if hireData.Status != hire.StatusOpen {
+		t.Errorf("hire status = %s, want %s", hireData.Status, hire.StatusOpen)
+	}
+}
+
+func TestCallbackDoesNotTrustBody(t *testing.T) {
+	f := newCallbackFixture(t)
+	// the body claims success while epay still reports the transaction as new
+	f.epay.SetTransaction(epay.TransactionResponse{InvoiceID: f.invoiceID, Amount: 500, Currency: "KZT", Terminal: "terminal", StatusName: "NEW"})
+
+	if code := f.post(t, f.notification("ok")); code != nethttp.StatusOK {
+		t.Fatalf("callback status = %d, want %d", code, nethttp.StatusOK)
+	}
+
+	paymentData, hireData := f.state(t)
+	if paymentData.Status != payment.StatusPending {
+		t.Errorf("payment status = %s, want %s", paymentData.Status, payment.StatusPending)
+	}
+	if hireData.Status != hire.StatusDraft {
+		t.Errorf("hire status = %s, want %s", hireData.Status, hire.StatusDraft)
+	}
+	if checks := f.epay.Checks(); checks != 1 {
+		t.Errorf("status checks = %d, want 1", checks)
+	}
+}
+
+func TestCallbackIsIdempotent(t *testing.T) {
+	f := newCallbackFixture(t)
+	f.epay.SetTransaction(epay.TransactionResponse{InvoiceID: f.invoiceID, Amount: 500, Currency: "KZT", Terminal: "terminal", StatusName: "AUTH"})
+
+	for i := 0; i < 3; i++ {
+		if code := f.post(t, f.notification("ok")); code != nethttp.StatusOK {
+			t.Fatalf("callback %d status = %d, want %d", i, code, nethttp.StatusOK)
+		}
+	}
+
+	history, err := f.repositories.HireHistory.List(context.Background(), f.hireID)
+	if err != nil {
+		t.Fatalf("HireHistory.List() error = %v", err)
+	}
+	if len(history) != 1 {
+		t.Errorf("hire status changes = %d, want 1", len(history))
+	}
+
+	callbacks, err := f.repositories.PaymentCallback.List(context.Background(), f.invoiceID)
+	if err != nil {
+		t.Fatalf("PaymentCallback.List() error = %v", err)
+	}
+	if len(callbacks) != 3 {
+		t.Errorf("stored callbacks = %d, want 3", len(callbacks))
+	}
+}
+
+func TestCallbackRejected(t *testing.T) {
+	tests := []struct {
+		name        string
+		transaction func(invoiceID string) epay.TransactionResponse
+		body        func(f callbackFixture) string
+	}{
+		{
+			name: "amount mismatch",
+			transaction: func(invoiceID string) epay.TransactionResponse {
+				return epay.TransactionResponse{InvoiceID: invoiceID, Amount: 1, Currency: "KZT", Terminal: "terminal", StatusName: "AUTH"}
+			},
+			body: func(f callbackFixture) string { return f.notification("ok") },
+		},
+		{
+			name: "terminal mismatch",
+			transaction: func(invoiceID string) epay.TransactionResponse {
+				return epay.TransactionResponse{InvoiceID: invoiceID, Amount: 500, Currency: "KZT", Terminal: "other", StatusName: "AUTH"}
+			},
+			body: func(f callbackFixture) string { return f.notification("ok") },
+		},
+		{
+			name: "malformed body",
+			body: func(f callbackFixture) string { return "invoiceId=1" },
+		},
+		{
+			name: "unknown invoice",
+			body: func(f callbackFixture) string { return `{"invoiceId":"999999999999999","code":"ok"}` },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newCallbackFixture(t)
+			if tt.transaction != nil {
+				f.epay.SetTransaction(tt.transaction(f.invoiceID))
+			}
+
+			body := tt.body(f)
+			// every rejection looks the same to the caller
+			if code := f.post(t, body); code != nethttp.StatusBadRequest {
+				t.Fatalf("callback status = %d, want %d", code, nethttp.StatusBadRequest)
+			}
+
+			paymentData, hireData := f.state(t)
+			if paymentData.Status != payment.StatusPending || hireData.Status != hire.StatusDraft {
+				t.Errorf("state = %s/%s, want %s/%s", paymentData.Status, hireData.Status, payment.StatusPending, hire.StatusDraft)
+			}
+
+			// the raw callback is kept even when it is rejected
+			callbacks, err := f.repositories.PaymentCallback.List(context.Background(), f.invoiceID)
+			if err != nil {
+				t.Fatalf("PaymentCallback.List() error = %v", err)
+			}
+			unknown, err := f.repositories.PaymentCallback.List(context.Background(), "")
+			if err != nil {
+				t.Fatalf("PaymentCallback.List() error = %v", err)
+			}
+			other, err := f.repositories.PaymentCallback.List(context.Background(), "999999999999999")
+			if err != nil {
+				t.Fatalf("PaymentCallback.List() error = %v", err)
+			}
+			if stored := len(callbacks) + len(unknown) + len(other); stored != 1 {
+				t.Errorf("stored callbacks = %d, want 1", stored)
+			} else if all := append(append(callbacks, unknown...), other...); all[0].Body != body {
+				t.Errorf("stored body = %q, want %q", all[0].Body, body)
+			}
+		})
+	}
+}
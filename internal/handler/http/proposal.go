@@ -0,0 +1,180 @@
+package http
+
+import (
+	"errors"
+	"exchanger/internal/domain/proposal"
+	"exchanger/internal/domain/user"
+	"exchanger/internal/service/hiring"
+	"exchanger/pkg/market"
+	"exchanger/pkg/server/response"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"net/http"
+)
+
+type ProposalHandler struct {
+	hiringService *hiring.Service
+}
+
+func NewProposalHandler(s *hiring.Service) *ProposalHandler {
+	return &ProposalHandler{hiringService: s}
+}
+
+// Routes are mounted under the hire, the id path param is the id of the hire
+func (h *ProposalHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.list)
+	r.With(RequireRole(user.RoleWorker, user.RoleAdmin)).Post("/", h.add)
+
+	r.Route("/{proposalId}", func(r chi.Router) {
+		r.Post("/accept", h.accept)
+		r.Post("/reject", h.reject)
+	})
+
+	return r
+}
+
+// @Summary	list of proposals to the hire
+// @Tags		proposals
+// @Accept		json
+// @Produce	json
+// @Param		id		path		string	true	"path param"
+// @Param		limit	query		int		false	"page size, 20 by default and 100 at most"
+// @Param		offset	query		int		false	"number of items to skip"
+// @Param		cursor	query		string	false	"next cursor of the previous page"
+// @Param		sort	query		string	false	"field to sort by"
+// @Param		order	query		string	false	"asc or desc"
+// @Success	200		{array}		proposal.Response
+// @Failure	400		{object}	response.Object
+// @Failure	403		{object}	response.Object
+// @Failure	404		{object}	response.Object
+// @Failure	500		{object}	response.Object
+// @Router		/hires/{id}/proposals [get]
+func (h *ProposalHandler) list(w http.ResponseWriter, r *http.Request) {
+	hireID := chi.URLParam(r, "id")
+
+	q, err := parseQuery(r, proposal.Fields)
+	if err != nil {
+		response.BadRequest(w, r, err, nil)
+		return
+	}
+
+	res, page, err := h.hiringService.ListProposals(r.Context(), hireID, q)
+	if err != nil {
+		switch {
+		case errors.Is(err, market.ErrorNotFound):
+			response.NotFound(w, r, err)
+		case errors.Is(err, market.ErrorForbidden):
+			response.Forbidden(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	response.OKWithMeta(w, r, res, page)
+}
+
+// @Summary	submit a proposal to the hire
+// @Tags		proposals
+// @Accept		json
+// @Produce	json
+// @Param		id		path		string				true	"path param"
+// @Param		request	body		proposal.Request	true	"body param"
+// @Success	200		{object}	proposal.Response
+// @Failure	400		{object}	response.Object
+// @Failure	403		{object}	response.Object
+// @Failure	404		{object}	response.Object
+// @Failure	409		{object}	response.Object
+// @Failure	500		{object}	response.Object
+// @Router		/hires/{id}/proposals [post]
+func (h *ProposalHandler) add(w http.ResponseWriter, r *http.Request) {
+	hireID := chi.URLParam(r, "id")
+
+	req := proposal.Request{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	res, err := h.hiringService.AddProposal(r.Context(), hireID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, market.ErrorNotFound):
+			response.NotFound(w, r, err)
+		case errors.Is(err, market.ErrorForbidden):
+			response.Forbidden(w, r, err)
+		case errors.Is(err, market.ErrorAlreadyExists), errors.Is(err, hiring.ErrorHireClosed):
+			response.Conflict(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	accept the proposal and close the hire to new ones
+// @Tags		proposals
+// @Accept		json
+// @Produce	json
+// @Param		id			path		string	true	"path param"
+// @Param		proposalId	path		string	true	"path param"
+// @Success	200			{object}	proposal.Response
+// @Failure	403			{object}	response.Object
+// @Failure	404			{object}	response.Object
+// @Failure	409			{object}	response.Object
+// @Failure	500			{object}	response.Object
+// @Router		/hires/{id}/proposals/{proposalId}/accept [post]
+func (h *ProposalHandler) accept(w http.ResponseWriter, r *http.Request) {
+	hireID := chi.URLParam(r, "id")
+	id := chi.URLParam(r, "proposalId")
+
+	res, err := h.hiringService.AcceptProposal(r.Context(), hireID, id)
+	if err != nil {
+		h.decisionError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	reject the proposal
+// @Tags		proposals
+// @Accept		json
+// @Produce	json
+// @Param		id			path		string	true	"path param"
+// @Param		proposalId	path		string	true	"path param"
+// @Success	200			{object}	proposal.Response
+// @Failure	403			{object}	response.Object
+// @Failure	404			{object}	response.Object
+// @Failure	409			{object}	response.Object
+// @Failure	500			{object}	response.Object
+// @Router		/hires/{id}/proposals/{proposalId}/reject [post]
+func (h *ProposalHandler) reject(w http.ResponseWriter, r *http.Request) {
+	hireID := chi.URLParam(r, "id")
+	id := chi.URLParam(r, "proposalId")
+
+	res, err := h.hiringService.RejectProposal(r.Context(), hireID, id)
+	if err != nil {
+		h.decisionError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+func (h *ProposalHandler) decisionError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, market.ErrorNotFound):
+		response.NotFound(w, r, err)
+	case errors.Is(err, market.ErrorForbidden):
+		response.Forbidden(w, r, err)
+	case errors.Is(err, hiring.ErrorHireClosed), errors.Is(err, hiring.ErrorProposalDecided):
+		response.Conflict(w, r, err)
+	default:
+		response.InternalServerError(w, r, err)
+	}
+}
@@ -0,0 +1,11 @@
+package http
+
+import (
+	"exchanger/pkg/market"
+	"net/http"
+)
+
+// parseQuery reads the list query of the request, the parameters that aren't fields of the list are left to the handler
+func parseQuery(r *http.Request, fields map[string]market.Field) (market.Query, error) {
+	return market.ParseQuery(r.URL.Query(), fields)
+}
@@ -0,0 +1,163 @@
+package http
+
+import (
+	"errors"
+	"exchanger/internal/domain/rate"
+	"exchanger/internal/service/exchange"
+	"exchanger/pkg/server/response"
+	"github.com/go-chi/chi/v5"
+	"github.com/shopspring/decimal"
+	"net/http"
+	"time"
+)
+
+type RateHandler struct {
+	exchangeService *exchange.Service
+}
+
+func NewRateHandler(s *exchange.Service) *RateHandler {
+	return &RateHandler{exchangeService: s}
+}
+
+func (h *RateHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.list)
+	r.Get("/convert", h.convert)
+	r.Get("/{code}", h.get)
+
+	return r
+}
+
+// @Summary	list of the national bank rates of the date
+// @Tags		rates
+// @Accept		json
+// @Produce	json
+// @Param		date	query		string	false	"date as 2006-01-02, today by default"
+// @Success	200		{array}		rate.Response
+// @Failure	400		{object}	response.Object
+// @Failure	500		{object}	response.Object
+// @Failure	503		{object}	response.Object
+// @Router		/rates [get]
+func (h *RateHandler) list(w http.ResponseWriter, r *http.Request) {
+	date, err := parseDate(r)
+	if err != nil {
+		response.BadRequest(w, r, err, nil)
+		return
+	}
+
+	res, err := h.exchangeService.ListRates(r.Context(), date)
+	if err != nil {
+		h.error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	get the national bank rate of the currency on the date
+// @Tags		rates
+// @Accept		json
+// @Produce	json
+// @Param		code	path		string	true	"ISO 4217 code"
+// @Param		date	query		string	false	"date as 2006-01-02, today by default"
+// @Success	200		{object}	rate.Response
+// @Failure	400		{object}	response.Object
+// @Failure	404		{object}	response.Object
+// @Failure	500		{object}	response.Object
+// @Failure	503		{object}	response.Object
+// @Router		/rates/{code} [get]
+func (h *RateHandler) get(w http.ResponseWriter, r *http.Request) {
+	code := chi.URLParam(r, "code")
+
+	date, err := parseDate(r)
+	if err != nil {
+		response.BadRequest(w, r, err, nil)
+		return
+	}
+
+	res, err := h.exchangeService.GetRate(r.Context(), code, date)
+	if err != nil {
+		if errors.Is(err, exchange.ErrorUnknownCurrency) {
+			response.NotFound(w, r, err)
+			return
+		}
+		h.error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	convert the amount between the currencies by the national bank rates of the date
+// @Tags		rates
+// @Accept		json
+// @Produce	json
+// @Param		from	query		string	true	"ISO 4217 code to convert from"
+// @Param		to		query		string	true	"ISO 4217 code to convert into"
+// @Param		amount	query		string	true	"amount to convert"
+// @Param		date	query		string	false	"date as 2006-01-02, today by default"
+// @Success	200		{object}	rate.ConvertResponse
+// @Failure	400		{object}	response.Object
+// @Failure	500		{object}	response.Object
+// @Failure	503		{object}	response.Object
+// @Router		/rates/convert [get]
+func (h *RateHandler) convert(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	req := rate.ConvertRequest{
+		From: query.Get("from"),
+		To:   query.Get("to"),
+	}
+
+	amount, err := decimal.NewFromString(query.Get("amount"))
+	if err != nil {
+		response.BadRequest(w, r, errors.New("amount: must be a number"), nil)
+		return
+	}
+	req.Amount = amount
+
+	if req.Date, err = parseDate(r); err != nil {
+		response.BadRequest(w, r, err, nil)
+		return
+	}
+
+	if err = req.Bind(r); err != nil {
+		response.BadRequest(w, r, err, nil)
+		return
+	}
+
+	res, err := h.exchangeService.Convert(r.Context(), req)
+	if err != nil {
+		h.error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+func (h *RateHandler) error(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, exchange.ErrorUnknownCurrency), errors.Is(err, exchange.ErrorFutureDate):
+		response.BadRequest(w, r, err, nil)
+	case errors.Is(err, exchange.ErrorRatesDisabled), errors.Is(err, exchange.ErrorRatesUnavailable):
+		response.ServiceUnavailable(w, r, err)
+	default:
+		response.InternalServerError(w, r, err)
+	}
+}
+
+// parseDate returns the date of the rates, zero date stands for today
+func parseDate(r *http.Request) (date time.Time, err error) {
+	value := r.URL.Query().Get("date")
+	if value == "" {
+		return
+	}
+
+	date, err = time.Parse(rate.DateLayout, value)
+	if err != nil {
+		err = errors.New("date: must be formatted as " + rate.DateLayout)
+	}
+
+	return
+}
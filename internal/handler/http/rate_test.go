@@ -0,0 +1,199 @@
+package http
+
+import (
+	"context"
+	"exchanger/internal/domain/rate"
+	"exchanger/internal/provider/currency"
+	"exchanger/internal/repository"
+	"exchanger/internal/service/exchange"
+	"github.com/go-chi/chi/v5"
+	nethttp "net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+)
+
+var feedDate = regexp.MustCompile(`<date>[^<]*</date>`)
+
+// newRateRouter mounts the rate handler over the national bank feed served from testdata/rates.xml dated
+// the day asked for, requests returns how many times the feed was asked for the date
+func newRateRouter(t *testing.T) (router chi.Router, repositories *repository.Repository, requests func(date string) int) {
+	t.Helper()
+
+	feed, err := os.ReadFile("testdata/rates.xml")
+	if err != nil {
+		t.Fatalf("os.ReadFile() error = %v", err)
+	}
+
+	var mutex sync.Mutex
+	counts := make(map[string]int)
+	server := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		date := r.URL.Query().Get("fdate")
+
+		mutex.Lock()
+		counts[date]++
+		mutex.Unlock()
+
+		w.Header().Set("Content-Type", "text/xml")
+		w.Write(feedDate.ReplaceAll(feed, []byte("<date>"+date+"</date>")))
+	}))
+	t.Cleanup(server.Close)
+
+	requests = func(date string) int {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		return counts[date]
+	}
+
+	repositories = newMemoryRepositories(t)
+
+	currencyClient, err := currency.New(currency.Credentials{URL: server.URL})
+	if err != nil {
+		t.Fatalf("currency.New() error = %v", err)
+	}
+
+	exchangeService, err := exchange.New(
+		exchange.WithRateRepository(repositories.Rate),
+		exchange.WithCurrencyClient(currencyClient))
+	if err != nil {
+		t.Fatalf("exchange.New() error = %v", err)
+	}
+
+	router = chi.NewRouter()
+	router.Mount("/rates", NewRateHandler(exchangeService).Routes())
+
+	return
+}
+
+func TestRatesOfPastDayAreFetchedOnce(t *testing.T) {
+	router, repositories, requests := newRateRouter(t)
+
+	w := serve(router, nethttp.MethodGet, "/rates?date=2024-01-15", "")
+	if w.Code != nethttp.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, nethttp.StatusOK)
+	}
+
+	var list []rate.Response
+	if readData(t, w, &list); len(list) != 3 {
+		t.Fatalf("rates = %d, want 3", len(list))
+	}
+
+	for _, target := range []string{"/rates/usd?date=2024-01-15", "/rates/convert?from=USD&to=EUR&amount=1&date=2024-01-15"} {
+		if w = serve(router, nethttp.MethodGet, target, ""); w.Code != nethttp.StatusOK {
+			t.Fatalf("%s: status = %d, want %d", target, w.Code, nethttp.StatusOK)
+		}
+	}
+
+	if got := requests("15.01.2024"); got != 1 {
+		t.Errorf("feed requests = %d, want 1", got)
+	}
+
+	stored, err := repositories.Rate.List(context.Background(), time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Rate.List() error = %v", err)
+	}
+
+	if len(stored) != 3 {
+		t.Errorf("stored rates = %d, want 3", len(stored))
+	}
+}
+
+func TestRatesGet(t *testing.T) {
+	router, _, _ := newRateRouter(t)
+
+	tests := []struct {
+		name  string
+		code  string
+		want  string
+		value string
+	}{
+		{name: "upper-cased code", code: "USD", want: "USD", value: "500"},
+		{name: "lower-cased code", code: "eur", want: "EUR", value: "550"},
+		{name: "rate by quant", code: "JPY", want: "JPY", value: "350"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(router, nethttp.MethodGet, "/rates/"+tt.code+"?date=2024-01-15", "")
+			if w.Code != nethttp.StatusOK {
+				t.Fatalf("status = %d, want %d", w.Code, nethttp.StatusOK)
+			}
+
+			var res rate.Response
+			readData(t, w, &res)
+
+			if res.Code != tt.want || res.Value.String() != tt.value || res.Date != "2024-01-15" {
+				t.Errorf("rate = %+v, want %s %s on 2024-01-15", res, tt.want, tt.value)
+			}
+		})
+	}
+}
+
+func TestRatesConvert(t *testing.T) {
+	router, _, _ := newRateRouter(t)
+
+	tests := []struct {
+		name   string
+		query  string
+		result string
+		rate   string
+	}{
+		{name: "cross rate", query: "from=USD&to=EUR&amount=100", result: "90.91", rate: "0.90909091"},
+		{name: "to tenge by quant", query: "from=JPY&to=KZT&amount=1000", result: "3500", rate: "3.5"},
+		{name: "from tenge by quant", query: "from=KZT&to=JPY&amount=700", result: "200", rate: "0.28571429"},
+		{name: "cross rate by quant", query: "from=usd&to=jpy&amount=1", result: "142.86", rate: "142.85714286"},
+		{name: "same currency", query: "from=EUR&to=EUR&amount=12.5", result: "12.5", rate: "1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(router, nethttp.MethodGet, "/rates/convert?date=2024-01-15&"+tt.query, "")
+			if w.Code != nethttp.StatusOK {
+				t.Fatalf("status = %d, want %d", w.Code, nethttp.StatusOK)
+			}
+
+			var res rate.ConvertResponse
+			readData(t, w, &res)
+
+			if res.Result.String() != tt.result {
+				t.Errorf("result = %s, want %s", res.Result, tt.result)
+			}
+
+			if res.Rate.String() != tt.rate {
+				t.Errorf("rate = %s, want %s", res.Rate, tt.rate)
+			}
+		})
+	}
+}
+
+func TestRatesRejected(t *testing.T) {
+	router, _, _ := newRateRouter(t)
+
+	tomorrow := time.Now().AddDate(0, 0, 2).Format(rate.DateLayout)
+
+	tests := []struct {
+		name   string
+		target string
+		status int
+	}{
+		{name: "unknown code", target: "/rates/XXX?date=2024-01-15", status: nethttp.StatusNotFound},
+		{name: "unknown currency", target: "/rates/convert?from=XXX&to=KZT&amount=1&date=2024-01-15", status: nethttp.StatusBadRequest},
+		{name: "malformed amount", target: "/rates/convert?from=USD&to=KZT&amount=one", status: nethttp.StatusBadRequest},
+		{name: "negative amount", target: "/rates/convert?from=USD&to=KZT&amount=-1", status: nethttp.StatusBadRequest},
+		{name: "blank currency", target: "/rates/convert?to=KZT&amount=1", status: nethttp.StatusBadRequest},
+		{name: "malformed date", target: "/rates?date=15.01.2024", status: nethttp.StatusBadRequest},
+		{name: "future date", target: "/rates?date=" + tomorrow, status: nethttp.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := serve(router, nethttp.MethodGet, tt.target, ""); w.Code != tt.status {
+				t.Errorf("status = %d, want %d", w.Code, tt.status)
+			}
+		})
+	}
+}
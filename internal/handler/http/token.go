@@ -0,0 +1,52 @@
+package http
+
+import (
+	"exchanger/internal/service/auth"
+	"exchanger/pkg/server/response"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+)
+
+type TokenHandler struct {
+	authService *auth.Service
+}
+
+func NewTokenHandler(s *auth.Service) *TokenHandler {
+	return &TokenHandler{authService: s}
+}
+
+// @Summary	revoke the bearer token of the request
+// @Tags		tokens
+// @Accept		json
+// @Produce	json
+// @Success	200
+// @Failure	401	{object}	response.Object
+// @Failure	500	{object}	response.Object
+// @Router		/logout [post]
+func (h *TokenHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	if err := h.authService.Logout(r.Context(), tokenIDFromContext(r)); err != nil {
+		response.InternalServerError(w, r, err)
+		return
+	}
+}
+
+// @Summary	revoke every token issued to the credential
+// @Tags		tokens
+// @Accept		json
+// @Produce	json
+// @Param		credential	path		string	true	"path param"
+// @Success	200			{object}	token.RevokeResponse
+// @Failure	403			{object}	response.Object
+// @Failure	500			{object}	response.Object
+// @Router		/admin/tokens/{credential}/revoke [post]
+func (h *TokenHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	credential := chi.URLParam(r, "credential")
+
+	res, err := h.authService.RevokeTokens(r.Context(), credential)
+	if err != nil {
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
@@ -0,0 +1,165 @@
+package http
+
+import (
+	"errors"
+	"exchanger/internal/domain/user"
+	"exchanger/internal/service/auth"
+	"exchanger/pkg/market"
+	"exchanger/pkg/server/response"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"net/http"
+)
+
+type UserHandler struct {
+	authService *auth.Service
+}
+
+func NewUserHandler(s *auth.Service) *UserHandler {
+	return &UserHandler{authService: s}
+}
+
+// PublicRoutes returns the routes available without a bearer token
+func (h *UserHandler) PublicRoutes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Post("/", h.register)
+
+	return r
+}
+
+func (h *UserHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Route("/{id}", func(r chi.Router) {
+		r.Put("/password", h.changePassword)
+		r.Post("/deactivate", h.deactivate)
+	})
+
+	return r
+}
+
+// @Summary	register a new user
+// @Tags		users
+// @Accept		json
+// @Produce	json
+// @Param		request	body		user.Request	true	"body param"
+// @Success	200		{object}	user.Response
+// @Failure	400		{object}	response.Object
+// @Failure	409		{object}	response.Object
+// @Failure	500		{object}	response.Object
+// @Router		/register [post]
+func (h *UserHandler) register(w http.ResponseWriter, r *http.Request) {
+	req := user.Request{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	res, err := h.authService.Register(r.Context(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, market.ErrorAlreadyExists):
+			response.Conflict(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	change the password of the user
+// @Tags		users
+// @Accept		json
+// @Produce	json
+// @Param		id		path	string					true	"path param"
+// @Param		request	body	user.PasswordRequest	true	"body param"
+// @Success	200
+// @Failure	400	{object}	response.Object
+// @Failure	403	{object}	response.Object
+// @Failure	404	{object}	response.Object
+// @Failure	500	{object}	response.Object
+// @Router		/users/{id}/password [put]
+func (h *UserHandler) changePassword(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := user.PasswordRequest{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, nil)
+		return
+	}
+
+	if err := h.authService.ChangePassword(r.Context(), id, userFromContext(r), req); err != nil {
+		switch {
+		case errors.Is(err, market.ErrorNotFound):
+			response.NotFound(w, r, err)
+		case errors.Is(err, market.ErrorForbidden):
+			response.Forbidden(w, r, err)
+		case errors.Is(err, auth.ErrorInvalidCredentials):
+			response.BadRequest(w, r, err, nil)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+}
+
+// @Summary	deactivate the user
+// @Tags		users
+// @Accept		json
+// @Produce	json
+// @Param		id	path	string	true	"path param"
+// @Success	200
+// @Failure	403	{object}	response.Object
+// @Failure	404	{object}	response.Object
+// @Failure	500	{object}	response.Object
+// @Router		/users/{id}/deactivate [post]
+func (h *UserHandler) deactivate(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	if err := h.authService.DeactivateUser(r.Context(), id, userFromContext(r)); err != nil {
+		switch {
+		case errors.Is(err, market.ErrorNotFound):
+			response.NotFound(w, r, err)
+		case errors.Is(err, market.ErrorForbidden):
+			response.Forbidden(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+}
+
+// @Summary	set the role of the user
+// @Tags		users
+// @Accept		json
+// @Produce	json
+// @Param		id		path	string				true	"path param"
+// @Param		request	body	user.RoleRequest	true	"body param"
+// @Success	200
+// @Failure	400	{object}	response.Object
+// @Failure	403	{object}	response.Object
+// @Failure	404	{object}	response.Object
+// @Failure	500	{object}	response.Object
+// @Router		/admin/users/{id}/role [put]
+func (h *UserHandler) SetRole(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := user.RoleRequest{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	if err := h.authService.SetRole(r.Context(), id, req); err != nil {
+		switch {
+		case errors.Is(err, market.ErrorNotFound):
+			response.NotFound(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+}
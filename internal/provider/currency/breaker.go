@@ -0,0 +1,97 @@
+package currency
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	// BreakerClosed lets the requests through
+	BreakerClosed = "closed"
+	// BreakerOpen refuses the requests until the timeout passes
+	BreakerOpen = "open"
+	// BreakerHalfOpen lets a single request through to find out whether the feed is back
+	BreakerHalfOpen = "half-open"
+)
+
+var ErrorCircuitOpen = errors.New("rates feed is unavailable, circuit is open")
+
+// breaker opens once threshold requests in a row have failed, zero threshold never opens it
+type breaker struct {
+	sync.Mutex
+	threshold int
+	timeout   time.Duration
+
+	failures int
+	state    string
+	openedAt time.Time
+}
+
+// allow reports whether the request may go to the feed
+func (b *breaker) allow(now time.Time) bool {
+	b.Lock()
+	defer b.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		if now.Sub(b.openedAt) < b.timeout {
+			return false
+		}
+		b.state = BreakerHalfOpen
+		return true
+	case BreakerHalfOpen:
+		// the trial request is still running
+		return false
+	default:
+		return true
+	}
+}
+
+func (b *breaker) success() {
+	b.Lock()
+	defer b.Unlock()
+
+	b.failures = 0
+	b.state = BreakerClosed
+}
+
+// failure counts the failed request and reports whether the circuit has opened on it
+func (b *breaker) failure(now time.Time) (opened bool) {
+	b.Lock()
+	defer b.Unlock()
+
+	b.failures++
+	if b.threshold == 0 {
+		return
+	}
+
+	if b.state == BreakerHalfOpen || b.failures >= b.threshold {
+		opened = b.state != BreakerOpen
+		b.state = BreakerOpen
+		b.openedAt = now
+	}
+
+	return
+}
+
+// release lets the next request try the feed again when the trial one was given up on
+func (b *breaker) release() {
+	b.Lock()
+	defer b.Unlock()
+
+	if b.state == BreakerHalfOpen {
+		b.state = BreakerOpen
+		b.openedAt = time.Time{}
+	}
+}
+
+func (b *breaker) current() string {
+	b.Lock()
+	defer b.Unlock()
+
+	if b.state == "" {
+		return BreakerClosed
+	}
+	return b.state
+}
@@ -2,35 +2,184 @@ package currency
 
 import (
 	"context"
+	"errors"
+	"exchanger/pkg/log"
 	"github.com/patrickmn/go-cache"
+	"go.uber.org/zap"
+	"strings"
+	"sync"
 	"time"
 )
 
-func (c *Client) initCacheRefresher() {
-	c.GetRateFromCacheByID("USD")
+// latestRatesKey is the cache key of the rates of today
+const latestRatesKey = "rates"
 
-	timer := time.NewTicker(4 * time.Minute)
-	go func() {
-		for {
-			<-timer.C
-			c.GetRateFromCacheByID("USD")
+// rateKey is the cache key of the rate of today of the currency
+func rateKey(code string) string {
+	return "rate:" + strings.ToUpper(code)
+}
+
+// GetLatestRates returns the rates of today from the cache, the rates are requested once the cache expires.
+// The last known rates are returned as stale when the request fails
+func (c *Client) GetLatestRates(ctx context.Context) (dest Rates, err error) {
+	if data, found := c.caches.Get(latestRatesKey); found {
+		return data.(Rates), nil
+	}
+
+	dest, err = c.GetRates(ctx, time.Now())
+	if err != nil {
+		if last, ok := c.lastRates(); ok {
+			c.metrics.staleServed.Add(1)
+			return last, nil
 		}
-	}()
+		return
+	}
+	c.setLatestRates(dest)
+
+	return
 }
 
 func (c *Client) GetRateFromCacheByID(id string) (dest Rate, err error) {
-	if data, found := c.caches.Get(id); found {
+	if data, found := c.caches.Get(rateKey(id)); found {
 		return data.(Rate), nil
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	dest, err = c.GetRateByID(ctx, id, time.Now())
+	rates, err := c.GetLatestRates(ctx)
 	if err != nil {
 		return
 	}
-	c.caches.Set(id, dest, cache.DefaultExpiration)
+
+	for _, rate := range rates.Items {
+		if strings.EqualFold(id, rate.Title) {
+			return rate, nil
+		}
+	}
+
+	return dest, errors.New("id: " + id + " is not found")
+}
+
+func (c *Client) setLatestRates(data Rates) {
+	c.caches.Set(latestRatesKey, data, cache.DefaultExpiration)
+
+	c.latestMu.Lock()
+	defer c.latestMu.Unlock()
+
+	c.latest = data
+}
+
+// lastRates returns the last rates of today fetched marked as stale
+func (c *Client) lastRates() (dest Rates, ok bool) {
+	c.latestMu.RLock()
+	defer c.latestMu.RUnlock()
+
+	if c.latest.Date.IsZero() {
+		return
+	}
+
+	dest = c.latest
+	dest.Stale = true
+
+	return dest, true
+}
+
+// Refresher keeps the rates of today in the cache fresh in the background
+type Refresher struct {
+	client     *Client
+	interval   time.Duration
+	currencies []string
+
+	cancel   context.CancelFunc
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewRefresher returns the refresher fetching the rates every interval, the rates of the currencies
+// are cached one by one for GetRateFromCacheByID and the ones missing in the feed are counted in the metrics
+func (c *Client) NewRefresher(interval time.Duration, currencies ...string) *Refresher {
+	return &Refresher{
+		client:     c,
+		interval:   interval,
+		currencies: currencies,
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+}
+
+// Start runs the refresher in a goroutine until Stop is called
+func (r *Refresher) Start(ctx context.Context) {
+	ctx, r.cancel = context.WithCancel(ctx)
+
+	go func() {
+		defer close(r.done)
+
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		for {
+			r.Refresh(ctx)
+
+			select {
+			case <-r.stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop waits for the running refresh to finish, the refresh is cancelled once the context is done
+func (r *Refresher) Stop(ctx context.Context) (err error) {
+	if r.cancel == nil {
+		return
+	}
+
+	r.stopOnce.Do(func() {
+		close(r.stop)
+	})
+
+	select {
+	case <-r.done:
+	case <-ctx.Done():
+		r.cancel()
+		<-r.done
+		err = ctx.Err()
+	}
+	r.cancel()
 
 	return
 }
+
+// Refresh fetches the rates of today once and caches them
+func (r *Refresher) Refresh(ctx context.Context) {
+	logger := log.LoggerFromContext(ctx).Named("Refresher")
+
+	ctx, cancel := context.WithTimeout(ctx, r.interval)
+	defer cancel()
+
+	rates, err := r.client.GetRates(ctx, time.Now())
+	if err != nil {
+		logger.Warn("failed to refresh rates", zap.Error(err))
+		return
+	}
+	r.client.setLatestRates(rates)
+
+	for _, code := range r.currencies {
+		found := false
+		for _, rate := range rates.Items {
+			if strings.EqualFold(code, rate.Title) {
+				r.client.caches.Set(rateKey(code), rate, cache.DefaultExpiration)
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			r.client.metrics.missingCurrencies.Add(1)
+			logger.Warn("currency is missing in the feed", zap.String("currency", code))
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package currency
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetLatestRates(t *testing.T) {
+	tests := []struct {
+		name         string
+		known        bool
+		failures     int
+		wantStale    bool
+		wantErr      bool
+		wantRequests int
+	}{
+		{name: "cached rates aren't requested again", known: true, wantRequests: 1},
+		{name: "last known rates are served as stale", known: true, failures: -1, wantStale: true, wantRequests: 2},
+		{name: "nothing to serve without the known rates", failures: -1, wantErr: true, wantRequests: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := &stubSource{name: "stub", rates: Rates{Items: []Rate{{Title: "USD"}}}}
+			c, err := New(Credentials{}, WithSources(source))
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			ctx := context.Background()
+
+			if tt.known {
+				if _, err = c.GetLatestRates(ctx); err != nil {
+					t.Fatalf("GetLatestRates() error = %v", err)
+				}
+			}
+
+			// the feed goes down once the cache expires
+			if tt.failures != 0 {
+				c.caches.Flush()
+				source.Lock()
+				source.failures = tt.failures
+				source.Unlock()
+			}
+
+			got, err := c.GetLatestRates(ctx)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetLatestRates() error = %v, want error %v", err, tt.wantErr)
+			}
+
+			if got.Stale != tt.wantStale {
+				t.Errorf("stale = %v, want %v", got.Stale, tt.wantStale)
+			}
+			if !tt.wantErr && (len(got.Items) != 1 || got.Source != "stub") {
+				t.Errorf("rates = %+v, want the rates of the stub", got)
+			}
+			if served := c.Metrics().StaleServed; (served == 1) != tt.wantStale {
+				t.Errorf("stale served = %d, want stale %v", served, tt.wantStale)
+			}
+			if got := source.count(); got != tt.wantRequests {
+				t.Errorf("requests = %d, want %d", got, tt.wantRequests)
+			}
+		})
+	}
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"encoding/xml"
 	"errors"
+	"fmt"
 	"github.com/patrickmn/go-cache"
 	"io"
 	"net/http"
+	"sync"
 	"time"
 )
 
@@ -14,30 +16,98 @@ type Credentials struct {
 	URL string
 }
 
+// Configuration is an alias for a function that will take in a pointer to a Client and modify it
+type Configuration func(c *Client) error
+
 type Client struct {
 	caches      *cache.Cache
 	httpClient  *http.Client
+	sources     []RateSource
+	retry       retryPolicy
+	breaker     *breaker
+	pastBreaker *breaker
+	metrics     *metrics
 	Credentials Credentials
+
+	// latest holds the last rates of today fetched, they are served as stale while the feed is down
+	latest   Rates
+	latestMu sync.RWMutex
 }
 
-func New(credentials Credentials) *Client {
+// New takes the credentials with a variable amount of Configuration functions and returns a new Client,
+// the requests aren't retried and the circuit never opens unless configured
+func New(credentials Credentials, configs ...Configuration) (client *Client, err error) {
 	// Cache with 5 minutes expiration and 10 minutes cleanup interval
 	caches := cache.New(5*time.Minute, 10*time.Minute)
 
-	httpClient := http.DefaultClient
-	httpClient.Timeout = 30 * time.Second
+	httpClient := &http.Client{
+		Timeout: 30 * time.Second,
+	}
 
-	client := &Client{
+	client = &Client{
 		caches:      caches,
 		httpClient:  httpClient,
+		retry:       retryPolicy{attempts: 1},
+		breaker:     &breaker{},
+		pastBreaker: &breaker{},
+		metrics:     &metrics{},
 		Credentials: credentials,
 	}
-	client.initCacheRefresher()
 
-	return client
+	// Apply all Configurations passed in
+	for _, cfg := range configs {
+		if err = cfg(client); err != nil {
+			return
+		}
+	}
+
+	if client.sources == nil {
+		client.sources = []RateSource{NewNationalBankSource(credentials.URL, httpClient)}
+	}
+
+	return
+}
+
+// WithRetry repeats a failed request up to attempts times in all, the delay before each next attempt
+// doubles from delay up to maxDelay and is jittered so that the clients don't retry in step
+func WithRetry(attempts int, delay, maxDelay time.Duration) Configuration {
+	return func(c *Client) error {
+		if attempts < 1 {
+			return errors.New("retry attempts must be positive")
+		}
+		if delay < 0 || maxDelay < delay {
+			return errors.New("retry delay must not exceed the max delay")
+		}
+		c.retry = retryPolicy{attempts: attempts, delay: delay, maxDelay: maxDelay}
+		return nil
+	}
+}
+
+// WithCircuitBreaker stops requesting the feed for timeout once threshold requests in a row have failed.
+// The rates of today and of the past days have a circuit each, so that the past days missing in the feed
+// don't stop the rates of today
+func WithCircuitBreaker(threshold int, timeout time.Duration) Configuration {
+	return func(c *Client) error {
+		if threshold < 0 || timeout < 0 {
+			return errors.New("circuit breaker threshold and timeout cannot be negative")
+		}
+		c.breaker = &breaker{threshold: threshold, timeout: timeout}
+		c.pastBreaker = &breaker{threshold: threshold, timeout: timeout}
+		return nil
+	}
+}
+
+// breakerOf returns the circuit of the rates of the date
+func (c *Client) breakerOf(datetime time.Time) *breaker {
+	if datetime.Format(dateLayout) == time.Now().Format(dateLayout) {
+		return c.breaker
+	}
+
+	return c.pastBreaker
 }
 
-func (c *Client) request(ctx context.Context, method, url string, out interface{}) (err error) {
+// requestXML sends the request to the source and decodes the xml it answers with
+func requestXML(ctx context.Context, httpClient *http.Client, method, url string, out interface{}) (err error) {
 	// create new request
 	request, err := http.NewRequestWithContext(ctx, method, url, nil)
 	if err != nil {
@@ -54,7 +124,7 @@ func (c *Client) request(ctx context.Context, method, url string, out interface{
 	}
 
 	// send request
-	res, err := c.httpClient.Do(request)
+	res, err := httpClient.Do(request)
 	if err != nil {
 		return
 	}
@@ -68,7 +138,7 @@ func (c *Client) request(ctx context.Context, method, url string, out interface{
 
 	// check response status
 	if res.StatusCode != http.StatusOK {
-		return errors.New(string(data))
+		return fmt.Errorf("%s: %s", res.Status, data)
 	}
 	err = xml.Unmarshal(data, &out)
 
@@ -6,11 +6,11 @@ import (
 	"errors"
 	"fmt"
 	"github.com/shopspring/decimal"
-	"net/url"
 	"strings"
 	"time"
 )
 
+// Response is the rates feed of the national bank
 type Response struct {
 	XMLName     xml.Name `xml:"rates"`
 	Text        string   `xml:"text"`
@@ -64,37 +64,91 @@ func (c *Client) GetRateByID(ctx context.Context, id string, datetime time.Time)
 }
 
 func (c *Client) GetRatesByDate(ctx context.Context, datetime time.Time) (dest []Rate, err error) {
+	rates, err := c.GetRates(ctx, datetime)
+	if err != nil {
+		return
+	}
+	dest = rates.Items
+
+	return
+}
+
+// GetRates returns the rates of the date from the first source of the chain that gives them,
+// a failed request is retried by the retry policy and none is made while the circuit of the date is open
+func (c *Client) GetRates(ctx context.Context, datetime time.Time) (dest Rates, err error) {
 	if datetime.IsZero() {
 		return dest, errors.New("datetime: cannot be blank")
 	}
 
-	for {
-		dest, err = c.getRatesByDate(ctx, datetime)
-		if err == nil {
-			break
+	breaker := c.breakerOf(datetime)
+	if !breaker.allow(time.Now()) {
+		c.metrics.rejected.Add(1)
+		return dest, ErrorCircuitOpen
+	}
+
+	dest, err = c.fetchRates(ctx, datetime)
+	if err != nil {
+		// the request the caller gave up on tells nothing about the feed
+		if errors.Is(err, context.Canceled) {
+			breaker.release()
+			return
 		}
+
+		c.metrics.fail(time.Now(), err)
+		if breaker.failure(time.Now()) {
+			c.metrics.breakerOpens.Add(1)
+		}
+		return
 	}
+	breaker.success()
+	c.metrics.succeed(time.Now(), dest.Source)
 
 	return
 }
 
-func (c *Client) getRatesByDate(ctx context.Context, datetime time.Time) (dest []Rate, err error) {
-	path, err := url.Parse(c.Credentials.URL)
-	if err != nil {
-		return
+// fetchRates asks the sources in the order of priority, the next source is asked once the retries of the previous one run out
+func (c *Client) fetchRates(ctx context.Context, datetime time.Time) (dest Rates, err error) {
+	if len(c.sources) == 0 {
+		return dest, errors.New("sources: cannot be blank")
 	}
-	path = path.JoinPath("/rss/get_rates.cfm")
 
-	params := url.Values{
-		"fdate": []string{datetime.Format("02.01.2006")},
-	}
-	path.RawQuery = params.Encode()
+	errs := make([]error, 0, len(c.sources))
+	for _, source := range c.sources {
+		dest, err = c.fetchSource(ctx, source, datetime)
+		if err == nil {
+			dest.Source = source.Name()
+			if dest.Base == "" {
+				dest.Base = BaseCurrency
+			}
+			return
+		}
+		c.metrics.failSource(source.Name())
+		errs = append(errs, fmt.Errorf("%s: %w", source.Name(), err))
 
-	currency := Response{}
-	if err = c.request(ctx, "GET", path.String(), &currency); err != nil {
-		return
+		if ctx.Err() != nil {
+			break
+		}
 	}
-	dest = currency.Rates
+	err = errors.Join(errs...)
 
 	return
 }
+
+// fetchSource requests the source until it answers or the attempts of the retry policy run out
+func (c *Client) fetchSource(ctx context.Context, source RateSource, datetime time.Time) (dest Rates, err error) {
+	for attempt := 1; ; attempt++ {
+		c.metrics.requests.Add(1)
+
+		dest, err = source.GetRates(ctx, datetime)
+		if err == nil || attempt >= c.retry.attempts {
+			return
+		}
+		c.metrics.retries.Add(1)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(c.retry.backoff(attempt)):
+		}
+	}
+}
@@ -0,0 +1,244 @@
+package currency
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// stubSource fails the first failures requests and answers with the rates after them, -1 fails every request
+type stubSource struct {
+	sync.Mutex
+	name     string
+	rates    Rates
+	failures int
+	requests int
+}
+
+func (s *stubSource) Name() string {
+	return s.name
+}
+
+func (s *stubSource) GetRates(ctx context.Context, datetime time.Time) (dest Rates, err error) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.requests++
+	if s.failures != 0 {
+		if s.failures > 0 {
+			s.failures--
+		}
+		return dest, errors.New("unavailable")
+	}
+
+	dest = s.rates
+	dest.Date = datetime
+
+	return
+}
+
+func (s *stubSource) count() int {
+	s.Lock()
+	defer s.Unlock()
+
+	return s.requests
+}
+
+func TestBackoff(t *testing.T) {
+	policy := retryPolicy{attempts: 10, delay: 100 * time.Millisecond, maxDelay: time.Second}
+
+	tests := []struct {
+		name     string
+		policy   retryPolicy
+		attempt  int
+		min, max time.Duration
+	}{
+		{name: "first attempt", policy: policy, attempt: 1, min: 50 * time.Millisecond, max: 100 * time.Millisecond},
+		{name: "doubled", policy: policy, attempt: 2, min: 100 * time.Millisecond, max: 200 * time.Millisecond},
+		{name: "doubled twice", policy: policy, attempt: 3, min: 200 * time.Millisecond, max: 400 * time.Millisecond},
+		{name: "capped by the max delay", policy: policy, attempt: 5, min: 500 * time.Millisecond, max: time.Second},
+		{name: "stays at the max delay", policy: policy, attempt: 30, min: 500 * time.Millisecond, max: time.Second},
+		{name: "no delay", policy: retryPolicy{attempts: 3}, attempt: 2, min: 0, max: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the jitter is random, so the bounds are checked over a number of draws
+			for i := 0; i < 100; i++ {
+				if got := tt.policy.backoff(tt.attempt); got < tt.min || got > tt.max {
+					t.Fatalf("backoff(%d) = %v, want within [%v, %v]", tt.attempt, got, tt.min, tt.max)
+				}
+			}
+		})
+	}
+}
+
+func TestGetRatesRetries(t *testing.T) {
+	tests := []struct {
+		name         string
+		configs      []Configuration
+		failures     int
+		wantRequests int
+		wantRetries  int64
+		wantErr      bool
+	}{
+		{name: "not retried by default", failures: -1, wantRequests: 1, wantErr: true},
+		{name: "answer isn't repeated", configs: []Configuration{WithRetry(3, time.Millisecond, 2*time.Millisecond)},
+			wantRequests: 1},
+		{name: "retried until the answer", configs: []Configuration{WithRetry(3, time.Millisecond, 2*time.Millisecond)},
+			failures: 2, wantRequests: 3, wantRetries: 2},
+		{name: "attempts run out", configs: []Configuration{WithRetry(3, time.Millisecond, 2*time.Millisecond)},
+			failures: -1, wantRequests: 3, wantRetries: 2, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := &stubSource{name: "stub", failures: tt.failures}
+			c, err := New(Credentials{}, append(tt.configs, WithSources(source))...)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			_, err = c.GetRates(context.Background(), time.Now())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetRates() error = %v, want error %v", err, tt.wantErr)
+			}
+
+			if got := source.count(); got != tt.wantRequests {
+				t.Errorf("requests = %d, want %d", got, tt.wantRequests)
+			}
+			if got := c.Metrics().Retries; got != tt.wantRetries {
+				t.Errorf("retries = %d, want %d", got, tt.wantRetries)
+			}
+		})
+	}
+}
+
+func TestBreaker(t *testing.T) {
+	type step struct {
+		at    time.Duration
+		call  string
+		want  bool
+		state string
+	}
+
+	tests := []struct {
+		name      string
+		threshold int
+		steps     []step
+	}{
+		{
+			name:      "opens once the threshold is reached and closes on the trial success",
+			threshold: 2,
+			steps: []step{
+				{at: 0, call: "allow", want: true, state: BreakerClosed},
+				{at: 0, call: "failure", want: false, state: BreakerClosed},
+				{at: 0, call: "failure", want: true, state: BreakerOpen},
+				{at: 30 * time.Second, call: "allow", want: false, state: BreakerOpen},
+				{at: time.Minute, call: "allow", want: true, state: BreakerHalfOpen},
+				{at: time.Minute, call: "allow", want: false, state: BreakerHalfOpen},
+				{at: time.Minute, call: "success", state: BreakerClosed},
+				{at: time.Minute, call: "allow", want: true, state: BreakerClosed},
+			},
+		},
+		{
+			name:      "trial failure opens it again",
+			threshold: 1,
+			steps: []step{
+				{at: 0, call: "failure", want: true, state: BreakerOpen},
+				{at: time.Minute, call: "allow", want: true, state: BreakerHalfOpen},
+				{at: time.Minute, call: "failure", want: true, state: BreakerOpen},
+				{at: time.Minute + 30*time.Second, call: "allow", want: false, state: BreakerOpen},
+				{at: 2 * time.Minute, call: "allow", want: true, state: BreakerHalfOpen},
+			},
+		},
+		{
+			name:      "success resets the failures in a row",
+			threshold: 2,
+			steps: []step{
+				{at: 0, call: "failure", want: false, state: BreakerClosed},
+				{at: 0, call: "success", state: BreakerClosed},
+				{at: 0, call: "failure", want: false, state: BreakerClosed},
+			},
+		},
+		{
+			name:      "given up trial lets the next request in",
+			threshold: 1,
+			steps: []step{
+				{at: 0, call: "failure", want: true, state: BreakerOpen},
+				{at: time.Minute, call: "allow", want: true, state: BreakerHalfOpen},
+				{at: time.Minute, call: "release", state: BreakerOpen},
+				{at: time.Minute, call: "allow", want: true, state: BreakerHalfOpen},
+			},
+		},
+		{
+			name:      "zero threshold never opens",
+			threshold: 0,
+			steps: []step{
+				{at: 0, call: "failure", want: false, state: BreakerClosed},
+				{at: 0, call: "failure", want: false, state: BreakerClosed},
+				{at: 0, call: "allow", want: true, state: BreakerClosed},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &breaker{threshold: tt.threshold, timeout: time.Minute}
+			start := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+
+			for i, step := range tt.steps {
+				now := start.Add(step.at)
+
+				var got bool
+				switch step.call {
+				case "allow":
+					got = b.allow(now)
+				case "failure":
+					got = b.failure(now)
+				case "success":
+					b.success()
+				case "release":
+					b.release()
+				}
+
+				if got != step.want {
+					t.Fatalf("step %d: %s() = %v, want %v", i, step.call, got, step.want)
+				}
+				if state := b.current(); state != step.state {
+					t.Fatalf("step %d: state after %s() = %s, want %s", i, step.call, state, step.state)
+				}
+			}
+		})
+	}
+}
+
+func TestGetRatesCircuitOfDate(t *testing.T) {
+	source := &stubSource{name: "stub", failures: -1}
+	c, err := New(Credentials{}, WithSources(source), WithCircuitBreaker(1, time.Minute))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	ctx := context.Background()
+
+	past := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	if _, err = c.GetRates(ctx, past); err == nil || errors.Is(err, ErrorCircuitOpen) {
+		t.Fatalf("GetRates(past) error = %v, want the source error", err)
+	}
+	if _, err = c.GetRates(ctx, past); !errors.Is(err, ErrorCircuitOpen) {
+		t.Fatalf("GetRates(past) error = %v, want %v", err, ErrorCircuitOpen)
+	}
+
+	// the past day missing in the feed doesn't stop the rates of today
+	if got := c.Metrics().BreakerState; got != BreakerClosed {
+		t.Errorf("breaker state = %s, want %s", got, BreakerClosed)
+	}
+	if _, err = c.GetRates(ctx, time.Now()); errors.Is(err, ErrorCircuitOpen) {
+		t.Errorf("GetRates(today) error = %v, want the source asked", err)
+	}
+	if got := source.count(); got != 2 {
+		t.Errorf("requests = %d, want 2", got)
+	}
+}
@@ -0,0 +1,117 @@
+package currency
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"github.com/shopspring/decimal"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ECBBaseCurrency is the currency the european central bank gives the rates in
+const ECBBaseCurrency = "EUR"
+
+// ecbDateLayout is the date format of the european central bank feed
+const ecbDateLayout = "2006-01-02"
+
+// ecbHistoryDays is how far back the short history file of the feed goes
+const ecbHistoryDays = 90
+
+// ECBResponse is the reference rates feed of the european central bank
+type ECBResponse struct {
+	XMLName xml.Name `xml:"Envelope"`
+	Days    []ECBDay `xml:"Cube>Cube"`
+}
+
+type ECBDay struct {
+	Time  string    `xml:"time,attr"`
+	Rates []ECBRate `xml:"Cube"`
+}
+
+// ECBRate is the amount of the currency a single euro is worth
+type ECBRate struct {
+	Currency string          `xml:"currency,attr"`
+	Rate     decimal.Decimal `xml:"rate,attr"`
+}
+
+// ECBSource reads the euro reference rates of the european central bank, the rates are turned
+// into the prices in euro of the currencies. The feed has no rates on weekends and holidays,
+// the last day published before the date is given instead
+type ECBSource struct {
+	url        string
+	httpClient *http.Client
+}
+
+func NewECBSource(url string, httpClient *http.Client) *ECBSource {
+	return &ECBSource{
+		url:        url,
+		httpClient: httpClient,
+	}
+}
+
+func (s *ECBSource) Name() string {
+	return SourceECB
+}
+
+func (s *ECBSource) GetRates(ctx context.Context, datetime time.Time) (dest Rates, err error) {
+	file := "eurofxref-daily.xml"
+	switch age := time.Since(datetime); {
+	case age > ecbHistoryDays*24*time.Hour:
+		file = "eurofxref-hist.xml"
+	case datetime.Format(ecbDateLayout) != time.Now().Format(ecbDateLayout):
+		file = "eurofxref-hist-90d.xml"
+	}
+
+	path, err := url.Parse(s.url)
+	if err != nil {
+		return
+	}
+	path = path.JoinPath(file)
+
+	var res ECBResponse
+	if err = requestXML(ctx, s.httpClient, "GET", path.String(), &res); err != nil {
+		return
+	}
+
+	date := datetime.Format(ecbDateLayout)
+	var day *ECBDay
+	for i := range res.Days {
+		if res.Days[i].Time > date {
+			continue
+		}
+		if day == nil || res.Days[i].Time > day.Time {
+			day = &res.Days[i]
+		}
+	}
+
+	if day == nil {
+		return dest, errors.New("rates of " + date + " are not published")
+	}
+
+	dest = Rates{
+		Base:  ECBBaseCurrency,
+		Items: make([]Rate, 0, len(day.Rates)),
+	}
+
+	dest.Date, err = time.Parse(ecbDateLayout, day.Time)
+	if err != nil {
+		return
+	}
+
+	for _, rate := range day.Rates {
+		if rate.Rate.IsZero() {
+			continue
+		}
+
+		dest.Items = append(dest.Items, Rate{
+			Title:    rate.Currency,
+			Fullname: rate.Currency,
+			Rate:     decimal.NewFromInt(1).DivRound(rate.Rate, 16),
+			Quant:    "1",
+		})
+	}
+
+	return
+}
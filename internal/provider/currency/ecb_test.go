@@ -0,0 +1,90 @@
+package currency
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestECBSource(t *testing.T) {
+	feed, err := os.ReadFile("testdata/ecb.xml")
+	if err != nil {
+		t.Fatalf("os.ReadFile() error = %v", err)
+	}
+
+	// the stand-in serves the same days whatever file is asked for and keeps the name of the last one
+	var mutex sync.Mutex
+	var file string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		file = path.Base(r.URL.Path)
+		mutex.Unlock()
+
+		w.Write(feed)
+	}))
+	t.Cleanup(server.Close)
+
+	source := NewECBSource(server.URL, server.Client())
+
+	tests := []struct {
+		name     string
+		date     time.Time
+		wantFile string
+		wantDate string
+		wantErr  bool
+	}{
+		{name: "published day", date: time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC),
+			wantFile: "eurofxref-hist.xml", wantDate: "2024-01-15"},
+		{name: "weekend gets the last published day", date: time.Date(2024, 1, 14, 0, 0, 0, 0, time.UTC),
+			wantFile: "eurofxref-hist.xml", wantDate: "2024-01-12"},
+		{name: "day before the history", date: time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+			wantFile: "eurofxref-hist.xml", wantErr: true},
+		{name: "recent day reads the short history", date: time.Now().AddDate(0, 0, -10),
+			wantFile: "eurofxref-hist-90d.xml", wantDate: "2024-01-15"},
+		{name: "today reads the daily file", date: time.Now(),
+			wantFile: "eurofxref-daily.xml", wantDate: "2024-01-15"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := source.GetRates(context.Background(), tt.date)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetRates() error = %v, want error %v", err, tt.wantErr)
+			}
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			if file != tt.wantFile {
+				t.Errorf("file = %s, want %s", file, tt.wantFile)
+			}
+
+			if tt.wantErr {
+				return
+			}
+			if date := got.Date.Format(ecbDateLayout); date != tt.wantDate || got.Base != ECBBaseCurrency {
+				t.Errorf("rates of %s in %s, want of %s in %s", date, got.Base, tt.wantDate, ECBBaseCurrency)
+			}
+		})
+	}
+
+	// the euro rates are turned into the prices in euro and the zero ones are dropped
+	got, err := source.GetRates(context.Background(), time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("GetRates() error = %v", err)
+	}
+
+	want := map[string]string{"USD": "0.9132420091324201", "JPY": "0.00625"}
+	if len(got.Items) != len(want) {
+		t.Fatalf("rates = %+v, want %v", got.Items, want)
+	}
+	for _, rate := range got.Items {
+		if rate.Rate.String() != want[rate.Title] || rate.Quant != "1" {
+			t.Errorf("rate of %s = %s per %s, want %s per 1", rate.Title, rate.Rate, rate.Quant, want[rate.Title])
+		}
+	}
+}
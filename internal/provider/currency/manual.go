@@ -0,0 +1,99 @@
+package currency
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/shopspring/decimal"
+	"os"
+	"strconv"
+	"time"
+)
+
+// ManualRates is the rate table kept by hand, the table gives the same rates for any date
+type ManualRates struct {
+	Base  string       `json:"base"`
+	Rates []ManualRate `json:"rates"`
+}
+
+type ManualRate struct {
+	Code  string          `json:"code"`
+	Name  string          `json:"name"`
+	Value decimal.Decimal `json:"value"`
+	Quant int             `json:"quant"`
+}
+
+// ManualStore keeps the manual rate table, a file or a database table the admins edit
+type ManualStore interface {
+	Load(ctx context.Context) (dest ManualRates, err error)
+}
+
+// ManualFile is the manual rate table in a json file, the file is read on each request
+// so that the edits take effect right away
+type ManualFile struct {
+	path string
+}
+
+func NewManualFile(path string) *ManualFile {
+	return &ManualFile{path: path}
+}
+
+func (f *ManualFile) Load(ctx context.Context) (dest ManualRates, err error) {
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(data, &dest)
+
+	return
+}
+
+// ManualSource gives the rates of the manual table, placed first in the chain it overrides the feeds
+type ManualSource struct {
+	store ManualStore
+}
+
+func NewManualSource(store ManualStore) *ManualSource {
+	return &ManualSource{store: store}
+}
+
+func (s *ManualSource) Name() string {
+	return SourceManual
+}
+
+func (s *ManualSource) GetRates(ctx context.Context, datetime time.Time) (dest Rates, err error) {
+	data, err := s.store.Load(ctx)
+	if err != nil {
+		return
+	}
+
+	if len(data.Rates) == 0 {
+		return dest, errors.New("rates: cannot be blank")
+	}
+
+	dest = Rates{
+		Date:  datetime,
+		Base:  data.Base,
+		Items: make([]Rate, 0, len(data.Rates)),
+	}
+
+	if dest.Base == "" {
+		dest.Base = BaseCurrency
+	}
+
+	for _, rate := range data.Rates {
+		quant := rate.Quant
+		if quant <= 0 {
+			quant = 1
+		}
+
+		dest.Items = append(dest.Items, Rate{
+			Title:    rate.Code,
+			Fullname: rate.Name,
+			Rate:     rate.Value,
+			Quant:    strconv.Itoa(quant),
+		})
+	}
+
+	return
+}
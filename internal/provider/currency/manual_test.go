@@ -0,0 +1,78 @@
+package currency
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// manualStoreFunc is the manual rate table given by the function
+type manualStoreFunc func(ctx context.Context) (ManualRates, error)
+
+func (f manualStoreFunc) Load(ctx context.Context) (ManualRates, error) {
+	return f(ctx)
+}
+
+func TestManualSource(t *testing.T) {
+	tests := []struct {
+		name     string
+		store    ManualStore
+		wantBase string
+		want     map[string]string
+		wantErr  bool
+	}{
+		{
+			name:     "file",
+			store:    NewManualFile("testdata/manual.json"),
+			wantBase: "KZT",
+			want:     map[string]string{"USD": "500 per 1", "JPY": "350 per 100"},
+		},
+		{
+			name: "blank base is the base currency",
+			store: manualStoreFunc(func(ctx context.Context) (ManualRates, error) {
+				return ManualRates{Rates: []ManualRate{{Code: "USD", Value: decimal.NewFromInt(500), Quant: 1}}}, nil
+			}),
+			wantBase: BaseCurrency,
+			want:     map[string]string{"USD": "500 per 1"},
+		},
+		{name: "empty table", store: NewManualFile("testdata/manual_empty.json"), wantErr: true},
+		{name: "missing file", store: NewManualFile("testdata/missing.json"), wantErr: true},
+		{
+			name: "store failure",
+			store: manualStoreFunc(func(ctx context.Context) (ManualRates, error) {
+				return ManualRates{}, errors.New("unavailable")
+			}),
+			wantErr: true,
+		},
+	}
+
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewManualSource(tt.store).GetRates(context.Background(), date)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetRates() error = %v, want error %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			// the table gives the same rates for any date
+			if !got.Date.Equal(date) || got.Base != tt.wantBase {
+				t.Errorf("rates of %v in %s, want of %v in %s", got.Date, got.Base, date, tt.wantBase)
+			}
+
+			if len(got.Items) != len(tt.want) {
+				t.Fatalf("rates = %+v, want %v", got.Items, tt.want)
+			}
+			for _, rate := range got.Items {
+				if value := rate.Rate.String() + " per " + rate.Quant; value != tt.want[rate.Title] {
+					t.Errorf("rate of %s = %s, want %s", rate.Title, value, tt.want[rate.Title])
+				}
+			}
+		})
+	}
+}
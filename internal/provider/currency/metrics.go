@@ -0,0 +1,112 @@
+package currency
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Metrics count the work of the client since it was created
+type Metrics struct {
+	// Requests are the requests made to the feed including the retries
+	Requests int64 `json:"requests"`
+	// Retries are the requests repeated after a failed one
+	Retries int64 `json:"retries"`
+	// Failures are the fetches which failed after all the retries
+	Failures int64 `json:"failures"`
+	// Rejected are the fetches refused while the circuit was open
+	Rejected int64 `json:"rejected"`
+	// BreakerOpens are the times the circuit has opened
+	BreakerOpens int64 `json:"breakerOpens"`
+	// StaleServed are the times the last known rates were served instead of the fresh ones
+	StaleServed int64 `json:"staleServed"`
+	// MissingCurrencies are the refreshes which didn't find a currency the refresher covers
+	MissingCurrencies int64 `json:"missingCurrencies"`
+
+	// SourceFailures are the fetches failed by each source of the chain
+	SourceFailures map[string]int64 `json:"sourceFailures"`
+
+	// BreakerState is the circuit of the rates of today and PastBreakerState is the one of the past days
+	BreakerState     string    `json:"breakerState"`
+	PastBreakerState string    `json:"pastBreakerState"`
+	LastSource       string    `json:"lastSource,omitempty"`
+	LastSuccess      time.Time `json:"lastSuccess"`
+	LastFailure      time.Time `json:"lastFailure"`
+	LastError        string    `json:"lastError,omitempty"`
+}
+
+type metrics struct {
+	requests          atomic.Int64
+	retries           atomic.Int64
+	failures          atomic.Int64
+	rejected          atomic.Int64
+	breakerOpens      atomic.Int64
+	staleServed       atomic.Int64
+	missingCurrencies atomic.Int64
+
+	sync.Mutex
+	sourceFailures map[string]int64
+	lastSource     string
+	lastSuccess    time.Time
+	lastFailure    time.Time
+	lastError      string
+}
+
+func (m *metrics) succeed(now time.Time, source string) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.lastSuccess = now
+	m.lastSource = source
+}
+
+func (m *metrics) failSource(source string) {
+	m.Lock()
+	defer m.Unlock()
+
+	if m.sourceFailures == nil {
+		m.sourceFailures = make(map[string]int64)
+	}
+	m.sourceFailures[source]++
+}
+
+func (m *metrics) fail(now time.Time, err error) {
+	m.failures.Add(1)
+
+	m.Lock()
+	defer m.Unlock()
+
+	m.lastFailure = now
+	m.lastError = err.Error()
+}
+
+// Metrics returns the snapshot of the client metrics
+func (c *Client) Metrics() (dest Metrics) {
+	m := c.metrics
+
+	dest = Metrics{
+		Requests:          m.requests.Load(),
+		Retries:           m.retries.Load(),
+		Failures:          m.failures.Load(),
+		Rejected:          m.rejected.Load(),
+		BreakerOpens:      m.breakerOpens.Load(),
+		StaleServed:       m.staleServed.Load(),
+		MissingCurrencies: m.missingCurrencies.Load(),
+		BreakerState:      c.breaker.current(),
+		PastBreakerState:  c.pastBreaker.current(),
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	dest.SourceFailures = make(map[string]int64, len(m.sourceFailures))
+	for source, count := range m.sourceFailures {
+		dest.SourceFailures[source] = count
+	}
+	dest.LastSource = m.lastSource
+	dest.LastSuccess = m.lastSuccess
+	dest.LastFailure = m.lastFailure
+	dest.LastError = m.lastError
+
+	return
+}
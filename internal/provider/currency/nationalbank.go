@@ -0,0 +1,56 @@
+package currency
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// NationalBankSource reads the rates in tenge from the RSS feed of the national bank
+type NationalBankSource struct {
+	url        string
+	httpClient *http.Client
+}
+
+func NewNationalBankSource(url string, httpClient *http.Client) *NationalBankSource {
+	return &NationalBankSource{
+		url:        url,
+		httpClient: httpClient,
+	}
+}
+
+func (s *NationalBankSource) Name() string {
+	return SourceNationalBank
+}
+
+// GetRates returns the rates of the date with the date the national bank published them on
+func (s *NationalBankSource) GetRates(ctx context.Context, datetime time.Time) (dest Rates, err error) {
+	path, err := url.Parse(s.url)
+	if err != nil {
+		return
+	}
+	path = path.JoinPath("/rss/get_rates.cfm")
+
+	params := url.Values{
+		"fdate": []string{datetime.Format(dateLayout)},
+	}
+	path.RawQuery = params.Encode()
+
+	var res Response
+	if err = requestXML(ctx, s.httpClient, "GET", path.String(), &res); err != nil {
+		return
+	}
+
+	dest = Rates{
+		Base:  BaseCurrency,
+		Items: res.Rates,
+	}
+
+	dest.Date, err = time.Parse(dateLayout, res.Date)
+	if err != nil {
+		dest.Date, err = time.Parse(dateLayout, datetime.Format(dateLayout))
+	}
+
+	return
+}
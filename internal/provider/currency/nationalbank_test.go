@@ -0,0 +1,75 @@
+package currency
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNationalBankSource(t *testing.T) {
+	feed, err := os.ReadFile("testdata/nationalbank.xml")
+	if err != nil {
+		t.Fatalf("os.ReadFile() error = %v", err)
+	}
+
+	// the feed of 15.01.2024 is published, the one of 16.01.2024 comes without its date and the others fail
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rss/get_rates.cfm" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		switch r.URL.Query().Get("fdate") {
+		case "15.01.2024":
+			w.Write(feed)
+		case "16.01.2024":
+			w.Write([]byte(strings.Replace(string(feed), "<date>15.01.2024</date>", "", 1)))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	source := NewNationalBankSource(server.URL, server.Client())
+
+	tests := []struct {
+		name     string
+		date     time.Time
+		wantDate string
+		wantErr  bool
+	}{
+		{name: "published date", date: time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC), wantDate: "15.01.2024"},
+		{name: "blank date is the one asked for", date: time.Date(2024, 1, 16, 12, 0, 0, 0, time.UTC), wantDate: "16.01.2024"},
+		{name: "feed failure", date: time.Date(2024, 1, 17, 0, 0, 0, 0, time.UTC), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := source.GetRates(context.Background(), tt.date)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetRates() error = %v, want error %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if date := got.Date.Format(dateLayout); date != tt.wantDate || got.Base != BaseCurrency {
+				t.Errorf("rates of %s in %s, want of %s in %s", date, got.Base, tt.wantDate, BaseCurrency)
+			}
+
+			want := map[string]string{"USD": "500 per 1", "EUR": "550 per 1", "JPY": "350 per 100"}
+			if len(got.Items) != len(want) {
+				t.Fatalf("rates = %+v, want %v", got.Items, want)
+			}
+			for _, rate := range got.Items {
+				if value := rate.Rate.String() + " per " + rate.Quant; value != want[rate.Title] {
+					t.Errorf("rate of %s = %s, want %s", rate.Title, value, want[rate.Title])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package currency
+
+import (
+	"time"
+)
+
+// BaseCurrency is the currency the national bank gives the rates in
+const BaseCurrency = "KZT"
+
+// dateLayout is the date format of the national bank feed
+const dateLayout = "02.01.2006"
+
+// Rates are the rates of the date, each rate is the price in the base currency of the quant of its currency.
+// Source is the name of the source which gave the rates, stale rates are the last known ones
+// served while the sources are unavailable
+type Rates struct {
+	Date   time.Time
+	Base   string
+	Items  []Rate
+	Source string
+	Stale  bool
+}
@@ -0,0 +1,32 @@
+package currency
+
+import (
+	"math/rand"
+	"time"
+)
+
+// retryPolicy is how many times in all a request is made and how long it waits in between
+type retryPolicy struct {
+	attempts int
+	delay    time.Duration
+	maxDelay time.Duration
+}
+
+// backoff returns the wait after the failed attempt, the delay doubles each attempt up to the max delay
+// and a random half of it is dropped
+func (p retryPolicy) backoff(attempt int) (delay time.Duration) {
+	delay = p.delay
+	for i := 1; i < attempt && delay < p.maxDelay; i++ {
+		delay *= 2
+	}
+
+	if delay > p.maxDelay {
+		delay = p.maxDelay
+	}
+
+	if half := int64(delay / 2); half > 0 {
+		delay = time.Duration(half + rand.Int63n(half+1))
+	}
+
+	return
+}
@@ -0,0 +1,82 @@
+package currency
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	// SourceNationalBank is the RSS feed of the national bank of Kazakhstan
+	SourceNationalBank = "nationalbank"
+	// SourceECB is the euro reference rates feed of the european central bank
+	SourceECB = "ecb"
+	// SourceManual is the rate table kept by hand
+	SourceManual = "manual"
+)
+
+// RateSource gives the rates of a date, the rates are the prices of the quants of the currencies in the base currency
+type RateSource interface {
+	Name() string
+	GetRates(ctx context.Context, datetime time.Time) (dest Rates, err error)
+}
+
+// SourceConfig holds the settings of all the sources, only the ones named in the chain are used
+type SourceConfig struct {
+	NationalBankURL string
+	ECBURL          string
+	ManualFile      string
+}
+
+// WithSources asks the sources for the rates in the order of priority, the national bank feed
+// of the credentials url is the only source unless configured
+func WithSources(sources ...RateSource) Configuration {
+	return func(c *Client) error {
+		c.sources = sources
+		return nil
+	}
+}
+
+// WithSourceChain asks the sources named in the order of priority for the rates
+func WithSourceChain(cfg SourceConfig, names ...string) Configuration {
+	return func(c *Client) (err error) {
+		c.sources, err = c.newSources(cfg, names...)
+		return
+	}
+}
+
+// PrimarySource returns the name of the first source of the chain, the rates of the other sources are a fallback
+func (c *Client) PrimarySource() string {
+	if len(c.sources) == 0 {
+		return ""
+	}
+
+	return c.sources[0].Name()
+}
+
+// newSources returns the sources by the names in the order of priority
+func (c *Client) newSources(cfg SourceConfig, names ...string) (dest []RateSource, err error) {
+	for _, name := range names {
+		switch name {
+		case SourceNationalBank:
+			if cfg.NationalBankURL == "" {
+				return nil, fmt.Errorf("source %q: url cannot be blank", name)
+			}
+			dest = append(dest, NewNationalBankSource(cfg.NationalBankURL, c.httpClient))
+		case SourceECB:
+			if cfg.ECBURL == "" {
+				return nil, fmt.Errorf("source %q: url cannot be blank", name)
+			}
+			dest = append(dest, NewECBSource(cfg.ECBURL, c.httpClient))
+		case SourceManual:
+			if cfg.ManualFile == "" {
+				return nil, fmt.Errorf("source %q: file cannot be blank", name)
+			}
+			dest = append(dest, NewManualSource(NewManualFile(cfg.ManualFile)))
+		default:
+			return nil, fmt.Errorf("source %q is unknown", name)
+		}
+	}
+
+	return
+}
@@ -0,0 +1,110 @@
+package currency
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSourceChain(t *testing.T) {
+	tests := []struct {
+		name         string
+		sources      []*stubSource
+		wantSource   string
+		wantErr      []string
+		wantRequests []int
+	}{
+		{
+			name:         "first source answers",
+			sources:      []*stubSource{{name: "first"}, {name: "second"}},
+			wantSource:   "first",
+			wantRequests: []int{1, 0},
+		},
+		{
+			name:         "next source answers once the first fails",
+			sources:      []*stubSource{{name: "first", failures: -1}, {name: "second"}},
+			wantSource:   "second",
+			wantRequests: []int{1, 1},
+		},
+		{
+			name:         "every source fails",
+			sources:      []*stubSource{{name: "first", failures: -1}, {name: "second", failures: -1}},
+			wantErr:      []string{"first: unavailable", "second: unavailable"},
+			wantRequests: []int{1, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sources := make([]RateSource, 0, len(tt.sources))
+			for _, source := range tt.sources {
+				sources = append(sources, source)
+			}
+
+			c, err := New(Credentials{}, WithSources(sources...))
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			got, err := c.GetRates(context.Background(), time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC))
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Fatalf("GetRates() error = %v, want %v", err, tt.wantErr)
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("GetRates() error = %v, want it to contain %q", err, want)
+				}
+			}
+
+			if err == nil && (got.Source != tt.wantSource || got.Base != BaseCurrency) {
+				t.Errorf("rates of %s in %s, want of %s in %s", got.Source, got.Base, tt.wantSource, BaseCurrency)
+			}
+
+			for i, source := range tt.sources {
+				if got := source.count(); got != tt.wantRequests[i] {
+					t.Errorf("requests of %s = %d, want %d", source.name, got, tt.wantRequests[i])
+				}
+			}
+		})
+	}
+}
+
+func TestWithSourceChain(t *testing.T) {
+	cfg := SourceConfig{NationalBankURL: "http://nationalbank", ECBURL: "http://ecb", ManualFile: "testdata/manual.json"}
+
+	tests := []struct {
+		name    string
+		cfg     SourceConfig
+		names   []string
+		want    []string
+		wantErr bool
+	}{
+		{name: "order of priority", cfg: cfg, names: []string{SourceManual, SourceECB, SourceNationalBank},
+			want: []string{SourceManual, SourceECB, SourceNationalBank}},
+		{name: "unknown source", cfg: cfg, names: []string{"bank"}, wantErr: true},
+		{name: "blank url", names: []string{SourceECB}, wantErr: true},
+		{name: "blank file", names: []string{SourceManual}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := New(Credentials{}, WithSourceChain(tt.cfg, tt.names...))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("New() error = %v, want error %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			var got []string
+			for _, source := range c.sources {
+				got = append(got, source.Name())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("sources = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package epay
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// Notification is the body epay posts to the postLink and the failurePostLink of the payment,
+// it isn't signed, so the transaction has to be verified with GetStatus before it's trusted
+type Notification struct {
+	ID             string  `json:"id"`
+	DateTime       string  `json:"dateTime"`
+	InvoiceID      string  `json:"invoiceId"`
+	Amount         float64 `json:"amount"`
+	Currency       string  `json:"currency"`
+	Terminal       string  `json:"terminal"`
+	AccountID      string  `json:"accountId"`
+	Description    string  `json:"description"`
+	Language       string  `json:"language"`
+	CardMask       string  `json:"cardMask"`
+	CardType       string  `json:"cardType"`
+	Issuer         string  `json:"issuer"`
+	Reference      string  `json:"reference"`
+	Secure         string  `json:"secure"`
+	TokenRecipient string  `json:"tokenRecipient"`
+	Code           string  `json:"code"`
+	Reason         string  `json:"reason"`
+	ReasonCode     int     `json:"reasonCode"`
+	Name           string  `json:"name"`
+	Email          string  `json:"email"`
+	Phone          string  `json:"phone"`
+	CardID         string  `json:"cardId"`
+}
+
+// ParseNotification reads the callback body of epay
+func ParseNotification(data []byte) (dst Notification, err error) {
+	if err = json.Unmarshal(data, &dst); err != nil {
+		return
+	}
+
+	if dst.InvoiceID == "" {
+		err = errors.New("invoiceId: cannot be blank")
+	}
+
+	return
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"io"
 	"net/http"
+	"sync"
 	"time"
 )
 
@@ -17,22 +18,41 @@ type Credentials struct {
 	OAuthURL       string
 	PaymentPageURL string
 	GlobalToken    TokenResponse
+
+	// defaults of the payment requests made through the terminal
+	TerminalID      string
+	Currency        string
+	BackLink        string
+	FailureBackLink string
+	PostLink        string
+	FailurePostLink string
 }
 
 type Client struct {
 	httpClient  *http.Client
 	credentials Credentials
+
+	tokenMutex sync.RWMutex
+
+	// the global token refresher run by Start
+	cancel   context.CancelFunc
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
 }
 
-func New(credentials Credentials) (client Client, err error) {
-	httpClient := http.DefaultClient
-	httpClient.Timeout = 30 * time.Second
+func New(credentials Credentials) (client *Client, err error) {
+	httpClient := &http.Client{
+		Timeout: 30 * time.Second,
+	}
 
-	client = Client{
+	client = &Client{
 		httpClient:  httpClient,
 		credentials: credentials,
+		stop:        make(chan struct{}),
+		done:        make(chan struct{}),
 	}
-	err = client.initGlobalTokenRefresher()
+	err = client.initGlobalToken()
 
 	return
 }
@@ -58,10 +78,18 @@ func (c *Client) request(ctx context.Context, repeat bool, method, url string, b
 
 	// check unauthorized status
 	if res.StatusCode == http.StatusUnauthorized && repeat {
-		if err = c.initGlobalTokenRefresher(); err != nil {
+		if err = c.refreshGlobalToken(ctx); err != nil {
 			return
 		}
-		return c.request(ctx, false, method, url, body, headers, out)
+
+		// the request is repeated with the refreshed token instead of the rejected one
+		retryHeaders := make(map[string]string, len(headers))
+		for key, value := range headers {
+			retryHeaders[key] = value
+		}
+		retryHeaders["Authorization"] = "Bearer " + c.GlobalToken()
+
+		return c.request(ctx, false, method, url, body, retryHeaders, out)
 	}
 
 	// read response body
@@ -74,6 +102,11 @@ func (c *Client) request(ctx context.Context, repeat bool, method, url string, b
 	if res.StatusCode != http.StatusOK {
 		return errors.New(string(data))
 	}
+
+	// the operations answer with an empty body
+	if len(data) == 0 || out == nil {
+		return
+	}
 	err = json.Unmarshal(data, &out)
 
 	return
@@ -0,0 +1,136 @@
+// Package epaytest provides a stand-in of epay for the tests of the packages calling it
+package epaytest
+
+import (
+	"encoding/json"
+	"exchanger/internal/provider/epay"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+)
+
+// Server serves the token, the transaction status and the operation endpoints of epay,
+// the operations move the transaction the way epay does
+type Server struct {
+	*httptest.Server
+
+	mutex        sync.Mutex
+	transactions map[string]epay.TransactionResponse
+	checks       int
+	failing      bool
+}
+
+// NewServer starts the stand-in knowing no transaction, the caller closes it
+func NewServer() *Server {
+	s := &Server{transactions: make(map[string]epay.TransactionResponse)}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+
+	return s
+}
+
+// Credentials returns the credentials of the client of the stand-in, the terminal takes tenge
+func (s *Server) Credentials() epay.Credentials {
+	return epay.Credentials{
+		URL:        s.URL,
+		OAuthURL:   s.URL,
+		Login:      "login",
+		Password:   "password",
+		TerminalID: "terminal",
+		Currency:   "KZT",
+	}
+}
+
+// SetTransaction makes the status endpoint report the transaction of its invoice
+func (s *Server) SetTransaction(data epay.TransactionResponse) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.transactions[data.InvoiceID] = data
+}
+
+// Transaction returns the transaction of the invoice as it is now
+func (s *Server) Transaction(invoiceID string) epay.TransactionResponse {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.transactions[invoiceID]
+}
+
+// SetFailing makes the status and the operation endpoints fail until it's called with false
+func (s *Server) SetFailing(failing bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.failing = failing
+}
+
+// Checks returns how many times the status of a transaction was asked for
+func (s *Server) Checks() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.checks
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if r.URL.Path == "/oauth2/token" {
+		json.NewEncoder(w).Encode(map[string]any{"access_token": "token", "expires_in": 7200})
+		return
+	}
+
+	if r.Header.Get("Authorization") != "Bearer token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	switch {
+	case strings.HasPrefix(r.URL.Path, "/check-status/payment/transaction/"):
+		s.checks++
+		if s.failing {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		data, ok := s.transactions[strings.TrimPrefix(r.URL.Path, "/check-status/payment/transaction/")]
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code":1373,"message":"Invalid InvoiceID"}`))
+			return
+		}
+		json.NewEncoder(w).Encode(epay.StatusResponse{ResultCode: "100", Transaction: data})
+	case strings.HasPrefix(r.URL.Path, "/operation/"):
+		if s.failing {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		// the path is /operation/{transaction id}/{operation}
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/operation/"), "/")
+		if len(parts) != 2 || !s.operate(parts[0], parts[1]) {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// operate moves the transaction by the operation, the operation on an unknown transaction fails
+func (s *Server) operate(transactionID, operation string) bool {
+	statuses := map[string]string{"charge": "CHARGE", "cancel": "CANCEL", "refund": "REFUND"}
+
+	for invoiceID, data := range s.transactions {
+		if data.ID != transactionID || statuses[operation] == "" {
+			continue
+		}
+
+		data.StatusName = statuses[operation]
+		s.transactions[invoiceID] = data
+		return true
+	}
+
+	return false
+}
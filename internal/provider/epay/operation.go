@@ -0,0 +1,44 @@
+package epay
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Charge captures the amount of the authorized transaction, the rest of the hold goes back to the card,
+// zero amount captures the whole transaction
+func (c *Client) Charge(ctx context.Context, token, transactionID string, amount int) (err error) {
+	return c.operate(ctx, token, transactionID, "charge", amount)
+}
+
+// Cancel releases the hold of the authorized transaction
+func (c *Client) Cancel(ctx context.Context, token, transactionID string) (err error) {
+	return c.operate(ctx, token, transactionID, "cancel", 0)
+}
+
+// Refund returns the amount of the charged transaction to the card, zero amount refunds the whole transaction
+func (c *Client) Refund(ctx context.Context, token, transactionID string, amount int) (err error) {
+	return c.operate(ctx, token, transactionID, "refund", amount)
+}
+
+func (c *Client) operate(ctx context.Context, token, transactionID, operation string, amount int) (err error) {
+	path, err := url.Parse(c.credentials.URL)
+	if err != nil {
+		return
+	}
+	path = path.JoinPath("/operation/", transactionID, operation)
+
+	if amount > 0 {
+		query := path.Query()
+		query.Set("amount", strconv.Itoa(amount))
+		path.RawQuery = query.Encode()
+	}
+
+	headers := map[string]string{
+		"Authorization": fmt.Sprintf("Bearer %s", token),
+	}
+
+	return c.request(ctx, true, "POST", path.String(), nil, headers, nil)
+}
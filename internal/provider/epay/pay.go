@@ -2,13 +2,15 @@ package epay
 
 import (
 	"context"
+	"embed"
 	"html/template"
 	"net/http"
-	"os"
-	"path/filepath"
 	"time"
 )
 
+//go:embed template/*.html
+var templates embed.FS
+
 type PaymentCardID struct {
 	ID string `json:"id"`
 }
@@ -31,6 +33,7 @@ type PaymentRequest struct {
 	Language        string        `json:"language"`
 	PaymentType     string        `json:"paymentType"`
 	CardID          PaymentCardID `json:"cardId"`
+	CardSave        bool          `json:"cardSave"`
 
 	HomebankToken  string `json:"-"`
 	PaymentPageURL string `json:"-"`
@@ -57,12 +60,33 @@ type PaymentResponse struct {
 	PaymentLink  string      `json:"paymentLink,omitempty"`
 }
 
-func (c *Client) PayByPaymentPage(ctx context.Context, w http.ResponseWriter, src PaymentRequest, dueDate time.Time) (err error) {
-	rootDir, err := os.Getwd()
-	if err != nil {
-		return
+// NewPaymentRequest returns the payment request of the invoice filled with the terminal defaults
+func (c *Client) NewPaymentRequest(invoiceID, amount, description string) PaymentRequest {
+	return PaymentRequest{
+		Amount:          amount,
+		Currency:        c.credentials.Currency,
+		TerminalID:      c.credentials.TerminalID,
+		InvoiceID:       invoiceID,
+		Description:     description,
+		BackLink:        c.credentials.BackLink,
+		FailureBackLink: c.credentials.FailureBackLink,
+		PostLink:        c.credentials.PostLink,
+		FailurePostLink: c.credentials.FailurePostLink,
+		Language:        "rus",
 	}
+}
 
+// Currency returns the currency the terminal accepts
+func (c *Client) Currency() string {
+	return c.credentials.Currency
+}
+
+// TerminalID returns the terminal the payments are made through
+func (c *Client) TerminalID() string {
+	return c.credentials.TerminalID
+}
+
+func (c *Client) PayByPaymentPage(ctx context.Context, w http.ResponseWriter, src PaymentRequest, dueDate time.Time) (err error) {
 	if !dueDate.IsZero() && (time.Now().Unix() > dueDate.Add(-1500*time.Second).Unix()) {
 		src.Status.Transaction.StatusName = "EXPIRED"
 		src.Status.Transaction.StatusDescription = "Истек срок оплаты"
@@ -71,15 +95,15 @@ func (c *Client) PayByPaymentPage(ctx context.Context, w http.ResponseWriter, sr
 	templateName := ""
 	switch src.Status.Transaction.StatusName {
 	case "NEW", "AUTH", "EXPIRED":
-		templateName = filepath.Join(rootDir, "pkg", "provider", "epay", "template", "pending.html")
+		templateName = "template/pending.html"
 	case "CHARGE":
-		templateName = filepath.Join(rootDir, "pkg", "provider", "epay", "template", "success.html")
+		templateName = "template/success.html"
 	case "CANCEL", "REFUND":
-		templateName = filepath.Join(rootDir, "pkg", "provider", "epay", "template", "cancelled.html")
+		templateName = "template/cancelled.html"
 	case "REJECT", "FAILED", "3D", "CANCEL_OLD":
-		templateName = filepath.Join(rootDir, "pkg", "provider", "epay", "template", "failed.html")
+		templateName = "template/failed.html"
 	default:
-		templateName = filepath.Join(rootDir, "pkg", "provider", "epay", "template", "payment.html")
+		templateName = "template/payment.html"
 
 		src.Token, err = c.GetPaymentToken(ctx, &src)
 		if err != nil {
@@ -88,10 +112,12 @@ func (c *Client) PayByPaymentPage(ctx context.Context, w http.ResponseWriter, sr
 		src.PaymentPageURL = c.credentials.PaymentPageURL
 	}
 
-	tmpl, err := template.ParseFiles(templateName)
+	tmpl, err := template.ParseFS(templates, templateName)
 	if err != nil {
 		return
 	}
 
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
 	return tmpl.Execute(w, src)
 }
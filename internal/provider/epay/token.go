@@ -17,26 +17,94 @@ type TokenResponse struct {
 	RefreshToken string          `json:"refresh_token"`
 }
 
-func (c *Client) initGlobalTokenRefresher() (err error) {
+// initGlobalToken gets the global token the client starts with
+func (c *Client) initGlobalToken() (err error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	c.credentials.GlobalToken, err = c.GetPaymentToken(ctx, nil)
-	if err != nil {
-		return
-	}
-	ticker := time.NewTicker(time.Duration(c.credentials.GlobalToken.ExpiresIn.IntPart()-60) * time.Second)
+	return c.refreshGlobalToken(ctx)
+}
+
+// Start refreshes the global token in a goroutine a minute before it expires until Stop is called
+func (c *Client) Start(ctx context.Context) {
+	ctx, c.cancel = context.WithCancel(ctx)
 
 	go func() {
+		defer close(c.done)
+
 		for {
-			<-ticker.C
-			c.credentials.GlobalToken, err = c.GetPaymentToken(ctx, nil)
+			timer := time.NewTimer(c.globalTokenLifetime())
+
+			select {
+			case <-c.stop:
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+
+			refreshCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+			c.refreshGlobalToken(refreshCtx)
+			cancel()
 		}
 	}()
+}
+
+// Stop waits for the running refresh to finish, the refresh is cancelled once the context is done
+func (c *Client) Stop(ctx context.Context) (err error) {
+	if c.cancel == nil {
+		return
+	}
+
+	c.stopOnce.Do(func() {
+		close(c.stop)
+	})
+
+	select {
+	case <-c.done:
+	case <-ctx.Done():
+		c.cancel()
+		<-c.done
+		err = ctx.Err()
+	}
+	c.cancel()
+
+	return
+}
+
+// GlobalToken returns the access token of the terminal used by the status requests
+func (c *Client) GlobalToken() string {
+	c.tokenMutex.RLock()
+	defer c.tokenMutex.RUnlock()
+
+	return c.credentials.GlobalToken.AccessToken
+}
+
+func (c *Client) refreshGlobalToken(ctx context.Context) (err error) {
+	token, err := c.GetPaymentToken(ctx, nil)
+	if err != nil {
+		return
+	}
+
+	c.tokenMutex.Lock()
+	c.credentials.GlobalToken = token
+	c.tokenMutex.Unlock()
 
 	return
 }
 
+// globalTokenLifetime returns the time to refresh the global token a minute before it expires
+func (c *Client) globalTokenLifetime() time.Duration {
+	c.tokenMutex.RLock()
+	defer c.tokenMutex.RUnlock()
+
+	lifetime := time.Duration(c.credentials.GlobalToken.ExpiresIn.IntPart()-60) * time.Second
+	if lifetime < time.Minute {
+		lifetime = time.Minute
+	}
+
+	return lifetime
+}
+
 func (c *Client) GetPaymentToken(ctx context.Context, src *PaymentRequest) (dst TokenResponse, err error) {
 	path, err := url.Parse(c.credentials.OAuthURL)
 	if err != nil {
@@ -46,7 +114,6 @@ func (c *Client) GetPaymentToken(ctx context.Context, src *PaymentRequest) (dst
 
 	body := &bytes.Buffer{}
 	writer := multipart.NewWriter(body)
-	defer writer.Close()
 
 	_ = writer.WriteField("client_id", c.credentials.Login)
 	_ = writer.WriteField("client_secret", c.credentials.Password)
@@ -60,10 +127,16 @@ func (c *Client) GetPaymentToken(ctx context.Context, src *PaymentRequest) (dst
 		_ = writer.WriteField("terminal", src.TerminalID)
 	}
 
+	// the closing boundary has to be written before the body is sent
+	if err = writer.Close(); err != nil {
+		return
+	}
+
 	headers := map[string]string{
 		"Content-Type": writer.FormDataContentType(),
 	}
-	err = c.request(ctx, true, "POST", path.String(), body, headers, &dst)
+	// the token request isn't authorized by the global token, so it is never repeated
+	err = c.request(ctx, false, "POST", path.String(), body, headers, &dst)
 
 	return
 }
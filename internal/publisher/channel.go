@@ -0,0 +1,32 @@
+package publisher
+
+import (
+	"context"
+	"exchanger/internal/domain/event"
+)
+
+// Channel publishes the events to the subscribers of the same process through a buffered channel,
+// the publish waits for room in the buffer until its context is done
+type Channel struct {
+	events chan event.Message
+}
+
+func NewChannel(size int) *Channel {
+	return &Channel{
+		events: make(chan event.Message, size),
+	}
+}
+
+func (p *Channel) Publish(ctx context.Context, data event.Message) (err error) {
+	select {
+	case p.events <- data:
+		return
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Events returns the channel the published events are received from
+func (p *Channel) Events() <-chan event.Message {
+	return p.events
+}
@@ -0,0 +1,82 @@
+package publisher_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"exchanger/internal/domain/event"
+	"exchanger/internal/publisher"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func message() event.Message {
+	return event.Message{
+		ID:        "1",
+		Type:      event.HireCreated,
+		EntityID:  "2",
+		Payload:   json.RawMessage(`{"id":"2"}`),
+		CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+}
+
+func TestChannel(t *testing.T) {
+	p := publisher.NewChannel(1)
+
+	if err := p.Publish(context.Background(), message()); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+
+	// the buffer is full, the publish gives up once its context is done
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := p.Publish(ctx, message()); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("publish to the full buffer: got %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	if got := <-p.Events(); got.ID != "1" || got.Type != event.HireCreated {
+		t.Errorf("events: got %+v, want the published event", got)
+	}
+}
+
+func TestWebhook(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		wantErr error
+	}{
+		{name: "delivers the signed event", status: http.StatusNoContent},
+		{name: "fails on the rejected event", status: http.StatusServiceUnavailable, wantErr: publisher.ErrorRejected},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+
+				if got, want := r.Header.Get("X-Signature"), "sha256="+publisher.Sign("secret", body); got != want {
+					t.Errorf("signature: got %q, want %q", got, want)
+				}
+				if got := r.Header.Get("X-Event-Type"); got != event.HireCreated {
+					t.Errorf("event type: got %q, want %q", got, event.HireCreated)
+				}
+
+				var got event.Message
+				if err := json.Unmarshal(body, &got); err != nil || got.ID != "1" || string(got.Payload) != `{"id":"2"}` {
+					t.Errorf("body: got %s, want the event", body)
+				}
+
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			err := publisher.NewWebhook(server.URL, "secret", time.Second).Publish(context.Background(), message())
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("publish: got %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
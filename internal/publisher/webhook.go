@@ -0,0 +1,75 @@
+package publisher
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"exchanger/internal/domain/event"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+var ErrorRejected = errors.New("webhook rejected the event")
+
+// Webhook publishes the events by posting them as JSON to the URL, any status but 2xx fails the publish.
+// The body is signed by HMAC-SHA256 of the secret in the X-Signature header unless the secret is blank
+type Webhook struct {
+	httpClient *http.Client
+	url        string
+	secret     string
+}
+
+func NewWebhook(url, secret string, timeout time.Duration) *Webhook {
+	return &Webhook{
+		httpClient: &http.Client{
+			Timeout: timeout,
+		},
+		url:    url,
+		secret: secret,
+	}
+}
+
+func (p *Webhook) Publish(ctx context.Context, data event.Message) (err error) {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Event-ID", data.ID)
+	req.Header.Set("X-Event-Type", data.Type)
+	if p.secret != "" {
+		req.Header.Set("X-Signature", "sha256="+Sign(p.secret, body))
+	}
+
+	res, err := p.httpClient.Do(req)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("%w: %s", ErrorRejected, res.Status)
+	}
+
+	return
+}
+
+// Sign returns the hex HMAC-SHA256 of the body the subscribers verify the X-Signature header by
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,53 @@
+package memory
+
+import (
+	"context"
+	"exchanger/internal/domain/audit"
+	"github.com/google/uuid"
+	"sort"
+	"sync"
+)
+
+type AuditRepository struct {
+	db map[string]audit.Entity
+	sync.RWMutex
+}
+
+func NewAuditRepository() *AuditRepository {
+	return &AuditRepository{
+		db: make(map[string]audit.Entity),
+	}
+}
+
+func (r *AuditRepository) List(ctx context.Context, entity, entityID string) (dest []audit.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest = make([]audit.Entity, 0)
+	for _, data := range r.db {
+		if data.Entity == entity && data.EntityID == entityID {
+			dest = append(dest, data)
+		}
+	}
+
+	sort.Slice(dest, func(i, j int) bool {
+		return dest[i].CreatedAt.Before(dest[j].CreatedAt)
+	})
+
+	return
+}
+
+func (r *AuditRepository) Add(ctx context.Context, data audit.Entity) (dest string, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	id := r.generateID()
+	data.ID = id
+	r.db[id] = data
+
+	return id, nil
+}
+
+func (r *AuditRepository) generateID() string {
+	return uuid.New().String()
+}
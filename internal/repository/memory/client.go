@@ -0,0 +1,73 @@
+package memory
+
+import (
+	"context"
+	"exchanger/internal/domain/client"
+	"exchanger/pkg/market"
+	"github.com/google/uuid"
+	"sync"
+)
+
+type ClientRepository struct {
+	db map[string]client.Entity
+	sync.RWMutex
+}
+
+func NewClientRepository() *ClientRepository {
+	return &ClientRepository{
+		db: make(map[string]client.Entity),
+	}
+}
+
+func (r *ClientRepository) Add(ctx context.Context, data client.Entity) (dest string, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	id := r.generateID()
+	data.ID = id
+	r.db[id] = data
+
+	return id, nil
+}
+
+func (r *ClientRepository) Get(ctx context.Context, id string) (dest client.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest, ok := r.db[id]
+	if !ok {
+		err = market.ErrorNotFound
+		return
+	}
+
+	return
+}
+
+func (r *ClientRepository) Update(ctx context.Context, id string, data client.Entity) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	dest, ok := r.db[id]
+	if !ok {
+		return market.ErrorNotFound
+	}
+
+	if data.Name != nil {
+		dest.Name = data.Name
+	}
+
+	if data.SecretHash != nil {
+		dest.SecretHash = data.SecretHash
+	}
+
+	if data.IsActive != nil {
+		dest.IsActive = data.IsActive
+	}
+	r.db[id] = dest
+
+	return
+}
+
+func (r *ClientRepository) generateID() string {
+	return uuid.New().String()
+}
@@ -0,0 +1,12 @@
+package memory
+
+import (
+	"context"
+	"exchanger/pkg/market"
+	"time"
+)
+
+// visible checks that the entity is listed and got in the context, the deleted one only while it's asked for
+func visible(ctx context.Context, deletedAt *time.Time) bool {
+	return deletedAt == nil || market.IncludesDeleted(ctx)
+}
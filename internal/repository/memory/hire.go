@@ -2,30 +2,87 @@ package memory
 
 import (
 	"context"
-	"database/sql"
 	"exchanger/internal/domain/hire"
+	"exchanger/pkg/market"
 	"github.com/google/uuid"
+	"sort"
+	"strings"
 	"sync"
+	"time"
 )
 
 type HireRepository struct {
-	db map[string]hire.Entity
+	db    map[string]hire.Entity
+	index map[string]map[string]bool
 	sync.RWMutex
 }
 
 func NewHireRepository() *HireRepository {
 	return &HireRepository{
-		db: make(map[string]hire.Entity),
+		db:    make(map[string]hire.Entity),
+		index: make(map[string]map[string]bool),
 	}
 }
 
-func (r *HireRepository) List(ctx context.Context) (dest []hire.Entity, err error) {
+func (r *HireRepository) List(ctx context.Context, q market.Query) (dest []hire.Entity, total int, err error) {
 	r.RLock()
 	defer r.RUnlock()
 
 	dest = make([]hire.Entity, 0, len(r.db))
 	for _, data := range r.db {
-		dest = append(dest, data)
+		if visible(ctx, data.DeletedAt) {
+			dest = append(dest, data)
+		}
+	}
+	dest, total = applyQuery(dest, q)
+
+	return
+}
+
+func (r *HireRepository) Search(ctx context.Context, s hire.Search) (dest []hire.SearchEntity, total int, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	terms := hire.Terms(s.Text)
+
+	dest = make([]hire.SearchEntity, 0)
+	for id := range r.lookup(terms) {
+		data := r.db[id]
+		if !visible(ctx, data.DeletedAt) {
+			continue
+		}
+		if s.CustomerID != "" && data.CustomerID != s.CustomerID {
+			continue
+		}
+		if s.Currency != "" && (data.Currency == nil || *data.Currency != s.Currency) {
+			continue
+		}
+		if s.AmountFrom != nil && (data.Amount == nil || data.Amount.LessThan(*s.AmountFrom)) {
+			continue
+		}
+		if s.AmountTo != nil && (data.Amount == nil || data.Amount.GreaterThan(*s.AmountTo)) {
+			continue
+		}
+
+		if res, ok := hire.Match(data, terms); ok {
+			dest = append(dest, res)
+		}
+	}
+	total = len(dest)
+
+	sort.Slice(dest, func(i, j int) bool {
+		if dest[i].Rank != dest[j].Rank {
+			return dest[i].Rank > dest[j].Rank
+		}
+		return dest[i].ID < dest[j].ID
+	})
+
+	if s.Offset >= len(dest) {
+		return dest[:0], total, nil
+	}
+	dest = dest[s.Offset:]
+	if s.Limit > 0 && s.Limit < len(dest) {
+		dest = dest[:s.Limit]
 	}
 
 	return
@@ -36,8 +93,9 @@ func (r *HireRepository) Add(ctx context.Context, data hire.Entity) (dest string
 	defer r.Unlock()
 
 	id := r.generateID()
-	data.ID = id
+	data.ID, data.Version = id, 1
 	r.db[id] = data
+	r.indexWords(data)
 
 	return id, nil
 }
@@ -47,8 +105,8 @@ func (r *HireRepository) Get(ctx context.Context, id string) (dest hire.Entity,
 	defer r.RUnlock()
 
 	dest, ok := r.db[id]
-	if !ok {
-		err = sql.ErrNoRows
+	if !ok || !visible(ctx, dest.DeletedAt) {
+		err = market.ErrorNotFound
 		return
 	}
 
@@ -59,26 +117,158 @@ func (r *HireRepository) Update(ctx context.Context, id string, data hire.Entity
 	r.Lock()
 	defer r.Unlock()
 
-	if _, ok := r.db[id]; !ok {
-		err = sql.ErrNoRows
+	dest, ok := r.db[id]
+	if !ok || dest.DeletedAt != nil {
+		return market.ErrorNotFound
+	}
+
+	if data.Version != 0 && data.Version != dest.Version {
+		return market.ErrorConflict
+	}
+
+	if data.JobName != nil {
+		dest.JobName = data.JobName
+	}
+
+	if data.Amount != nil {
+		dest.Amount = data.Amount
+	}
+
+	if data.Currency != nil {
+		dest.Currency = data.Currency
+	}
+
+	if data.Description != nil {
+		dest.Description = data.Description
+	}
+
+	if data.Position != nil {
+		dest.Position = data.Position
+	}
+
+	if data.Status != "" {
+		dest.Status = data.Status
+	}
+	dest.Version++
+	r.unindexWords(r.db[id])
+	r.db[id] = dest
+	r.indexWords(dest)
+
+	return
+}
+
+func (r *HireRepository) Delete(ctx context.Context, id string, version int) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	data, ok := r.db[id]
+	if !ok || data.DeletedAt != nil {
+		return market.ErrorNotFound
 	}
+
+	if version != 0 && version != data.Version {
+		return market.ErrorConflict
+	}
+
+	now := time.Now().UTC()
+	data.DeletedAt = &now
+	data.Version++
 	r.db[id] = data
 
 	return
 }
 
-func (r *HireRepository) Delete(ctx context.Context, id string) (err error) {
+func (r *HireRepository) Restore(ctx context.Context, id string) (err error) {
 	r.Lock()
 	defer r.Unlock()
 
-	if _, ok := r.db[id]; !ok {
-		err = sql.ErrNoRows
+	data, ok := r.db[id]
+	if !ok || data.DeletedAt == nil {
+		return market.ErrorNotFound
 	}
+	data.DeletedAt = nil
+	data.Version++
+	r.db[id] = data
+
+	return
+}
+
+func (r *HireRepository) ListDeleted(ctx context.Context, before time.Time) (dest []hire.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest = make([]hire.Entity, 0)
+	for _, data := range r.db {
+		if data.DeletedAt != nil && data.DeletedAt.Before(before) {
+			dest = append(dest, data)
+		}
+	}
+
+	return
+}
+
+func (r *HireRepository) Purge(ctx context.Context, id string) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	data, ok := r.db[id]
+	if !ok || data.DeletedAt == nil {
+		return market.ErrorNotFound
+	}
+	r.unindexWords(data)
 	delete(r.db, id)
 
 	return
 }
 
+// lookup returns the ids of the hires having a word that starts with every one of the terms
+func (r *HireRepository) lookup(terms []string) (ids map[string]bool) {
+	for _, term := range terms {
+		matched := make(map[string]bool)
+		for word, wordIDs := range r.index {
+			if !strings.HasPrefix(word, term) {
+				continue
+			}
+			for id := range wordIDs {
+				if ids == nil || ids[id] {
+					matched[id] = true
+				}
+			}
+		}
+		ids = matched
+	}
+
+	return
+}
+
+func (r *HireRepository) indexWords(data hire.Entity) {
+	for _, word := range r.words(data) {
+		if r.index[word] == nil {
+			r.index[word] = make(map[string]bool)
+		}
+		r.index[word][data.ID] = true
+	}
+}
+
+func (r *HireRepository) unindexWords(data hire.Entity) {
+	for _, word := range r.words(data) {
+		delete(r.index[word], data.ID)
+		if len(r.index[word]) == 0 {
+			delete(r.index, word)
+		}
+	}
+}
+
+func (r *HireRepository) words(data hire.Entity) (words []string) {
+	for _, value := range []*string{data.JobName, data.Description, data.Position} {
+		if value != nil {
+			words = append(words, hire.Terms(*value)...)
+		}
+	}
+
+	return
+}
+
 func (r *HireRepository) generateID() string {
 	return uuid.New().String()
 }
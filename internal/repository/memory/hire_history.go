@@ -0,0 +1,66 @@
+package memory
+
+import (
+	"context"
+	"exchanger/internal/domain/hire"
+	"github.com/google/uuid"
+	"sort"
+	"sync"
+)
+
+type HireHistoryRepository struct {
+	db map[string]hire.HistoryEntity
+	sync.RWMutex
+}
+
+func NewHireHistoryRepository() *HireHistoryRepository {
+	return &HireHistoryRepository{
+		db: make(map[string]hire.HistoryEntity),
+	}
+}
+
+func (r *HireHistoryRepository) List(ctx context.Context, hireID string) (dest []hire.HistoryEntity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest = make([]hire.HistoryEntity, 0)
+	for _, data := range r.db {
+		if data.HireID == hireID {
+			dest = append(dest, data)
+		}
+	}
+
+	sort.Slice(dest, func(i, j int) bool {
+		return dest[i].CreatedAt.Before(dest[j].CreatedAt)
+	})
+
+	return
+}
+
+func (r *HireHistoryRepository) Add(ctx context.Context, data hire.HistoryEntity) (dest string, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	id := r.generateID()
+	data.ID = id
+	r.db[id] = data
+
+	return id, nil
+}
+
+func (r *HireHistoryRepository) DeleteByHire(ctx context.Context, hireID string) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	for id, data := range r.db {
+		if data.HireID == hireID {
+			delete(r.db, id)
+		}
+	}
+
+	return
+}
+
+func (r *HireHistoryRepository) generateID() string {
+	return uuid.New().String()
+}
@@ -0,0 +1,91 @@
+package memory
+
+import (
+	"context"
+	"exchanger/internal/domain/event"
+	"exchanger/pkg/market"
+	"github.com/google/uuid"
+	"sort"
+	"sync"
+	"time"
+)
+
+type OutboxRepository struct {
+	db map[string]event.Entity
+	sync.RWMutex
+}
+
+func NewOutboxRepository() *OutboxRepository {
+	return &OutboxRepository{
+		db: make(map[string]event.Entity),
+	}
+}
+
+func (r *OutboxRepository) Add(ctx context.Context, data event.Entity) (dest string, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	id := r.generateID()
+	data.ID = id
+	r.db[id] = data
+
+	return id, nil
+}
+
+func (r *OutboxRepository) ListPending(ctx context.Context, limit int) (dest []event.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest = make([]event.Entity, 0)
+	for _, data := range r.db {
+		if data.PublishedAt == nil {
+			dest = append(dest, data)
+		}
+	}
+
+	sort.Slice(dest, func(i, j int) bool {
+		if !dest[i].CreatedAt.Equal(dest[j].CreatedAt) {
+			return dest[i].CreatedAt.Before(dest[j].CreatedAt)
+		}
+		return dest[i].ID < dest[j].ID
+	})
+
+	if limit > 0 && limit < len(dest) {
+		dest = dest[:limit]
+	}
+
+	return
+}
+
+func (r *OutboxRepository) MarkPublished(ctx context.Context, id string, at time.Time) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	data, ok := r.db[id]
+	if !ok {
+		return market.ErrorNotFound
+	}
+	data.PublishedAt = &at
+	r.db[id] = data
+
+	return
+}
+
+func (r *OutboxRepository) MarkFailed(ctx context.Context, id, reason string) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	data, ok := r.db[id]
+	if !ok {
+		return market.ErrorNotFound
+	}
+	data.Attempts++
+	data.LastError = reason
+	r.db[id] = data
+
+	return
+}
+
+func (r *OutboxRepository) generateID() string {
+	return uuid.New().String()
+}
@@ -0,0 +1,138 @@
+package memory
+
+import (
+	"context"
+	"exchanger/internal/domain/payment"
+	"exchanger/pkg/market"
+	"sort"
+	"sync"
+)
+
+type PaymentRepository struct {
+	db map[string]payment.Entity
+	sync.RWMutex
+}
+
+func NewPaymentRepository() *PaymentRepository {
+	return &PaymentRepository{
+		db: make(map[string]payment.Entity),
+	}
+}
+
+func (r *PaymentRepository) List(ctx context.Context, hireID string) (dest []payment.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest = make([]payment.Entity, 0)
+	for _, data := range r.db {
+		if data.HireID == hireID {
+			dest = append(dest, data)
+		}
+	}
+
+	sort.Slice(dest, func(i, j int) bool {
+		return dest[i].CreatedAt.Before(dest[j].CreatedAt)
+	})
+
+	return
+}
+
+func (r *PaymentRepository) ListByStatus(ctx context.Context, statuses ...string) (dest []payment.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest = make([]payment.Entity, 0)
+	for _, data := range r.db {
+		for _, status := range statuses {
+			if data.Status == status {
+				dest = append(dest, data)
+				break
+			}
+		}
+	}
+
+	sort.Slice(dest, func(i, j int) bool {
+		return dest[i].CreatedAt.Before(dest[j].CreatedAt)
+	})
+
+	return
+}
+
+func (r *PaymentRepository) Add(ctx context.Context, data payment.Entity) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	if _, ok := r.db[data.InvoiceID]; ok {
+		return market.ErrorAlreadyExists
+	}
+	if payment.IsActive(data.Status) && r.hasActive(data.HireID, data.InvoiceID) {
+		return payment.ErrorActivePayment
+	}
+	r.db[data.InvoiceID] = data
+
+	return
+}
+
+func (r *PaymentRepository) Get(ctx context.Context, invoiceID string) (dest payment.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest, ok := r.db[invoiceID]
+	if !ok {
+		err = market.ErrorNotFound
+		return
+	}
+
+	return
+}
+
+func (r *PaymentRepository) Update(ctx context.Context, invoiceID string, data payment.Entity) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	dest, ok := r.db[invoiceID]
+	if !ok {
+		return market.ErrorNotFound
+	}
+
+	if data.WorkerID != "" {
+		dest.WorkerID = data.WorkerID
+	}
+
+	if data.ChargedAmount != nil {
+		dest.ChargedAmount = data.ChargedAmount
+	}
+
+	if data.RefundedAmount != nil {
+		dest.RefundedAmount = data.RefundedAmount
+	}
+
+	if data.Status != "" {
+		if payment.IsActive(data.Status) && r.hasActive(dest.HireID, invoiceID) {
+			return market.ErrorAlreadyExists
+		}
+		dest.Status = data.Status
+	}
+
+	if data.ProviderStatus != "" {
+		dest.ProviderStatus = data.ProviderStatus
+	}
+
+	if data.ReleasedAt != nil {
+		dest.ReleasedAt = data.ReleasedAt
+	}
+	r.db[invoiceID] = dest
+
+	return
+}
+
+// hasActive checks that another payment of the hire is active, the way the unique index of the stores does
+func (r *PaymentRepository) hasActive(hireID, invoiceID string) bool {
+	for _, data := range r.db {
+		if data.HireID == hireID && data.InvoiceID != invoiceID && payment.IsActive(data.Status) {
+			return true
+		}
+	}
+
+	return false
+}
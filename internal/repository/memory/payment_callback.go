@@ -0,0 +1,53 @@
+package memory
+
+import (
+	"context"
+	"exchanger/internal/domain/payment"
+	"github.com/google/uuid"
+	"sort"
+	"sync"
+)
+
+type PaymentCallbackRepository struct {
+	db map[string]payment.CallbackEntity
+	sync.RWMutex
+}
+
+func NewPaymentCallbackRepository() *PaymentCallbackRepository {
+	return &PaymentCallbackRepository{
+		db: make(map[string]payment.CallbackEntity),
+	}
+}
+
+func (r *PaymentCallbackRepository) List(ctx context.Context, invoiceID string) (dest []payment.CallbackEntity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest = make([]payment.CallbackEntity, 0)
+	for _, data := range r.db {
+		if data.InvoiceID == invoiceID {
+			dest = append(dest, data)
+		}
+	}
+
+	sort.Slice(dest, func(i, j int) bool {
+		return dest[i].ReceivedAt.Before(dest[j].ReceivedAt)
+	})
+
+	return
+}
+
+func (r *PaymentCallbackRepository) Add(ctx context.Context, data payment.CallbackEntity) (dest string, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	id := r.generateID()
+	data.ID = id
+	r.db[id] = data
+
+	return id, nil
+}
+
+func (r *PaymentCallbackRepository) generateID() string {
+	return uuid.New().String()
+}
@@ -0,0 +1,53 @@
+package memory
+
+import (
+	"context"
+	"exchanger/internal/domain/payment"
+	"github.com/google/uuid"
+	"sort"
+	"sync"
+)
+
+type PaymentHistoryRepository struct {
+	db map[string]payment.HistoryEntity
+	sync.RWMutex
+}
+
+func NewPaymentHistoryRepository() *PaymentHistoryRepository {
+	return &PaymentHistoryRepository{
+		db: make(map[string]payment.HistoryEntity),
+	}
+}
+
+func (r *PaymentHistoryRepository) List(ctx context.Context, invoiceID string) (dest []payment.HistoryEntity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest = make([]payment.HistoryEntity, 0)
+	for _, data := range r.db {
+		if data.InvoiceID == invoiceID {
+			dest = append(dest, data)
+		}
+	}
+
+	sort.Slice(dest, func(i, j int) bool {
+		return dest[i].CreatedAt.Before(dest[j].CreatedAt)
+	})
+
+	return
+}
+
+func (r *PaymentHistoryRepository) Add(ctx context.Context, data payment.HistoryEntity) (dest string, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	id := r.generateID()
+	data.ID = id
+	r.db[id] = data
+
+	return id, nil
+}
+
+func (r *PaymentHistoryRepository) generateID() string {
+	return uuid.New().String()
+}
@@ -0,0 +1,118 @@
+package memory
+
+import (
+	"context"
+	"exchanger/internal/domain/proposal"
+	"exchanger/pkg/market"
+	"github.com/google/uuid"
+	"sync"
+)
+
+type ProposalRepository struct {
+	db map[string]proposal.Entity
+	sync.RWMutex
+}
+
+func NewProposalRepository() *ProposalRepository {
+	return &ProposalRepository{
+		db: make(map[string]proposal.Entity),
+	}
+}
+
+func (r *ProposalRepository) List(ctx context.Context, hireID string, q market.Query) (dest []proposal.Entity, total int, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest = make([]proposal.Entity, 0, len(r.db))
+	for _, data := range r.db {
+		dest = append(dest, data)
+	}
+	dest, total = applyQuery(dest, q.Where("hire_id", hireID))
+
+	return
+}
+
+func (r *ProposalRepository) Add(ctx context.Context, data proposal.Entity) (dest string, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	for _, object := range r.db {
+		if object.HireID == data.HireID && object.WorkerID == data.WorkerID {
+			return "", market.ErrorAlreadyExists
+		}
+	}
+
+	id := r.generateID()
+	data.ID = id
+	r.db[id] = data
+
+	return id, nil
+}
+
+func (r *ProposalRepository) Get(ctx context.Context, id string) (dest proposal.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest, ok := r.db[id]
+	if !ok {
+		err = market.ErrorNotFound
+		return
+	}
+
+	return
+}
+
+func (r *ProposalRepository) Update(ctx context.Context, id string, data proposal.Entity) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	dest, ok := r.db[id]
+	if !ok {
+		return market.ErrorNotFound
+	}
+
+	if data.CoverLetter != nil {
+		dest.CoverLetter = data.CoverLetter
+	}
+
+	if data.Amount != nil {
+		dest.Amount = data.Amount
+	}
+
+	if data.Status != "" {
+		dest.Status = data.Status
+	}
+	r.db[id] = dest
+
+	return
+}
+
+func (r *ProposalRepository) DeleteByHire(ctx context.Context, hireID string) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	for id, data := range r.db {
+		if data.HireID == hireID {
+			delete(r.db, id)
+		}
+	}
+
+	return
+}
+
+func (r *ProposalRepository) CountByWorker(ctx context.Context, workerID string) (count int, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	for _, data := range r.db {
+		if data.WorkerID == workerID {
+			count++
+		}
+	}
+
+	return
+}
+
+func (r *ProposalRepository) generateID() string {
+	return uuid.New().String()
+}
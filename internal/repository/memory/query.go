@@ -0,0 +1,113 @@
+package memory
+
+import (
+	"exchanger/pkg/market"
+	"fmt"
+	"github.com/shopspring/decimal"
+	"reflect"
+	"sort"
+)
+
+// applyQuery filters, sorts and pages the items by the db columns of their fields,
+// it returns the page and the number of items matching the filters
+func applyQuery[T any](items []T, q market.Query) (dest []T, total int) {
+	dest = make([]T, 0, len(items))
+	for _, item := range items {
+		if matchFilters(item, q.Filters) {
+			dest = append(dest, item)
+		}
+	}
+	total = len(dest)
+
+	sort.SliceStable(dest, func(i, j int) bool {
+		a, b := columnValue(dest[i], q.Sort), columnValue(dest[j], q.Sort)
+		if compareValues(a, b) == 0 {
+			a, b = columnValue(dest[i], "id"), columnValue(dest[j], "id")
+		}
+		if q.Order == market.OrderDesc {
+			return compareValues(a, b) > 0
+		}
+		return compareValues(a, b) < 0
+	})
+
+	if q.Offset >= len(dest) {
+		return dest[:0], total
+	}
+	dest = dest[q.Offset:]
+	if q.Limit > 0 && q.Limit < len(dest) {
+		dest = dest[:q.Limit]
+	}
+
+	return
+}
+
+func matchFilters(item any, filters map[string]any) bool {
+	for column, value := range filters {
+		field := columnValue(item, column)
+		if field == nil || fmt.Sprint(field) != fmt.Sprint(value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// columnValue returns the dereferenced value of the field tagged with the db column
+func columnValue(item any, column string) any {
+	value := reflect.ValueOf(item)
+	for i := 0; i < value.NumField(); i++ {
+		if value.Type().Field(i).Tag.Get("db") != column {
+			continue
+		}
+
+		field := value.Field(i)
+		if field.Kind() == reflect.Pointer {
+			if field.IsNil() {
+				return nil
+			}
+			field = field.Elem()
+		}
+		return field.Interface()
+	}
+
+	return nil
+}
+
+// compareValues orders nil first, then numbers and strings by their natural order
+func compareValues(a, b any) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+
+	if x, ok := a.(int); ok {
+		if y, ok := b.(int); ok {
+			switch {
+			case x < y:
+				return -1
+			case x > y:
+				return 1
+			}
+			return 0
+		}
+	}
+
+	if x, ok := a.(decimal.Decimal); ok {
+		if y, ok := b.(decimal.Decimal); ok {
+			return x.Cmp(y)
+		}
+	}
+
+	x, y := fmt.Sprint(a), fmt.Sprint(b)
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,53 @@
+package memory
+
+import (
+	"context"
+	"exchanger/internal/domain/rate"
+	"sort"
+	"sync"
+	"time"
+)
+
+type RateRepository struct {
+	db map[string]rate.Entity
+	sync.RWMutex
+}
+
+func NewRateRepository() *RateRepository {
+	return &RateRepository{
+		db: make(map[string]rate.Entity),
+	}
+}
+
+func (r *RateRepository) List(ctx context.Context, date time.Time) (dest []rate.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest = make([]rate.Entity, 0)
+	for _, data := range r.db {
+		if data.Date.Equal(date) {
+			dest = append(dest, data)
+		}
+	}
+
+	sort.Slice(dest, func(i, j int) bool {
+		return dest[i].Code < dest[j].Code
+	})
+
+	return
+}
+
+func (r *RateRepository) Add(ctx context.Context, data []rate.Entity) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	for _, object := range data {
+		object.ID = rate.NewID(object.Date, object.Code)
+		if _, ok := r.db[object.ID]; ok {
+			continue
+		}
+		r.db[object.ID] = object
+	}
+
+	return
+}
@@ -0,0 +1,91 @@
+package memory
+
+import (
+	"context"
+	"exchanger/internal/domain/token"
+	"exchanger/pkg/market"
+	"sync"
+	"time"
+)
+
+type TokenRepository struct {
+	db map[string]token.Entity
+	sync.RWMutex
+}
+
+func NewTokenRepository() *TokenRepository {
+	return &TokenRepository{
+		db: make(map[string]token.Entity),
+	}
+}
+
+func (r *TokenRepository) Add(ctx context.Context, data token.Entity) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.db[data.ID] = data
+
+	return
+}
+
+func (r *TokenRepository) Get(ctx context.Context, id string) (dest token.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest, ok := r.db[id]
+	if !ok {
+		err = market.ErrorNotFound
+		return
+	}
+
+	return
+}
+
+func (r *TokenRepository) Rotate(ctx context.Context, id, refreshTokenID string) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	dest, ok := r.db[id]
+	if !ok || dest.RefreshTokenID != refreshTokenID || dest.RotatedAt != nil || dest.RevokedAt != nil {
+		return market.ErrorNotFound
+	}
+	now := time.Now()
+	dest.RotatedAt = &now
+	r.db[id] = dest
+
+	return
+}
+
+func (r *TokenRepository) Revoke(ctx context.Context, id string) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	dest, ok := r.db[id]
+	if !ok {
+		return market.ErrorNotFound
+	}
+
+	if dest.RevokedAt == nil {
+		now := time.Now()
+		dest.RevokedAt = &now
+		r.db[id] = dest
+	}
+
+	return
+}
+
+func (r *TokenRepository) RevokeByCredential(ctx context.Context, credential string) (count int64, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	now := time.Now()
+	for id, data := range r.db {
+		if data.Credential == credential && data.RevokedAt == nil {
+			data.RevokedAt = &now
+			r.db[id] = data
+			count++
+		}
+	}
+
+	return
+}
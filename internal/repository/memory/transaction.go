@@ -0,0 +1,30 @@
+package memory
+
+import (
+	"context"
+	"sync"
+)
+
+type transaction struct{}
+
+// UnitOfWork runs the units of work of the memory store one at a time, so that they don't interleave.
+// The memory store can't roll the changes back, a failed unit of work keeps the changes made before the failure
+type UnitOfWork struct {
+	sync.Mutex
+}
+
+func NewUnitOfWork() *UnitOfWork {
+	return &UnitOfWork{}
+}
+
+// Do runs fn holding the lock of the store, the unit of work started inside another one runs under the outer lock
+func (u *UnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if ctx.Value(transaction{}) != nil {
+		return fn(ctx)
+	}
+
+	u.Lock()
+	defer u.Unlock()
+
+	return fn(context.WithValue(ctx, transaction{}, u))
+}
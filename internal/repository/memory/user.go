@@ -0,0 +1,93 @@
+package memory
+
+import (
+	"context"
+	"exchanger/internal/domain/user"
+	"exchanger/pkg/market"
+	"github.com/google/uuid"
+	"sync"
+)
+
+type UserRepository struct {
+	db map[string]user.Entity
+	sync.RWMutex
+}
+
+func NewUserRepository() *UserRepository {
+	return &UserRepository{
+		db: make(map[string]user.Entity),
+	}
+}
+
+func (r *UserRepository) Add(ctx context.Context, data user.Entity) (dest string, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	for _, object := range r.db {
+		if *object.Username == *data.Username {
+			return "", market.ErrorAlreadyExists
+		}
+	}
+
+	id := r.generateID()
+	data.ID = id
+	r.db[id] = data
+
+	return id, nil
+}
+
+func (r *UserRepository) Get(ctx context.Context, id string) (dest user.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest, ok := r.db[id]
+	if !ok {
+		err = market.ErrorNotFound
+		return
+	}
+
+	return
+}
+
+func (r *UserRepository) GetByUsername(ctx context.Context, username string) (dest user.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	for _, object := range r.db {
+		if *object.Username == username {
+			return object, nil
+		}
+	}
+	err = market.ErrorNotFound
+
+	return
+}
+
+func (r *UserRepository) Update(ctx context.Context, id string, data user.Entity) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	dest, ok := r.db[id]
+	if !ok {
+		return market.ErrorNotFound
+	}
+
+	if data.PasswordHash != nil {
+		dest.PasswordHash = data.PasswordHash
+	}
+
+	if data.Role != nil {
+		dest.Role = data.Role
+	}
+
+	if data.IsActive != nil {
+		dest.IsActive = data.IsActive
+	}
+	r.db[id] = dest
+
+	return
+}
+
+func (r *UserRepository) generateID() string {
+	return uuid.New().String()
+}
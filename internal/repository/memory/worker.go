@@ -2,10 +2,11 @@ package memory
 
 import (
 	"context"
-	"database/sql"
 	"exchanger/internal/domain/worker"
+	"exchanger/pkg/market"
 	"github.com/google/uuid"
 	"sync"
+	"time"
 )
 
 type WorkerRepository struct {
@@ -19,14 +20,17 @@ func NewWorkerRepository() *WorkerRepository {
 	}
 }
 
-func (r *WorkerRepository) List(ctx context.Context) (dest []worker.Entity, err error) {
+func (r *WorkerRepository) List(ctx context.Context, q market.Query) (dest []worker.Entity, total int, err error) {
 	r.RLock()
 	defer r.RUnlock()
 
 	dest = make([]worker.Entity, 0, len(r.db))
 	for _, data := range r.db {
-		dest = append(dest, data)
+		if visible(ctx, data.DeletedAt) {
+			dest = append(dest, data)
+		}
 	}
+	dest, total = applyQuery(dest, q)
 
 	return
 }
@@ -36,7 +40,7 @@ func (r *WorkerRepository) Add(ctx context.Context, data worker.Entity) (dest st
 	defer r.Unlock()
 
 	id := r.generateID()
-	data.ID = id
+	data.ID, data.Version = id, 1
 	r.db[id] = data
 
 	return id, nil
@@ -47,8 +51,8 @@ func (r *WorkerRepository) Get(ctx context.Context, id string) (dest worker.Enti
 	defer r.RUnlock()
 
 	dest, ok := r.db[id]
-	if !ok {
-		err = sql.ErrNoRows
+	if !ok || !visible(ctx, dest.DeletedAt) {
+		err = market.ErrorNotFound
 		return
 	}
 
@@ -59,20 +63,93 @@ func (r *WorkerRepository) Update(ctx context.Context, id string, data worker.En
 	r.Lock()
 	defer r.Unlock()
 
-	if _, ok := r.db[id]; !ok {
-		err = sql.ErrNoRows
+	dest, ok := r.db[id]
+	if !ok || dest.DeletedAt != nil {
+		return market.ErrorNotFound
+	}
+
+	if data.Version != 0 && data.Version != dest.Version {
+		return market.ErrorConflict
+	}
+
+	if data.FullName != nil {
+		dest.FullName = data.FullName
+	}
+
+	if data.Pseudonym != nil {
+		dest.Pseudonym = data.Pseudonym
+	}
+
+	if data.Description != nil {
+		dest.Description = data.Description
+	}
+
+	if data.Position != nil {
+		dest.Position = data.Position
+	}
+	dest.Version++
+	r.db[id] = dest
+
+	return
+}
+
+func (r *WorkerRepository) Delete(ctx context.Context, id string, version int) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	data, ok := r.db[id]
+	if !ok || data.DeletedAt != nil {
+		return market.ErrorNotFound
+	}
+
+	if version != 0 && version != data.Version {
+		return market.ErrorConflict
+	}
+
+	now := time.Now().UTC()
+	data.DeletedAt = &now
+	data.Version++
+	r.db[id] = data
+
+	return
+}
+
+func (r *WorkerRepository) Restore(ctx context.Context, id string) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	data, ok := r.db[id]
+	if !ok || data.DeletedAt == nil {
+		return market.ErrorNotFound
 	}
+	data.DeletedAt = nil
+	data.Version++
 	r.db[id] = data
 
 	return
 }
 
-func (r *WorkerRepository) Delete(ctx context.Context, id string) (err error) {
+func (r *WorkerRepository) ListDeleted(ctx context.Context, before time.Time) (dest []worker.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest = make([]worker.Entity, 0)
+	for _, data := range r.db {
+		if data.DeletedAt != nil && data.DeletedAt.Before(before) {
+			dest = append(dest, data)
+		}
+	}
+
+	return
+}
+
+func (r *WorkerRepository) Purge(ctx context.Context, id string) (err error) {
 	r.Lock()
 	defer r.Unlock()
 
-	if _, ok := r.db[id]; !ok {
-		err = sql.ErrNoRows
+	data, ok := r.db[id]
+	if !ok || data.DeletedAt == nil {
+		return market.ErrorNotFound
 	}
 	delete(r.db, id)
 
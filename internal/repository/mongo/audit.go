@@ -0,0 +1,60 @@
+package mongo
+
+import (
+	"context"
+	"exchanger/internal/domain/audit"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type AuditRepository struct {
+	db *mongo.Collection
+}
+
+func NewAuditRepository(db *mongo.Database) *AuditRepository {
+	return &AuditRepository{
+		db: db.Collection("audit"),
+	}
+}
+
+// CreateIndexes creates the index the changes of an entity are listed by
+func (r *AuditRepository) CreateIndexes(ctx context.Context) (err error) {
+	model := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "entity", Value: 1},
+			{Key: "entity_id", Value: 1},
+			{Key: "created_at", Value: 1},
+		},
+		Options: options.Index().
+			SetName("audit_entity_idx"),
+	}
+	_, err = r.db.Indexes().CreateOne(ctx, model)
+
+	return
+}
+
+func (r *AuditRepository) List(ctx context.Context, entity, entityID string) (dest []audit.Entity, err error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}})
+
+	cur, err := r.db.Find(ctx, bson.M{"entity": entity, "entity_id": entityID}, opts)
+	if err != nil {
+		return
+	}
+
+	dest = make([]audit.Entity, 0)
+	err = cur.All(ctx, &dest)
+
+	return
+}
+
+func (r *AuditRepository) Add(ctx context.Context, data audit.Entity) (id string, err error) {
+	data.ID = primitive.NewObjectID().Hex()
+
+	if _, err = r.db.InsertOne(ctx, data); err != nil {
+		return "", err
+	}
+
+	return data.ID, nil
+}
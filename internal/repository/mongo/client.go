@@ -0,0 +1,76 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"exchanger/internal/domain/client"
+	"exchanger/pkg/market"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type ClientRepository struct {
+	db *mongo.Collection
+}
+
+func NewClientRepository(db *mongo.Database) *ClientRepository {
+	return &ClientRepository{
+		db: db.Collection("clients"),
+	}
+}
+
+func (r *ClientRepository) Add(ctx context.Context, data client.Entity) (id string, err error) {
+	data.ID = primitive.NewObjectID().Hex()
+
+	if _, err = r.db.InsertOne(ctx, data); err != nil {
+		return "", err
+	}
+
+	return data.ID, nil
+}
+
+func (r *ClientRepository) Get(ctx context.Context, id string) (dest client.Entity, err error) {
+	if err = r.db.FindOne(ctx, bson.M{"_id": id}).Decode(&dest); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			err = market.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *ClientRepository) Update(ctx context.Context, id string, data client.Entity) (err error) {
+	args := r.prepareArgs(data)
+	if len(args) > 0 {
+
+		out, err := r.db.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": args})
+		if err != nil {
+			return err
+		}
+
+		if out.MatchedCount == 0 {
+			return market.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *ClientRepository) prepareArgs(data client.Entity) (args bson.M) {
+	args = bson.M{}
+
+	if data.Name != nil {
+		args["name"] = data.Name
+	}
+
+	if data.SecretHash != nil {
+		args["secret_hash"] = data.SecretHash
+	}
+
+	if data.IsActive != nil {
+		args["is_active"] = data.IsActive
+	}
+
+	return
+}
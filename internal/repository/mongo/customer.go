@@ -8,6 +8,8 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
 )
 
 type CustomerRepository struct {
@@ -20,30 +22,39 @@ func NewCustomerRepository(db *mongo.Database) *CustomerRepository {
 	}
 }
 
-func (r *CustomerRepository) List(ctx context.Context) (dest []customer.Entity, err error) {
-	cur, err := r.db.Find(ctx, bson.M{})
+func (r *CustomerRepository) List(ctx context.Context, q market.Query) (dest []customer.Entity, total int, err error) {
+	filter, opts := prepareQuery(q)
+	filter = excludeDeleted(ctx, filter)
+
+	count, err := r.db.CountDocuments(ctx, filter)
 	if err != nil {
-		return nil, err
+		return
 	}
+	total = int(count)
 
-	if err = cur.All(ctx, &dest); err != nil {
-		return nil, err
+	cur, err := r.db.Find(ctx, filter, opts)
+	if err != nil {
+		return
 	}
 
+	dest = make([]customer.Entity, 0, q.Limit)
+	err = cur.All(ctx, &dest)
+
 	return
 }
 
 func (r *CustomerRepository) Add(ctx context.Context, data customer.Entity) (id string, err error) {
-	res, err := r.db.InsertOne(ctx, data)
-	if err != nil {
+	data.ID, data.Version = primitive.NewObjectID().Hex(), 1
+
+	if _, err = r.db.InsertOne(ctx, data); err != nil {
 		return "", err
 	}
 
-	return res.InsertedID.(primitive.ObjectID).String(), nil
+	return data.ID, nil
 }
 
 func (r *CustomerRepository) Get(ctx context.Context, id string) (dest customer.Entity, err error) {
-	if err = r.db.FindOne(ctx, bson.M{"_id": id}).Decode(&dest); err != nil {
+	if err = r.db.FindOne(ctx, excludeDeleted(ctx, bson.M{"_id": id})).Decode(&dest); err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
 			err = market.ErrorNotFound
 		}
@@ -56,13 +67,14 @@ func (r *CustomerRepository) Update(ctx context.Context, id string, data custome
 	args := r.prepareArgs(data)
 	if len(args) > 0 {
 
-		out, err := r.db.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": args})
+		update := bson.M{"$set": args, "$inc": bson.M{"version": 1}}
+		out, err := r.db.UpdateOne(ctx, versionFilter(id, data.Version), update)
 		if err != nil {
 			return err
 		}
 
 		if out.MatchedCount == 0 {
-			return market.ErrorNotFound
+			return missingOrConflict(ctx, r.db, id, data.Version)
 		}
 	}
 
@@ -70,6 +82,8 @@ func (r *CustomerRepository) Update(ctx context.Context, id string, data custome
 }
 
 func (r *CustomerRepository) prepareArgs(data customer.Entity) (args bson.M) {
+	args = bson.M{}
+
 	if data.FullName != nil {
 		args["full_name"] = data.FullName
 	}
@@ -81,8 +95,53 @@ func (r *CustomerRepository) prepareArgs(data customer.Entity) (args bson.M) {
 	return
 }
 
-func (r *CustomerRepository) Delete(ctx context.Context, id string) (err error) {
-	out, err := r.db.DeleteOne(ctx, bson.M{"_id": id})
+func (r *CustomerRepository) Delete(ctx context.Context, id string, version int) (err error) {
+	update := bson.M{"$set": bson.M{"deleted_at": time.Now().UTC()}, "$inc": bson.M{"version": 1}}
+	out, err := r.db.UpdateOne(ctx, versionFilter(id, version), update)
+	if err != nil {
+		return err
+	}
+
+	if out.MatchedCount == 0 {
+		return missingOrConflict(ctx, r.db, id, version)
+	}
+
+	return
+}
+
+func (r *CustomerRepository) Restore(ctx context.Context, id string) (err error) {
+	filter := bson.M{"_id": id, "deleted_at": bson.M{"$ne": nil}}
+	update := bson.M{"$set": bson.M{"deleted_at": nil}, "$inc": bson.M{"version": 1}}
+
+	out, err := r.db.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+
+	if out.MatchedCount == 0 {
+		return market.ErrorNotFound
+	}
+
+	return
+}
+
+func (r *CustomerRepository) ListDeleted(ctx context.Context, before time.Time) (dest []customer.Entity, err error) {
+	filter := bson.M{"deleted_at": bson.M{"$lt": before}}
+	opts := options.Find().SetSort(bson.D{{Key: "deleted_at", Value: 1}})
+
+	cur, err := r.db.Find(ctx, filter, opts)
+	if err != nil {
+		return
+	}
+
+	dest = make([]customer.Entity, 0)
+	err = cur.All(ctx, &dest)
+
+	return
+}
+
+func (r *CustomerRepository) Purge(ctx context.Context, id string) (err error) {
+	out, err := r.db.DeleteOne(ctx, bson.M{"_id": id, "deleted_at": bson.M{"$ne": nil}})
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,16 @@
+package mongo
+
+import (
+	"context"
+	"exchanger/pkg/market"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// excludeDeleted adds the condition skipping the deleted documents to the filter unless the context asks for them
+func excludeDeleted(ctx context.Context, filter bson.M) bson.M {
+	if !market.IncludesDeleted(ctx) {
+		filter["deleted_at"] = nil
+	}
+
+	return filter
+}
@@ -8,8 +8,14 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"strings"
+	"time"
 )
 
+// wordStart matches the start of a word, the letters and digits make up the words like hire.Terms splits them
+const wordStart = `(?:^|[^\p{L}\p{N}])`
+
 type HireRepository struct {
 	db *mongo.Collection
 }
@@ -20,30 +26,122 @@ func NewHireRepository(db *mongo.Database) *HireRepository {
 	}
 }
 
-func (r *HireRepository) List(ctx context.Context) (dest []hire.Entity, err error) {
-	cur, err := r.db.Find(ctx, bson.M{})
+func (r *HireRepository) List(ctx context.Context, q market.Query) (dest []hire.Entity, total int, err error) {
+	filter, opts := prepareQuery(q)
+	filter = excludeDeleted(ctx, filter)
+
+	count, err := r.db.CountDocuments(ctx, filter)
 	if err != nil {
-		return nil, err
+		return
 	}
+	total = int(count)
 
-	if err = cur.All(ctx, &dest); err != nil {
-		return nil, err
+	cur, err := r.db.Find(ctx, filter, opts)
+	if err != nil {
+		return
 	}
 
+	dest = make([]hire.Entity, 0, q.Limit)
+	err = cur.All(ctx, &dest)
+
 	return
 }
 
-func (r *HireRepository) Add(ctx context.Context, data hire.Entity) (id string, err error) {
-	res, err := r.db.InsertOne(ctx, data)
+func (r *HireRepository) Search(ctx context.Context, s hire.Search) (dest []hire.SearchEntity, total int, err error) {
+	terms := hire.Terms(s.Text)
+	if len(terms) == 0 {
+		return make([]hire.SearchEntity, 0), 0, nil
+	}
+
+	// every term has to start a word of one of the fields, the terms are made of letters and digits only
+	matches := make(bson.A, 0, len(terms))
+	for _, term := range terms {
+		pattern := primitive.Regex{Pattern: wordStart + term, Options: "i"}
+		matches = append(matches, bson.M{"$or": bson.A{
+			bson.M{"job_name": pattern},
+			bson.M{"description": pattern},
+			bson.M{"position": pattern},
+		}})
+	}
+	filter := bson.M{"$and": matches}
+
+	if s.CustomerID != "" {
+		filter["customer_id"] = s.CustomerID
+	}
+
+	if s.Currency != "" {
+		filter["currency"] = s.Currency
+	}
+
+	amount := bson.M{}
+	if s.AmountFrom != nil {
+		amount["$gte"] = *s.AmountFrom
+	}
+	if s.AmountTo != nil {
+		amount["$lte"] = *s.AmountTo
+	}
+	if len(amount) > 0 {
+		filter["amount"] = amount
+	}
+	filter = excludeDeleted(ctx, filter)
+
+	count, err := r.db.CountDocuments(ctx, filter)
 	if err != nil {
+		return
+	}
+	total = int(count)
+
+	// the rank counts the words of every field starting with a term
+	ranks := bson.A{}
+	for column, weight := range hire.Weights {
+		words := bson.M{"$regexFindAll": bson.M{
+			"input":   bson.M{"$ifNull": bson.A{"$" + column, ""}},
+			"regex":   wordStart + "(?:" + strings.Join(terms, "|") + ")",
+			"options": "i",
+		}}
+		ranks = append(ranks, bson.M{"$multiply": bson.A{weight, bson.M{"$size": words}}})
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$addFields", Value: bson.M{"rank": bson.M{"$add": ranks}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "rank", Value: -1}, {Key: "_id", Value: 1}}}},
+		{{Key: "$skip", Value: int64(s.Offset)}},
+		{{Key: "$limit", Value: int64(s.Limit)}},
+	}
+
+	cur, err := r.db.Aggregate(ctx, pipeline)
+	if err != nil {
+		return
+	}
+
+	data := make([]hire.SearchEntity, 0, s.Limit)
+	if err = cur.All(ctx, &data); err != nil {
+		return
+	}
+
+	// the fields are highlighted the way the other stores do it
+	dest = make([]hire.SearchEntity, 0, len(data))
+	for _, object := range data {
+		res, _ := hire.Match(object.Entity, terms)
+		dest = append(dest, res)
+	}
+
+	return
+}
+
+func (r *HireRepository) Add(ctx context.Context, data hire.Entity) (id string, err error) {
+	data.ID, data.Version = primitive.NewObjectID().Hex(), 1
+
+	if _, err = r.db.InsertOne(ctx, data); err != nil {
 		return "", err
 	}
 
-	return res.InsertedID.(primitive.ObjectID).String(), nil
+	return data.ID, nil
 }
 
 func (r *HireRepository) Get(ctx context.Context, id string) (dest hire.Entity, err error) {
-	if err = r.db.FindOne(ctx, bson.M{"_id": id}).Decode(&dest); err != nil {
+	if err = r.db.FindOne(ctx, excludeDeleted(ctx, bson.M{"_id": id})).Decode(&dest); err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
 			err = market.ErrorNotFound
 		}
@@ -56,13 +154,14 @@ func (r *HireRepository) Update(ctx context.Context, id string, data hire.Entity
 	args := r.prepareArgs(data)
 	if len(args) > 0 {
 
-		out, err := r.db.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": args})
+		update := bson.M{"$set": args, "$inc": bson.M{"version": 1}}
+		out, err := r.db.UpdateOne(ctx, versionFilter(id, data.Version), update)
 		if err != nil {
 			return err
 		}
 
 		if out.MatchedCount == 0 {
-			return market.ErrorNotFound
+			return missingOrConflict(ctx, r.db, id, data.Version)
 		}
 	}
 
@@ -70,6 +169,8 @@ func (r *HireRepository) Update(ctx context.Context, id string, data hire.Entity
 }
 
 func (r *HireRepository) prepareArgs(data hire.Entity) (args bson.M) {
+	args = bson.M{}
+
 	if data.JobName != nil {
 		args["job_name"] = data.JobName
 	}
@@ -78,6 +179,10 @@ func (r *HireRepository) prepareArgs(data hire.Entity) (args bson.M) {
 		args["amount"] = data.Amount
 	}
 
+	if data.Currency != nil {
+		args["currency"] = data.Currency
+	}
+
 	if data.Position != nil {
 		args["position"] = data.Position
 	}
@@ -86,11 +191,60 @@ func (r *HireRepository) prepareArgs(data hire.Entity) (args bson.M) {
 		args["description"] = data.Description
 	}
 
+	if data.Status != "" {
+		args["status"] = data.Status
+	}
+
+	return
+}
+
+func (r *HireRepository) Delete(ctx context.Context, id string, version int) (err error) {
+	update := bson.M{"$set": bson.M{"deleted_at": time.Now().UTC()}, "$inc": bson.M{"version": 1}}
+	out, err := r.db.UpdateOne(ctx, versionFilter(id, version), update)
+	if err != nil {
+		return err
+	}
+
+	if out.MatchedCount == 0 {
+		return missingOrConflict(ctx, r.db, id, version)
+	}
+
+	return
+}
+
+func (r *HireRepository) Restore(ctx context.Context, id string) (err error) {
+	filter := bson.M{"_id": id, "deleted_at": bson.M{"$ne": nil}}
+	update := bson.M{"$set": bson.M{"deleted_at": nil}, "$inc": bson.M{"version": 1}}
+
+	out, err := r.db.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+
+	if out.MatchedCount == 0 {
+		return market.ErrorNotFound
+	}
+
+	return
+}
+
+func (r *HireRepository) ListDeleted(ctx context.Context, before time.Time) (dest []hire.Entity, err error) {
+	filter := bson.M{"deleted_at": bson.M{"$lt": before}}
+	opts := options.Find().SetSort(bson.D{{Key: "deleted_at", Value: 1}})
+
+	cur, err := r.db.Find(ctx, filter, opts)
+	if err != nil {
+		return
+	}
+
+	dest = make([]hire.Entity, 0)
+	err = cur.All(ctx, &dest)
+
 	return
 }
 
-func (r *HireRepository) Delete(ctx context.Context, id string) (err error) {
-	out, err := r.db.DeleteOne(ctx, bson.M{"_id": id})
+func (r *HireRepository) Purge(ctx context.Context, id string) (err error) {
+	out, err := r.db.DeleteOne(ctx, bson.M{"_id": id, "deleted_at": bson.M{"$ne": nil}})
 	if err != nil {
 		return err
 	}
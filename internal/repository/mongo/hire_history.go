@@ -0,0 +1,50 @@
+package mongo
+
+import (
+	"context"
+	"exchanger/internal/domain/hire"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type HireHistoryRepository struct {
+	db *mongo.Collection
+}
+
+func NewHireHistoryRepository(db *mongo.Database) *HireHistoryRepository {
+	return &HireHistoryRepository{
+		db: db.Collection("hire_status_history"),
+	}
+}
+
+func (r *HireHistoryRepository) List(ctx context.Context, hireID string) (dest []hire.HistoryEntity, err error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}})
+
+	cur, err := r.db.Find(ctx, bson.M{"hire_id": hireID}, opts)
+	if err != nil {
+		return
+	}
+
+	dest = make([]hire.HistoryEntity, 0)
+	err = cur.All(ctx, &dest)
+
+	return
+}
+
+func (r *HireHistoryRepository) Add(ctx context.Context, data hire.HistoryEntity) (id string, err error) {
+	data.ID = primitive.NewObjectID().Hex()
+
+	if _, err = r.db.InsertOne(ctx, data); err != nil {
+		return "", err
+	}
+
+	return data.ID, nil
+}
+
+func (r *HireHistoryRepository) DeleteByHire(ctx context.Context, hireID string) (err error) {
+	_, err = r.db.DeleteMany(ctx, bson.M{"hire_id": hireID})
+
+	return
+}
@@ -0,0 +1,94 @@
+package mongo
+
+import (
+	"context"
+	"exchanger/internal/domain/event"
+	"exchanger/pkg/market"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+type OutboxRepository struct {
+	db *mongo.Collection
+}
+
+func NewOutboxRepository(db *mongo.Database) *OutboxRepository {
+	return &OutboxRepository{
+		db: db.Collection("outbox"),
+	}
+}
+
+// CreateIndexes creates the index the pending events are listed by
+func (r *OutboxRepository) CreateIndexes(ctx context.Context) (err error) {
+	model := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "published_at", Value: 1},
+			{Key: "created_at", Value: 1},
+		},
+		Options: options.Index().
+			SetName("outbox_pending_idx"),
+	}
+	_, err = r.db.Indexes().CreateOne(ctx, model)
+
+	return
+}
+
+func (r *OutboxRepository) Add(ctx context.Context, data event.Entity) (id string, err error) {
+	data.ID = primitive.NewObjectID().Hex()
+
+	if _, err = r.db.InsertOne(ctx, data); err != nil {
+		return "", err
+	}
+
+	return data.ID, nil
+}
+
+func (r *OutboxRepository) ListPending(ctx context.Context, limit int) (dest []event.Entity, err error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}).
+		SetLimit(int64(limit))
+
+	cur, err := r.db.Find(ctx, bson.M{"published_at": nil}, opts)
+	if err != nil {
+		return
+	}
+
+	dest = make([]event.Entity, 0)
+	err = cur.All(ctx, &dest)
+
+	return
+}
+
+func (r *OutboxRepository) MarkPublished(ctx context.Context, id string, at time.Time) (err error) {
+	out, err := r.db.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"published_at": at}})
+	if err != nil {
+		return
+	}
+
+	if out.MatchedCount == 0 {
+		return market.ErrorNotFound
+	}
+
+	return
+}
+
+func (r *OutboxRepository) MarkFailed(ctx context.Context, id, reason string) (err error) {
+	update := bson.M{
+		"$inc": bson.M{"attempts": 1},
+		"$set": bson.M{"last_error": reason},
+	}
+
+	out, err := r.db.UpdateOne(ctx, bson.M{"_id": id}, update)
+	if err != nil {
+		return
+	}
+
+	if out.MatchedCount == 0 {
+		return market.ErrorNotFound
+	}
+
+	return
+}
@@ -0,0 +1,141 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"exchanger/internal/domain/payment"
+	"exchanger/pkg/market"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"strings"
+)
+
+type PaymentRepository struct {
+	db *mongo.Collection
+}
+
+func NewPaymentRepository(db *mongo.Database) *PaymentRepository {
+	return &PaymentRepository{
+		db: db.Collection("payments"),
+	}
+}
+
+// activeHireIndex is the unique index allowing one active payment per hire
+const activeHireIndex = "payments_active_hire_idx"
+
+// CreateIndexes creates the unique index allowing one active payment per hire
+func (r *PaymentRepository) CreateIndexes(ctx context.Context) (err error) {
+	model := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "hire_id", Value: 1},
+		},
+		Options: options.Index().
+			SetName(activeHireIndex).
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{
+				"status": bson.M{"$in": []string{payment.StatusPending, payment.StatusFunded}},
+			}),
+	}
+	_, err = r.db.Indexes().CreateOne(ctx, model)
+
+	return
+}
+
+func (r *PaymentRepository) List(ctx context.Context, hireID string) (dest []payment.Entity, err error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}})
+
+	cur, err := r.db.Find(ctx, bson.M{"hire_id": hireID}, opts)
+	if err != nil {
+		return
+	}
+
+	dest = make([]payment.Entity, 0)
+	err = cur.All(ctx, &dest)
+
+	return
+}
+
+func (r *PaymentRepository) ListByStatus(ctx context.Context, statuses ...string) (dest []payment.Entity, err error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}})
+
+	cur, err := r.db.Find(ctx, bson.M{"status": bson.M{"$in": statuses}}, opts)
+	if err != nil {
+		return
+	}
+
+	dest = make([]payment.Entity, 0)
+	err = cur.All(ctx, &dest)
+
+	return
+}
+
+func (r *PaymentRepository) Add(ctx context.Context, data payment.Entity) (err error) {
+	if _, err = r.db.InsertOne(ctx, data); err != nil {
+		switch {
+		case mongo.IsDuplicateKeyError(err) && strings.Contains(err.Error(), activeHireIndex):
+			err = payment.ErrorActivePayment
+		case mongo.IsDuplicateKeyError(err):
+			err = market.ErrorAlreadyExists
+		}
+	}
+
+	return
+}
+
+func (r *PaymentRepository) Get(ctx context.Context, invoiceID string) (dest payment.Entity, err error) {
+	if err = r.db.FindOne(ctx, bson.M{"_id": invoiceID}).Decode(&dest); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			err = market.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *PaymentRepository) Update(ctx context.Context, invoiceID string, data payment.Entity) (err error) {
+	args := r.prepareArgs(data)
+	if len(args) > 0 {
+
+		out, err := r.db.UpdateOne(ctx, bson.M{"_id": invoiceID}, bson.M{"$set": args})
+		if err != nil {
+			return err
+		}
+
+		if out.MatchedCount == 0 {
+			return market.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *PaymentRepository) prepareArgs(data payment.Entity) (args bson.M) {
+	args = bson.M{}
+
+	if data.WorkerID != "" {
+		args["worker_id"] = data.WorkerID
+	}
+
+	if data.ChargedAmount != nil {
+		args["charged_amount"] = data.ChargedAmount
+	}
+
+	if data.RefundedAmount != nil {
+		args["refunded_amount"] = data.RefundedAmount
+	}
+
+	if data.Status != "" {
+		args["status"] = data.Status
+	}
+
+	if data.ProviderStatus != "" {
+		args["provider_status"] = data.ProviderStatus
+	}
+
+	if data.ReleasedAt != nil {
+		args["released_at"] = data.ReleasedAt
+	}
+
+	return
+}
@@ -0,0 +1,44 @@
+package mongo
+
+import (
+	"context"
+	"exchanger/internal/domain/payment"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type PaymentCallbackRepository struct {
+	db *mongo.Collection
+}
+
+func NewPaymentCallbackRepository(db *mongo.Database) *PaymentCallbackRepository {
+	return &PaymentCallbackRepository{
+		db: db.Collection("payment_callbacks"),
+	}
+}
+
+func (r *PaymentCallbackRepository) List(ctx context.Context, invoiceID string) (dest []payment.CallbackEntity, err error) {
+	opts := options.Find().SetSort(bson.D{{Key: "received_at", Value: 1}, {Key: "_id", Value: 1}})
+
+	cur, err := r.db.Find(ctx, bson.M{"invoice_id": invoiceID}, opts)
+	if err != nil {
+		return
+	}
+
+	dest = make([]payment.CallbackEntity, 0)
+	err = cur.All(ctx, &dest)
+
+	return
+}
+
+func (r *PaymentCallbackRepository) Add(ctx context.Context, data payment.CallbackEntity) (id string, err error) {
+	data.ID = primitive.NewObjectID().Hex()
+
+	if _, err = r.db.InsertOne(ctx, data); err != nil {
+		return "", err
+	}
+
+	return data.ID, nil
+}
@@ -0,0 +1,44 @@
+package mongo
+
+import (
+	"context"
+	"exchanger/internal/domain/payment"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type PaymentHistoryRepository struct {
+	db *mongo.Collection
+}
+
+func NewPaymentHistoryRepository(db *mongo.Database) *PaymentHistoryRepository {
+	return &PaymentHistoryRepository{
+		db: db.Collection("payment_history"),
+	}
+}
+
+func (r *PaymentHistoryRepository) List(ctx context.Context, invoiceID string) (dest []payment.HistoryEntity, err error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}})
+
+	cur, err := r.db.Find(ctx, bson.M{"invoice_id": invoiceID}, opts)
+	if err != nil {
+		return
+	}
+
+	dest = make([]payment.HistoryEntity, 0)
+	err = cur.All(ctx, &dest)
+
+	return
+}
+
+func (r *PaymentHistoryRepository) Add(ctx context.Context, data payment.HistoryEntity) (id string, err error) {
+	data.ID = primitive.NewObjectID().Hex()
+
+	if _, err = r.db.InsertOne(ctx, data); err != nil {
+		return "", err
+	}
+
+	return data.ID, nil
+}
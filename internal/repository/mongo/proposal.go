@@ -0,0 +1,129 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"exchanger/internal/domain/proposal"
+	"exchanger/pkg/market"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ProposalRepository struct {
+	db *mongo.Collection
+}
+
+func NewProposalRepository(db *mongo.Database) *ProposalRepository {
+	return &ProposalRepository{
+		db: db.Collection("proposals"),
+	}
+}
+
+// CreateIndexes creates the unique index allowing one proposal of a worker per hire
+func (r *ProposalRepository) CreateIndexes(ctx context.Context) (err error) {
+	model := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "hire_id", Value: 1},
+			{Key: "worker_id", Value: 1},
+		},
+		Options: options.Index().
+			SetName("proposals_hire_worker_idx").
+			SetUnique(true),
+	}
+	_, err = r.db.Indexes().CreateOne(ctx, model)
+
+	return
+}
+
+func (r *ProposalRepository) List(ctx context.Context, hireID string, q market.Query) (dest []proposal.Entity, total int, err error) {
+	filter, opts := prepareQuery(q.Where("hire_id", hireID))
+
+	count, err := r.db.CountDocuments(ctx, filter)
+	if err != nil {
+		return
+	}
+	total = int(count)
+
+	cur, err := r.db.Find(ctx, filter, opts)
+	if err != nil {
+		return
+	}
+
+	dest = make([]proposal.Entity, 0, q.Limit)
+	err = cur.All(ctx, &dest)
+
+	return
+}
+
+func (r *ProposalRepository) Add(ctx context.Context, data proposal.Entity) (id string, err error) {
+	data.ID = primitive.NewObjectID().Hex()
+
+	if _, err = r.db.InsertOne(ctx, data); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			err = market.ErrorAlreadyExists
+		}
+		return "", err
+	}
+
+	return data.ID, nil
+}
+
+func (r *ProposalRepository) Get(ctx context.Context, id string) (dest proposal.Entity, err error) {
+	if err = r.db.FindOne(ctx, bson.M{"_id": id}).Decode(&dest); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			err = market.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *ProposalRepository) Update(ctx context.Context, id string, data proposal.Entity) (err error) {
+	args := r.prepareArgs(data)
+	if len(args) > 0 {
+
+		out, err := r.db.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": args})
+		if err != nil {
+			return err
+		}
+
+		if out.MatchedCount == 0 {
+			return market.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *ProposalRepository) prepareArgs(data proposal.Entity) (args bson.M) {
+	args = bson.M{}
+
+	if data.CoverLetter != nil {
+		args["cover_letter"] = data.CoverLetter
+	}
+
+	if data.Amount != nil {
+		args["amount"] = data.Amount
+	}
+
+	if data.Status != "" {
+		args["status"] = data.Status
+	}
+
+	return
+}
+
+func (r *ProposalRepository) DeleteByHire(ctx context.Context, hireID string) (err error) {
+	_, err = r.db.DeleteMany(ctx, bson.M{"hire_id": hireID})
+
+	return
+}
+
+func (r *ProposalRepository) CountByWorker(ctx context.Context, workerID string) (count int, err error) {
+	total, err := r.db.CountDocuments(ctx, bson.M{"worker_id": workerID})
+	count = int(total)
+
+	return
+}
@@ -0,0 +1,40 @@
+package mongo
+
+import (
+	"exchanger/pkg/market"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// prepareQuery builds the filter and the find options of the list query,
+// the id column is stored as the document _id
+func prepareQuery(q market.Query) (filter bson.M, opts *options.FindOptions) {
+	filter = bson.M{}
+	for column, value := range q.Filters {
+		filter[documentKey(column)] = value
+	}
+
+	direction := 1
+	if q.Order == market.OrderDesc {
+		direction = -1
+	}
+
+	sort := bson.D{{Key: documentKey(q.Sort), Value: direction}}
+	if q.Sort != "id" {
+		sort = append(sort, bson.E{Key: "_id", Value: direction})
+	}
+
+	opts = options.Find().
+		SetSort(sort).
+		SetSkip(int64(q.Offset)).
+		SetLimit(int64(q.Limit))
+
+	return
+}
+
+func documentKey(column string) string {
+	if column == "id" {
+		return "_id"
+	}
+	return column
+}
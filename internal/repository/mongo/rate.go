@@ -0,0 +1,53 @@
+package mongo
+
+import (
+	"context"
+	"exchanger/internal/domain/rate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+type RateRepository struct {
+	db *mongo.Collection
+}
+
+func NewRateRepository(db *mongo.Database) *RateRepository {
+	return &RateRepository{
+		db: db.Collection("rates"),
+	}
+}
+
+func (r *RateRepository) List(ctx context.Context, date time.Time) (dest []rate.Entity, err error) {
+	opts := options.Find().SetSort(bson.D{{Key: "code", Value: 1}})
+
+	cur, err := r.db.Find(ctx, bson.M{"date": date}, opts)
+	if err != nil {
+		return
+	}
+
+	dest = make([]rate.Entity, 0)
+	err = cur.All(ctx, &dest)
+
+	return
+}
+
+func (r *RateRepository) Add(ctx context.Context, data []rate.Entity) (err error) {
+	if len(data) == 0 {
+		return
+	}
+
+	models := make([]mongo.WriteModel, 0, len(data))
+	for _, object := range data {
+		object.ID = rate.NewID(object.Date, object.Code)
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": object.ID}).
+			SetUpdate(bson.M{"$setOnInsert": object}).
+			SetUpsert(true))
+	}
+
+	_, err = r.db.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+
+	return
+}
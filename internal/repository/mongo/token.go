@@ -0,0 +1,79 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"exchanger/internal/domain/token"
+	"exchanger/pkg/market"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"time"
+)
+
+type TokenRepository struct {
+	db *mongo.Collection
+}
+
+func NewTokenRepository(db *mongo.Database) *TokenRepository {
+	return &TokenRepository{
+		db: db.Collection("tokens"),
+	}
+}
+
+func (r *TokenRepository) Add(ctx context.Context, data token.Entity) (err error) {
+	_, err = r.db.InsertOne(ctx, data)
+
+	return
+}
+
+func (r *TokenRepository) Get(ctx context.Context, id string) (dest token.Entity, err error) {
+	if err = r.db.FindOne(ctx, bson.M{"_id": id}).Decode(&dest); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			err = market.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *TokenRepository) Rotate(ctx context.Context, id, refreshTokenID string) (err error) {
+	filter := bson.M{
+		"_id":              id,
+		"refresh_token_id": refreshTokenID,
+		"rotated_at":       nil,
+		"revoked_at":       nil,
+	}
+
+	out, err := r.db.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"rotated_at": time.Now()}})
+	if err != nil {
+		return
+	}
+
+	if out.MatchedCount == 0 {
+		return market.ErrorNotFound
+	}
+
+	return
+}
+
+func (r *TokenRepository) Revoke(ctx context.Context, id string) (err error) {
+	if _, err = r.Get(ctx, id); err != nil {
+		return
+	}
+
+	filter := bson.M{"_id": id, "revoked_at": nil}
+	_, err = r.db.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"revoked_at": time.Now()}})
+
+	return
+}
+
+func (r *TokenRepository) RevokeByCredential(ctx context.Context, credential string) (count int64, err error) {
+	filter := bson.M{"credential": credential, "revoked_at": nil}
+
+	out, err := r.db.UpdateMany(ctx, filter, bson.M{"$set": bson.M{"revoked_at": time.Now()}})
+	if err != nil {
+		return
+	}
+
+	return out.ModifiedCount, nil
+}
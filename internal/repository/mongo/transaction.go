@@ -0,0 +1,53 @@
+package mongo
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// UnitOfWork runs the repository calls in one transaction of a mongo session,
+// the transactions need the store to be a replica set, see SupportsTransactions
+type UnitOfWork struct {
+	client *mongo.Client
+}
+
+func NewUnitOfWork(client *mongo.Client) *UnitOfWork {
+	return &UnitOfWork{
+		client: client,
+	}
+}
+
+// Do runs fn in the transaction of a new session, the driver retries fn on transient errors.
+// The unit of work started inside another one joins the session of the outer one
+func (u *UnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if mongo.SessionFromContext(ctx) != nil {
+		return fn(ctx)
+	}
+
+	session, err := u.client.StartSession()
+	if err != nil {
+		return
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(ctx mongo.SessionContext) (any, error) {
+		return nil, fn(ctx)
+	})
+
+	return
+}
+
+// SupportsTransactions checks that the server is a member of a replica set or a mongos router,
+// the standalone server refuses the transactions
+func SupportsTransactions(ctx context.Context, client *mongo.Client) (ok bool, err error) {
+	var hello struct {
+		SetName string `bson:"setName"`
+		Msg     string `bson:"msg"`
+	}
+	if err = client.Database("admin").RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello); err != nil {
+		return
+	}
+
+	return hello.SetName != "" || hello.Msg == "isdbgrid", nil
+}
@@ -0,0 +1,92 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"exchanger/internal/domain/user"
+	"exchanger/pkg/market"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type UserRepository struct {
+	db *mongo.Collection
+}
+
+func NewUserRepository(db *mongo.Database) *UserRepository {
+	return &UserRepository{
+		db: db.Collection("users"),
+	}
+}
+
+func (r *UserRepository) Add(ctx context.Context, data user.Entity) (id string, err error) {
+	if _, err = r.GetByUsername(ctx, *data.Username); err == nil {
+		return "", market.ErrorAlreadyExists
+	}
+	data.ID = primitive.NewObjectID().Hex()
+
+	if _, err = r.db.InsertOne(ctx, data); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			err = market.ErrorAlreadyExists
+		}
+		return "", err
+	}
+
+	return data.ID, nil
+}
+
+func (r *UserRepository) Get(ctx context.Context, id string) (dest user.Entity, err error) {
+	if err = r.db.FindOne(ctx, bson.M{"_id": id}).Decode(&dest); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			err = market.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *UserRepository) GetByUsername(ctx context.Context, username string) (dest user.Entity, err error) {
+	if err = r.db.FindOne(ctx, bson.M{"username": username}).Decode(&dest); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			err = market.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *UserRepository) Update(ctx context.Context, id string, data user.Entity) (err error) {
+	args := r.prepareArgs(data)
+	if len(args) > 0 {
+
+		out, err := r.db.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": args})
+		if err != nil {
+			return err
+		}
+
+		if out.MatchedCount == 0 {
+			return market.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *UserRepository) prepareArgs(data user.Entity) (args bson.M) {
+	args = bson.M{}
+
+	if data.PasswordHash != nil {
+		args["password_hash"] = data.PasswordHash
+	}
+
+	if data.Role != nil {
+		args["role"] = data.Role
+	}
+
+	if data.IsActive != nil {
+		args["is_active"] = data.IsActive
+	}
+
+	return
+}
@@ -0,0 +1,36 @@
+package mongo
+
+import (
+	"context"
+	"exchanger/pkg/market"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// versionFilter returns the filter of the live document at the version the change is made for, zero version matches any
+func versionFilter(id string, version int) (filter bson.M) {
+	filter = bson.M{"_id": id, "deleted_at": nil}
+	if version != 0 {
+		filter["version"] = version
+	}
+
+	return
+}
+
+// missingOrConflict tells the missing or deleted document from the document of another version once the change matched nothing
+func missingOrConflict(ctx context.Context, db *mongo.Collection, id string, version int) (err error) {
+	if version == 0 {
+		return market.ErrorNotFound
+	}
+
+	count, err := db.CountDocuments(ctx, bson.M{"_id": id, "deleted_at": nil})
+	if err != nil {
+		return
+	}
+
+	if count > 0 {
+		return market.ErrorConflict
+	}
+
+	return market.ErrorNotFound
+}
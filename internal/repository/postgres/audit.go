@@ -0,0 +1,44 @@
+package postgres
+
+import (
+	"context"
+	"exchanger/internal/domain/audit"
+	"github.com/jmoiron/sqlx"
+)
+
+type AuditRepository struct {
+	db *sqlx.DB
+}
+
+func NewAuditRepository(db *sqlx.DB) *AuditRepository {
+	return &AuditRepository{
+		db: db,
+	}
+}
+
+func (r *AuditRepository) List(ctx context.Context, entity, entityID string) (dest []audit.Entity, err error) {
+	query := `
+		SELECT id, entity, entity_id, action, actor_id, actor_role, request_id, diff, created_at
+		FROM audit
+		WHERE entity=$1 AND entity_id=$2
+		ORDER BY created_at, id`
+
+	args := []any{entity, entityID}
+
+	err = conn(ctx, r.db).SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+func (r *AuditRepository) Add(ctx context.Context, data audit.Entity) (id string, err error) {
+	query := `
+		INSERT INTO audit (entity, entity_id, action, actor_id, actor_role, request_id, diff, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id`
+
+	args := []any{data.Entity, data.EntityID, data.Action, data.ActorID, data.ActorRole, data.RequestID, data.Diff, data.CreatedAt}
+
+	err = conn(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(&id)
+
+	return
+}
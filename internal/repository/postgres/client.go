@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"exchanger/internal/domain/client"
+	"exchanger/pkg/market"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"strings"
+)
+
+type ClientRepository struct {
+	db *sqlx.DB
+}
+
+func NewClientRepository(db *sqlx.DB) *ClientRepository {
+	return &ClientRepository{
+		db: db,
+	}
+}
+
+func (r *ClientRepository) Add(ctx context.Context, data client.Entity) (id string, err error) {
+	query := `
+		INSERT INTO clients (name, secret_hash, user_id, is_active)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id`
+
+	args := []any{data.Name, data.SecretHash, data.UserID, data.IsActive}
+
+	err = conn(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(&id)
+
+	return
+}
+
+func (r *ClientRepository) Get(ctx context.Context, id string) (dest client.Entity, err error) {
+	query := `
+		SELECT id, name, secret_hash, user_id, is_active
+		FROM clients
+		WHERE id=$1`
+
+	args := []any{id}
+
+	if err = conn(ctx, r.db).GetContext(ctx, &dest, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = market.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *ClientRepository) Update(ctx context.Context, id string, data client.Entity) (err error) {
+	sets, args := r.prepareArgs(data)
+	if len(args) > 0 {
+
+		args = append(args, id)
+		sets = append(sets, "updated_at=CURRENT_TIMESTAMP")
+		query := fmt.Sprintf("UPDATE clients SET %s WHERE id=$%d RETURNING id", strings.Join(sets, ", "), len(args))
+
+		if err = conn(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				err = market.ErrorNotFound
+			}
+		}
+	}
+
+	return
+}
+
+func (r *ClientRepository) prepareArgs(data client.Entity) (sets []string, args []any) {
+	if data.Name != nil {
+		args = append(args, data.Name)
+		sets = append(sets, fmt.Sprintf("name=$%d", len(args)))
+	}
+
+	if data.SecretHash != nil {
+		args = append(args, data.SecretHash)
+		sets = append(sets, fmt.Sprintf("secret_hash=$%d", len(args)))
+	}
+
+	if data.IsActive != nil {
+		args = append(args, data.IsActive)
+		sets = append(sets, fmt.Sprintf("is_active=$%d", len(args)))
+	}
+
+	return
+}
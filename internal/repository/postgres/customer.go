@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"github.com/jmoiron/sqlx"
 	"strings"
+	"time"
 )
 
 type CustomerRepository struct {
@@ -21,26 +22,35 @@ func NewCustomerRepository(db *sqlx.DB) *CustomerRepository {
 	}
 }
 
-func (r *CustomerRepository) List(ctx context.Context) (dest []customer.Entity, err error) {
-	query := `
-		SELECT id, full_name, pseudonym
+func (r *CustomerRepository) List(ctx context.Context, q market.Query) (dest []customer.Entity, total int, err error) {
+	where, order, args := prepareQuery(q)
+	where = excludeDeleted(ctx, where)
+
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM customers %s`, where)
+	if err = conn(ctx, r.db).GetContext(ctx, &total, query, args...); err != nil {
+		return
+	}
+
+	query = fmt.Sprintf(`
+		SELECT id, full_name, pseudonym, COALESCE(user_id::text, '') AS user_id, version, deleted_at
 		FROM customers
-		ORDER BY id`
+		%s
+		%s`, where, order)
 
-	err = r.db.SelectContext(ctx, &dest, query)
+	err = conn(ctx, r.db).SelectContext(ctx, &dest, query, args...)
 
 	return
 }
 
 func (r *CustomerRepository) Add(ctx context.Context, data customer.Entity) (id string, err error) {
 	query := `
-		INSERT INTO customers (full_name, pseudonym)
-		VALUES ($1, $2, $3)
+		INSERT INTO customers (full_name, pseudonym, user_id)
+		VALUES ($1, $2, NULLIF($3, '')::uuid)
 		RETURNING id`
 
-	args := []any{data.FullName, data.Pseudonym}
+	args := []any{data.FullName, data.Pseudonym, data.UserID}
 
-	err = r.db.QueryRowContext(ctx, query, args...).Scan(&id)
+	err = conn(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(&id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			err = market.ErrorNotFound
@@ -51,14 +61,14 @@ func (r *CustomerRepository) Add(ctx context.Context, data customer.Entity) (id
 }
 
 func (r *CustomerRepository) Get(ctx context.Context, id string) (dest customer.Entity, err error) {
-	query := `
-		SELECT id, full_name, pseudonym
+	query := fmt.Sprintf(`
+		SELECT id, full_name, pseudonym, COALESCE(user_id::text, '') AS user_id, version, deleted_at
 		FROM customers
-		WHERE id=$1`
+		%s`, excludeDeleted(ctx, "WHERE id=$1"))
 
 	args := []any{id}
 
-	if err = r.db.GetContext(ctx, &dest, query, args...); err != nil {
+	if err = conn(ctx, r.db).GetContext(ctx, &dest, query, args...); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			err = market.ErrorNotFound
 		}
@@ -72,12 +82,16 @@ func (r *CustomerRepository) Update(ctx context.Context, id string, data custome
 	if len(args) > 0 {
 
 		args = append(args, id)
-		sets = append(sets, "updated_at=CURRENT_TIMESTAMP")
-		query := fmt.Sprintf("UPDATE customers SET %r WHERE id=$%d RETURNING id", strings.Join(sets, ", "), len(args))
+		sets = append(sets, "updated_at=CURRENT_TIMESTAMP", "version=version+1")
+		where := fmt.Sprintf("id=$%d AND deleted_at IS NULL", len(args))
 
-		if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		var condition string
+		condition, args = versionCondition(data.Version, args)
+		query := fmt.Sprintf("UPDATE customers SET %s WHERE %s%s RETURNING id", strings.Join(sets, ", "), where, condition)
+
+		if err = conn(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
 			if errors.Is(err, sql.ErrNoRows) {
-				err = market.ErrorNotFound
+				err = missingOrConflict(ctx, r.db, "customers", id, data.Version)
 			}
 		}
 	}
@@ -99,15 +113,66 @@ func (r *CustomerRepository) prepareArgs(data customer.Entity) (sets []string, a
 	return
 }
 
-func (r *CustomerRepository) Delete(ctx context.Context, id string) (err error) {
+func (r *CustomerRepository) Delete(ctx context.Context, id string, version int) (err error) {
+	args := []any{id}
+
+	condition, args := versionCondition(version, args)
+	query := fmt.Sprintf(`
+		UPDATE customers
+		SET deleted_at=CURRENT_TIMESTAMP AT TIME ZONE 'UTC', version=version+1
+		WHERE id=$1 AND deleted_at IS NULL%s
+		RETURNING id`, condition)
+
+	if err = conn(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = missingOrConflict(ctx, r.db, "customers", id, version)
+		}
+	}
+
+	return
+}
+
+func (r *CustomerRepository) Restore(ctx context.Context, id string) (err error) {
+	query := `
+		UPDATE customers
+		SET deleted_at=NULL, version=version+1
+		WHERE id=$1 AND deleted_at IS NOT NULL
+		RETURNING id`
+
+	args := []any{id}
+
+	if err = conn(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = market.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *CustomerRepository) ListDeleted(ctx context.Context, before time.Time) (dest []customer.Entity, err error) {
+	query := `
+		SELECT id, full_name, pseudonym, COALESCE(user_id::text, '') AS user_id, version, deleted_at
+		FROM customers
+		WHERE deleted_at<$1
+		ORDER BY deleted_at`
+
+	args := []any{before.UTC()}
+
+	err = conn(ctx, r.db).SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+func (r *CustomerRepository) Purge(ctx context.Context, id string) (err error) {
 	query := `
 		DELETE FROM customers
-		WHERE id=$1
+		WHERE id=$1 AND deleted_at IS NOT NULL
 		RETURNING id`
 
 	args := []any{id}
 
-	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+	if err = conn(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			err = market.ErrorNotFound
 		}
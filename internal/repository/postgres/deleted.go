@@ -0,0 +1,18 @@
+package postgres
+
+import (
+	"context"
+	"exchanger/pkg/market"
+)
+
+// excludeDeleted adds the condition skipping the deleted rows to the WHERE clause unless the context asks for them
+func excludeDeleted(ctx context.Context, where string) string {
+	switch {
+	case market.IncludesDeleted(ctx):
+		return where
+	case where == "":
+		return "WHERE deleted_at IS NULL"
+	default:
+		return where + " AND deleted_at IS NULL"
+	}
+}
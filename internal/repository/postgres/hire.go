@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"github.com/jmoiron/sqlx"
 	"strings"
+	"time"
 )
 
 type HireRepository struct {
@@ -21,26 +22,106 @@ func NewHireRepository(db *sqlx.DB) *HireRepository {
 	}
 }
 
-func (r *HireRepository) List(ctx context.Context) (dest []hire.Entity, err error) {
-	query := `
-		SELECT id, job_name, amount, description, position, customer_id
+func (r *HireRepository) List(ctx context.Context, q market.Query) (dest []hire.Entity, total int, err error) {
+	where, order, args := prepareQuery(q)
+	where = excludeDeleted(ctx, where)
+
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM hires %s`, where)
+	if err = conn(ctx, r.db).GetContext(ctx, &total, query, args...); err != nil {
+		return
+	}
+
+	query = fmt.Sprintf(`
+		SELECT id, job_name, amount, currency, description, position, customer_id, status, version, deleted_at
 		FROM hires
-		ORDER BY id`
+		%s
+		%s`, where, order)
 
-	err = r.db.SelectContext(ctx, &dest, query)
+	err = conn(ctx, r.db).SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+func (r *HireRepository) Search(ctx context.Context, s hire.Search) (dest []hire.SearchEntity, total int, err error) {
+	terms := hire.Terms(s.Text)
+
+	// every term has to be the prefix of a word, the words are the lexemes of search_vector
+	prefixes := make([]string, 0, len(terms))
+	for _, term := range terms {
+		prefixes = append(prefixes, term+":*")
+	}
+	conditions := []string{"search_vector @@ TO_TSQUERY('simple', $1)"}
+	args := []any{strings.Join(prefixes, " & ")}
+
+	if s.CustomerID != "" {
+		args = append(args, s.CustomerID)
+		conditions = append(conditions, fmt.Sprintf("customer_id::text=$%d", len(args)))
+	}
+
+	if s.Currency != "" {
+		args = append(args, s.Currency)
+		conditions = append(conditions, fmt.Sprintf("currency=$%d", len(args)))
+	}
+
+	if s.AmountFrom != nil {
+		args = append(args, *s.AmountFrom)
+		conditions = append(conditions, fmt.Sprintf("amount>=$%d", len(args)))
+	}
+
+	if s.AmountTo != nil {
+		args = append(args, *s.AmountTo)
+		conditions = append(conditions, fmt.Sprintf("amount<=$%d", len(args)))
+	}
+	if !market.IncludesDeleted(ctx) {
+		conditions = append(conditions, "deleted_at IS NULL")
+	}
+	where := strings.Join(conditions, " AND ")
+
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM hires WHERE %s`, where)
+	if err = conn(ctx, r.db).GetContext(ctx, &total, query, args...); err != nil {
+		return
+	}
+
+	// the rank counts the words of every field starting with a term, the pattern matches such a word
+	args = append(args, `(^|[^[:alnum:]])(`+strings.Join(terms, "|")+`)`)
+	ranks := make([]string, 0, len(hire.Weights))
+	for _, column := range []string{"job_name", "position", "description"} {
+		ranks = append(ranks, fmt.Sprintf("%g * (SELECT COUNT(*) FROM REGEXP_MATCHES(LOWER(COALESCE(%s, '')), $%d, 'g'))",
+			hire.Weights[column], column, len(args)))
+	}
+
+	query = fmt.Sprintf(`
+		SELECT id, job_name, amount, currency, description, position, customer_id, status, version, deleted_at,
+		       %s AS rank
+		FROM hires
+		WHERE %s
+		ORDER BY rank DESC, id
+		LIMIT %d OFFSET %d`, strings.Join(ranks, " + "), where, s.Limit, s.Offset)
+
+	data := make([]hire.SearchEntity, 0, s.Limit)
+	if err = conn(ctx, r.db).SelectContext(ctx, &data, query, args...); err != nil {
+		return
+	}
+
+	// the fields are highlighted the way the other stores do it
+	dest = make([]hire.SearchEntity, 0, len(data))
+	for _, object := range data {
+		res, _ := hire.Match(object.Entity, terms)
+		dest = append(dest, res)
+	}
 
 	return
 }
 
 func (r *HireRepository) Add(ctx context.Context, data hire.Entity) (id string, err error) {
 	query := `
-		INSERT INTO hires (job_name, amount, description, position, customer_id)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO hires (job_name, amount, currency, description, position, customer_id, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
 		RETURNING id`
 
-	args := []any{data.JobName, data.Amount, data.Description, data.Position, data.CustomerID}
+	args := []any{data.JobName, data.Amount, data.Currency, data.Description, data.Position, data.CustomerID, data.Status}
 
-	err = r.db.QueryRowContext(ctx, query, args...).Scan(&id)
+	err = conn(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(&id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			err = market.ErrorNotFound
@@ -51,14 +132,14 @@ func (r *HireRepository) Add(ctx context.Context, data hire.Entity) (id string,
 }
 
 func (r *HireRepository) Get(ctx context.Context, id string) (dest hire.Entity, err error) {
-	query := `
-		SELECT id, job_name, amount, description, position, customer_id
+	query := fmt.Sprintf(`
+		SELECT id, job_name, amount, currency, description, position, customer_id, status, version, deleted_at
 		FROM hires
-		WHERE id=$1`
+		%s`, excludeDeleted(ctx, "WHERE id=$1"))
 
 	args := []any{id}
 
-	if err = r.db.GetContext(ctx, &dest, query, args...); err != nil {
+	if err = conn(ctx, r.db).GetContext(ctx, &dest, query, args...); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			err = market.ErrorNotFound
 		}
@@ -72,12 +153,16 @@ func (r *HireRepository) Update(ctx context.Context, id string, data hire.Entity
 	if len(args) > 0 {
 
 		args = append(args, id)
-		sets = append(sets, "updated_at=CURRENT_TIMESTAMP")
-		query := fmt.Sprintf("UPDATE hires SET %r WHERE id=$%d RETURNING id", strings.Join(sets, ", "), len(args))
+		sets = append(sets, "updated_at=CURRENT_TIMESTAMP", "version=version+1")
+		where := fmt.Sprintf("id=$%d AND deleted_at IS NULL", len(args))
+
+		var condition string
+		condition, args = versionCondition(data.Version, args)
+		query := fmt.Sprintf("UPDATE hires SET %s WHERE %s%s RETURNING id", strings.Join(sets, ", "), where, condition)
 
-		if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		if err = conn(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
 			if errors.Is(err, sql.ErrNoRows) {
-				err = market.ErrorNotFound
+				err = missingOrConflict(ctx, r.db, "hires", id, data.Version)
 			}
 		}
 	}
@@ -96,6 +181,11 @@ func (r *HireRepository) prepareArgs(data hire.Entity) (sets []string, args []an
 		sets = append(sets, fmt.Sprintf("amount=$%d", len(args)))
 	}
 
+	if data.Currency != nil {
+		args = append(args, data.Currency)
+		sets = append(sets, fmt.Sprintf("currency=$%d", len(args)))
+	}
+
 	if data.Description != nil {
 		args = append(args, data.Description)
 		sets = append(sets, fmt.Sprintf("description=$%d", len(args)))
@@ -106,18 +196,74 @@ func (r *HireRepository) prepareArgs(data hire.Entity) (sets []string, args []an
 		sets = append(sets, fmt.Sprintf("position=$%d", len(args)))
 	}
 
+	if data.Status != "" {
+		args = append(args, data.Status)
+		sets = append(sets, fmt.Sprintf("status=$%d", len(args)))
+	}
+
+	return
+}
+
+func (r *HireRepository) Delete(ctx context.Context, id string, version int) (err error) {
+	args := []any{id}
+
+	condition, args := versionCondition(version, args)
+	query := fmt.Sprintf(`
+		UPDATE hires
+		SET deleted_at=CURRENT_TIMESTAMP AT TIME ZONE 'UTC', version=version+1
+		WHERE id=$1 AND deleted_at IS NULL%s
+		RETURNING id`, condition)
+
+	if err = conn(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = missingOrConflict(ctx, r.db, "hires", id, version)
+		}
+	}
+
+	return
+}
+
+func (r *HireRepository) Restore(ctx context.Context, id string) (err error) {
+	query := `
+		UPDATE hires
+		SET deleted_at=NULL, version=version+1
+		WHERE id=$1 AND deleted_at IS NOT NULL
+		RETURNING id`
+
+	args := []any{id}
+
+	if err = conn(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = market.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *HireRepository) ListDeleted(ctx context.Context, before time.Time) (dest []hire.Entity, err error) {
+	query := `
+		SELECT id, job_name, amount, currency, description, position, customer_id, status, version, deleted_at
+		FROM hires
+		WHERE deleted_at<$1
+		ORDER BY deleted_at`
+
+	args := []any{before.UTC()}
+
+	err = conn(ctx, r.db).SelectContext(ctx, &dest, query, args...)
+
 	return
 }
 
-func (r *HireRepository) Delete(ctx context.Context, id string) (err error) {
+func (r *HireRepository) Purge(ctx context.Context, id string) (err error) {
 	query := `
 		DELETE FROM hires
-		WHERE id=$1
+		WHERE id=$1 AND deleted_at IS NOT NULL
 		RETURNING id`
 
 	args := []any{id}
 
-	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+	if err = conn(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			err = market.ErrorNotFound
 		}
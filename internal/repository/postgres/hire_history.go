@@ -0,0 +1,56 @@
+package postgres
+
+import (
+	"context"
+	"exchanger/internal/domain/hire"
+	"github.com/jmoiron/sqlx"
+)
+
+type HireHistoryRepository struct {
+	db *sqlx.DB
+}
+
+func NewHireHistoryRepository(db *sqlx.DB) *HireHistoryRepository {
+	return &HireHistoryRepository{
+		db: db,
+	}
+}
+
+func (r *HireHistoryRepository) List(ctx context.Context, hireID string) (dest []hire.HistoryEntity, err error) {
+	query := `
+		SELECT id, hire_id, from_status, to_status, COALESCE(user_id::text, '') AS user_id, created_at
+		FROM hire_status_history
+		WHERE hire_id=$1
+		ORDER BY created_at, id`
+
+	args := []any{hireID}
+
+	err = conn(ctx, r.db).SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+func (r *HireHistoryRepository) Add(ctx context.Context, data hire.HistoryEntity) (id string, err error) {
+	query := `
+		INSERT INTO hire_status_history (hire_id, from_status, to_status, user_id, created_at)
+		VALUES ($1, $2, $3, NULLIF($4, '')::uuid, $5)
+		RETURNING id`
+
+	args := []any{data.HireID, data.FromStatus, data.ToStatus, data.UserID, data.CreatedAt}
+
+	err = conn(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(&id)
+
+	return
+}
+
+func (r *HireHistoryRepository) DeleteByHire(ctx context.Context, hireID string) (err error) {
+	query := `
+		DELETE FROM hire_status_history
+		WHERE hire_id=$1`
+
+	args := []any{hireID}
+
+	_, err = conn(ctx, r.db).ExecContext(ctx, query, args...)
+
+	return
+}
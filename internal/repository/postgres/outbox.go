@@ -0,0 +1,85 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"exchanger/internal/domain/event"
+	"exchanger/pkg/market"
+	"github.com/jmoiron/sqlx"
+	"time"
+)
+
+type OutboxRepository struct {
+	db *sqlx.DB
+}
+
+func NewOutboxRepository(db *sqlx.DB) *OutboxRepository {
+	return &OutboxRepository{
+		db: db,
+	}
+}
+
+func (r *OutboxRepository) Add(ctx context.Context, data event.Entity) (id string, err error) {
+	query := `
+		INSERT INTO outbox (type, entity_id, payload, created_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id`
+
+	args := []any{data.Type, data.EntityID, data.Payload, data.CreatedAt}
+
+	err = conn(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(&id)
+
+	return
+}
+
+func (r *OutboxRepository) ListPending(ctx context.Context, limit int) (dest []event.Entity, err error) {
+	query := `
+		SELECT id, type, entity_id, payload::text AS payload, attempts, last_error, created_at, published_at
+		FROM outbox
+		WHERE published_at IS NULL
+		ORDER BY created_at, id
+		LIMIT $1`
+
+	args := []any{limit}
+
+	err = conn(ctx, r.db).SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+func (r *OutboxRepository) MarkPublished(ctx context.Context, id string, at time.Time) (err error) {
+	query := `
+		UPDATE outbox
+		SET published_at=$2
+		WHERE id=$1
+		RETURNING id`
+
+	args := []any{id, at}
+
+	if err = conn(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = market.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *OutboxRepository) MarkFailed(ctx context.Context, id, reason string) (err error) {
+	query := `
+		UPDATE outbox
+		SET attempts=attempts+1, last_error=$2
+		WHERE id=$1
+		RETURNING id`
+
+	args := []any{id, reason}
+
+	if err = conn(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = market.ErrorNotFound
+		}
+	}
+
+	return
+}
@@ -0,0 +1,146 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"exchanger/internal/domain/payment"
+	"exchanger/pkg/market"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"strings"
+)
+
+// activeHireIndex is the unique index allowing one active payment per hire
+const activeHireIndex = "payments_active_hire_idx"
+
+type PaymentRepository struct {
+	db *sqlx.DB
+}
+
+func NewPaymentRepository(db *sqlx.DB) *PaymentRepository {
+	return &PaymentRepository{
+		db: db,
+	}
+}
+
+func (r *PaymentRepository) List(ctx context.Context, hireID string) (dest []payment.Entity, err error) {
+	query := `
+		SELECT invoice_id, hire_id, customer_id, COALESCE(worker_id::text, '') AS worker_id, amount, currency,
+		       charged_amount, refunded_amount, status, provider_status, created_at, expires_at, released_at
+		FROM payments
+		WHERE hire_id=$1
+		ORDER BY created_at, invoice_id`
+
+	args := []any{hireID}
+
+	err = conn(ctx, r.db).SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+func (r *PaymentRepository) ListByStatus(ctx context.Context, statuses ...string) (dest []payment.Entity, err error) {
+	query := `
+		SELECT invoice_id, hire_id, customer_id, COALESCE(worker_id::text, '') AS worker_id, amount, currency,
+		       charged_amount, refunded_amount, status, provider_status, created_at, expires_at, released_at
+		FROM payments
+		WHERE status=ANY($1)
+		ORDER BY created_at, invoice_id`
+
+	args := []any{pq.Array(statuses)}
+
+	err = conn(ctx, r.db).SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+func (r *PaymentRepository) Add(ctx context.Context, data payment.Entity) (err error) {
+	query := `
+		INSERT INTO payments (invoice_id, hire_id, customer_id, amount, currency, status, provider_status, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	args := []any{data.InvoiceID, data.HireID, data.CustomerID, data.Amount, data.Currency, data.Status, data.ProviderStatus, data.CreatedAt, data.ExpiresAt}
+
+	if _, err = conn(ctx, r.db).ExecContext(ctx, query, args...); err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+			err = market.ErrorAlreadyExists
+			if pqErr.Constraint == activeHireIndex {
+				err = payment.ErrorActivePayment
+			}
+		}
+	}
+
+	return
+}
+
+func (r *PaymentRepository) Get(ctx context.Context, invoiceID string) (dest payment.Entity, err error) {
+	query := `
+		SELECT invoice_id, hire_id, customer_id, COALESCE(worker_id::text, '') AS worker_id, amount, currency,
+		       charged_amount, refunded_amount, status, provider_status, created_at, expires_at, released_at
+		FROM payments
+		WHERE invoice_id=$1`
+
+	args := []any{invoiceID}
+
+	if err = conn(ctx, r.db).GetContext(ctx, &dest, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = market.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *PaymentRepository) Update(ctx context.Context, invoiceID string, data payment.Entity) (err error) {
+	sets, args := r.prepareArgs(data)
+	if len(args) > 0 {
+
+		args = append(args, invoiceID)
+		sets = append(sets, "updated_at=CURRENT_TIMESTAMP")
+		query := fmt.Sprintf("UPDATE payments SET %s WHERE invoice_id=$%d RETURNING invoice_id", strings.Join(sets, ", "), len(args))
+
+		if err = conn(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(&invoiceID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				err = market.ErrorNotFound
+			}
+		}
+	}
+
+	return
+}
+
+func (r *PaymentRepository) prepareArgs(data payment.Entity) (sets []string, args []any) {
+	if data.WorkerID != "" {
+		args = append(args, data.WorkerID)
+		sets = append(sets, fmt.Sprintf("worker_id=$%d", len(args)))
+	}
+
+	if data.ChargedAmount != nil {
+		args = append(args, data.ChargedAmount)
+		sets = append(sets, fmt.Sprintf("charged_amount=$%d", len(args)))
+	}
+
+	if data.RefundedAmount != nil {
+		args = append(args, data.RefundedAmount)
+		sets = append(sets, fmt.Sprintf("refunded_amount=$%d", len(args)))
+	}
+
+	if data.Status != "" {
+		args = append(args, data.Status)
+		sets = append(sets, fmt.Sprintf("status=$%d", len(args)))
+	}
+
+	if data.ProviderStatus != "" {
+		args = append(args, data.ProviderStatus)
+		sets = append(sets, fmt.Sprintf("provider_status=$%d", len(args)))
+	}
+
+	if data.ReleasedAt != nil {
+		args = append(args, data.ReleasedAt)
+		sets = append(sets, fmt.Sprintf("released_at=$%d", len(args)))
+	}
+
+	return
+}
@@ -0,0 +1,44 @@
+package postgres
+
+import (
+	"context"
+	"exchanger/internal/domain/payment"
+	"github.com/jmoiron/sqlx"
+)
+
+type PaymentCallbackRepository struct {
+	db *sqlx.DB
+}
+
+func NewPaymentCallbackRepository(db *sqlx.DB) *PaymentCallbackRepository {
+	return &PaymentCallbackRepository{
+		db: db,
+	}
+}
+
+func (r *PaymentCallbackRepository) List(ctx context.Context, invoiceID string) (dest []payment.CallbackEntity, err error) {
+	query := `
+		SELECT id, invoice_id, body, remote_addr, received_at
+		FROM payment_callbacks
+		WHERE invoice_id=$1
+		ORDER BY received_at, id`
+
+	args := []any{invoiceID}
+
+	err = conn(ctx, r.db).SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+func (r *PaymentCallbackRepository) Add(ctx context.Context, data payment.CallbackEntity) (id string, err error) {
+	query := `
+		INSERT INTO payment_callbacks (invoice_id, body, remote_addr, received_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id`
+
+	args := []any{data.InvoiceID, data.Body, data.RemoteAddr, data.ReceivedAt}
+
+	err = conn(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(&id)
+
+	return
+}
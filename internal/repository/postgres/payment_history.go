@@ -0,0 +1,44 @@
+package postgres
+
+import (
+	"context"
+	"exchanger/internal/domain/payment"
+	"github.com/jmoiron/sqlx"
+)
+
+type PaymentHistoryRepository struct {
+	db *sqlx.DB
+}
+
+func NewPaymentHistoryRepository(db *sqlx.DB) *PaymentHistoryRepository {
+	return &PaymentHistoryRepository{
+		db: db,
+	}
+}
+
+func (r *PaymentHistoryRepository) List(ctx context.Context, invoiceID string) (dest []payment.HistoryEntity, err error) {
+	query := `
+		SELECT id, invoice_id, operation, amount, from_status, to_status, COALESCE(user_id::text, '') AS user_id, created_at
+		FROM payment_history
+		WHERE invoice_id=$1
+		ORDER BY created_at, id`
+
+	args := []any{invoiceID}
+
+	err = conn(ctx, r.db).SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+func (r *PaymentHistoryRepository) Add(ctx context.Context, data payment.HistoryEntity) (id string, err error) {
+	query := `
+		INSERT INTO payment_history (invoice_id, operation, amount, from_status, to_status, user_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, '')::uuid, $7)
+		RETURNING id`
+
+	args := []any{data.InvoiceID, data.Operation, data.Amount, data.FromStatus, data.ToStatus, data.UserID, data.CreatedAt}
+
+	err = conn(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(&id)
+
+	return
+}
@@ -0,0 +1,140 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"exchanger/internal/domain/proposal"
+	"exchanger/pkg/market"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"strings"
+)
+
+type ProposalRepository struct {
+	db *sqlx.DB
+}
+
+func NewProposalRepository(db *sqlx.DB) *ProposalRepository {
+	return &ProposalRepository{
+		db: db,
+	}
+}
+
+func (r *ProposalRepository) List(ctx context.Context, hireID string, q market.Query) (dest []proposal.Entity, total int, err error) {
+	where, order, args := prepareQuery(q.Where("hire_id", hireID))
+
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM proposals %s`, where)
+	if err = conn(ctx, r.db).GetContext(ctx, &total, query, args...); err != nil {
+		return
+	}
+
+	query = fmt.Sprintf(`
+		SELECT id, hire_id, worker_id, cover_letter, amount, status
+		FROM proposals
+		%s
+		%s`, where, order)
+
+	err = conn(ctx, r.db).SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+func (r *ProposalRepository) Add(ctx context.Context, data proposal.Entity) (id string, err error) {
+	query := `
+		INSERT INTO proposals (hire_id, worker_id, cover_letter, amount, status)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id`
+
+	args := []any{data.HireID, data.WorkerID, data.CoverLetter, data.Amount, data.Status}
+
+	err = conn(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(&id)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+			err = market.ErrorAlreadyExists
+		}
+	}
+
+	return
+}
+
+func (r *ProposalRepository) Get(ctx context.Context, id string) (dest proposal.Entity, err error) {
+	query := `
+		SELECT id, hire_id, worker_id, cover_letter, amount, status
+		FROM proposals
+		WHERE id=$1`
+
+	args := []any{id}
+
+	if err = conn(ctx, r.db).GetContext(ctx, &dest, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = market.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *ProposalRepository) Update(ctx context.Context, id string, data proposal.Entity) (err error) {
+	sets, args := r.prepareArgs(data)
+	if len(args) > 0 {
+
+		args = append(args, id)
+		sets = append(sets, "updated_at=CURRENT_TIMESTAMP")
+		query := fmt.Sprintf("UPDATE proposals SET %s WHERE id=$%d RETURNING id", strings.Join(sets, ", "), len(args))
+
+		if err = conn(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				err = market.ErrorNotFound
+			}
+		}
+	}
+
+	return
+}
+
+func (r *ProposalRepository) prepareArgs(data proposal.Entity) (sets []string, args []any) {
+	if data.CoverLetter != nil {
+		args = append(args, data.CoverLetter)
+		sets = append(sets, fmt.Sprintf("cover_letter=$%d", len(args)))
+	}
+
+	if data.Amount != nil {
+		args = append(args, data.Amount)
+		sets = append(sets, fmt.Sprintf("amount=$%d", len(args)))
+	}
+
+	if data.Status != "" {
+		args = append(args, data.Status)
+		sets = append(sets, fmt.Sprintf("status=$%d", len(args)))
+	}
+
+	return
+}
+
+func (r *ProposalRepository) DeleteByHire(ctx context.Context, hireID string) (err error) {
+	query := `
+		DELETE FROM proposals
+		WHERE hire_id=$1`
+
+	args := []any{hireID}
+
+	_, err = conn(ctx, r.db).ExecContext(ctx, query, args...)
+
+	return
+}
+
+func (r *ProposalRepository) CountByWorker(ctx context.Context, workerID string) (count int, err error) {
+	query := `
+		SELECT COUNT(*)
+		FROM proposals
+		WHERE worker_id=$1`
+
+	args := []any{workerID}
+
+	err = conn(ctx, r.db).GetContext(ctx, &count, query, args...)
+
+	return
+}
@@ -0,0 +1,39 @@
+package postgres
+
+import (
+	"exchanger/pkg/market"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// prepareQuery builds the WHERE and ORDER BY clauses of the list query with its arguments,
+// the columns come from the domain fields so only the values are passed as arguments
+func prepareQuery(q market.Query) (where, order string, args []any) {
+	columns := make([]string, 0, len(q.Filters))
+	for column := range q.Filters {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	conditions := make([]string, 0, len(columns))
+	for _, column := range columns {
+		value := q.Filters[column]
+		args = append(args, value)
+
+		if _, ok := value.(string); ok {
+			conditions = append(conditions, fmt.Sprintf("%s::text=$%d", column, len(args)))
+			continue
+		}
+		conditions = append(conditions, fmt.Sprintf("%s=$%d", column, len(args)))
+	}
+
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+	// the ties are broken by id in the same direction as memory and mongo do
+	direction := strings.ToUpper(q.Order)
+	order = fmt.Sprintf("ORDER BY %s %s, id %s LIMIT %d OFFSET %d", q.Sort, direction, direction, q.Limit, q.Offset)
+
+	return
+}
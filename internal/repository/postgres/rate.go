@@ -0,0 +1,50 @@
+package postgres
+
+import (
+	"context"
+	"exchanger/internal/domain/rate"
+	"github.com/jmoiron/sqlx"
+	"time"
+)
+
+type RateRepository struct {
+	db *sqlx.DB
+}
+
+func NewRateRepository(db *sqlx.DB) *RateRepository {
+	return &RateRepository{
+		db: db,
+	}
+}
+
+func (r *RateRepository) List(ctx context.Context, date time.Time) (dest []rate.Entity, err error) {
+	query := `
+		SELECT id, date, code, name, value, quant, base, source
+		FROM rates
+		WHERE date=$1
+		ORDER BY code`
+
+	args := []any{date}
+
+	err = conn(ctx, r.db).SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+func (r *RateRepository) Add(ctx context.Context, data []rate.Entity) (err error) {
+	query := `
+		INSERT INTO rates (id, date, code, name, value, quant, base, source)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (id) DO NOTHING`
+
+	// the rates of the day are stored all at once or not at all
+	return NewUnitOfWork(r.db).Do(ctx, func(ctx context.Context) error {
+		for _, object := range data {
+			args := []any{rate.NewID(object.Date, object.Code), object.Date, object.Code, object.Name, object.Value, object.Quant, object.Base, object.Source}
+			if _, err := conn(ctx, r.db).ExecContext(ctx, query, args...); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
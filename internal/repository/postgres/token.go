@@ -0,0 +1,101 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"exchanger/internal/domain/token"
+	"exchanger/pkg/market"
+	"github.com/jmoiron/sqlx"
+)
+
+type TokenRepository struct {
+	db *sqlx.DB
+}
+
+func NewTokenRepository(db *sqlx.DB) *TokenRepository {
+	return &TokenRepository{
+		db: db,
+	}
+}
+
+func (r *TokenRepository) Add(ctx context.Context, data token.Entity) (err error) {
+	query := `
+		INSERT INTO tokens (id, refresh_token_id, credential, token_type)
+		VALUES ($1, $2, $3, $4)`
+
+	args := []any{data.ID, data.RefreshTokenID, data.Credential, data.TokenType}
+
+	_, err = conn(ctx, r.db).ExecContext(ctx, query, args...)
+
+	return
+}
+
+func (r *TokenRepository) Get(ctx context.Context, id string) (dest token.Entity, err error) {
+	query := `
+		SELECT id, refresh_token_id, credential, token_type, created_at, rotated_at, revoked_at
+		FROM tokens
+		WHERE id=$1`
+
+	args := []any{id}
+
+	if err = conn(ctx, r.db).GetContext(ctx, &dest, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = market.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *TokenRepository) Rotate(ctx context.Context, id, refreshTokenID string) (err error) {
+	query := `
+		UPDATE tokens
+		SET rotated_at=CURRENT_TIMESTAMP
+		WHERE id=$1 AND refresh_token_id=$2 AND rotated_at IS NULL AND revoked_at IS NULL
+		RETURNING id`
+
+	args := []any{id, refreshTokenID}
+
+	if err = conn(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = market.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *TokenRepository) Revoke(ctx context.Context, id string) (err error) {
+	query := `
+		UPDATE tokens
+		SET revoked_at=COALESCE(revoked_at, CURRENT_TIMESTAMP)
+		WHERE id=$1
+		RETURNING id`
+
+	args := []any{id}
+
+	if err = conn(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = market.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *TokenRepository) RevokeByCredential(ctx context.Context, credential string) (count int64, err error) {
+	query := `
+		UPDATE tokens
+		SET revoked_at=CURRENT_TIMESTAMP
+		WHERE credential=$1 AND revoked_at IS NULL`
+
+	args := []any{credential}
+
+	res, err := conn(ctx, r.db).ExecContext(ctx, query, args...)
+	if err != nil {
+		return
+	}
+
+	return res.RowsAffected()
+}
@@ -0,0 +1,65 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"github.com/jmoiron/sqlx"
+)
+
+type transaction struct{}
+
+// executor is the part of sqlx the repositories query by, it's the transaction of the unit of work
+// when the context carries one and the db otherwise
+type executor interface {
+	GetContext(ctx context.Context, dest any, query string, args ...any) error
+	SelectContext(ctx context.Context, dest any, query string, args ...any) error
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// conn returns the transaction of the context or the db when the call is made outside of a unit of work
+func conn(ctx context.Context, db *sqlx.DB) executor {
+	if tx, ok := ctx.Value(transaction{}).(*sqlx.Tx); ok {
+		return tx
+	}
+
+	return db
+}
+
+// UnitOfWork runs the repository calls in one postgres transaction
+type UnitOfWork struct {
+	db *sqlx.DB
+}
+
+func NewUnitOfWork(db *sqlx.DB) *UnitOfWork {
+	return &UnitOfWork{
+		db: db,
+	}
+}
+
+// Do begins the transaction and commits it once fn succeeds, the unit of work started inside another one
+// joins the outer transaction
+func (u *UnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if _, ok := ctx.Value(transaction{}).(*sqlx.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := u.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err = fn(context.WithValue(ctx, transaction{}, tx)); err != nil {
+		tx.Rollback()
+		return
+	}
+
+	return tx.Commit()
+}
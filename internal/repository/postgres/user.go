@@ -0,0 +1,116 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"exchanger/internal/domain/user"
+	"exchanger/pkg/market"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"strings"
+)
+
+// uniqueViolation is the postgres error code raised on a duplicate key
+const uniqueViolation = "23505"
+
+type UserRepository struct {
+	db *sqlx.DB
+}
+
+func NewUserRepository(db *sqlx.DB) *UserRepository {
+	return &UserRepository{
+		db: db,
+	}
+}
+
+func (r *UserRepository) Add(ctx context.Context, data user.Entity) (id string, err error) {
+	query := `
+		INSERT INTO users (username, password_hash, role, is_active)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id`
+
+	args := []any{data.Username, data.PasswordHash, data.Role, data.IsActive}
+
+	err = conn(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(&id)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+			err = market.ErrorAlreadyExists
+		}
+	}
+
+	return
+}
+
+func (r *UserRepository) Get(ctx context.Context, id string) (dest user.Entity, err error) {
+	query := `
+		SELECT id, username, password_hash, role, is_active
+		FROM users
+		WHERE id=$1`
+
+	args := []any{id}
+
+	if err = conn(ctx, r.db).GetContext(ctx, &dest, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = market.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *UserRepository) GetByUsername(ctx context.Context, username string) (dest user.Entity, err error) {
+	query := `
+		SELECT id, username, password_hash, role, is_active
+		FROM users
+		WHERE username=$1`
+
+	args := []any{username}
+
+	if err = conn(ctx, r.db).GetContext(ctx, &dest, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = market.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *UserRepository) Update(ctx context.Context, id string, data user.Entity) (err error) {
+	sets, args := r.prepareArgs(data)
+	if len(args) > 0 {
+
+		args = append(args, id)
+		sets = append(sets, "updated_at=CURRENT_TIMESTAMP")
+		query := fmt.Sprintf("UPDATE users SET %s WHERE id=$%d RETURNING id", strings.Join(sets, ", "), len(args))
+
+		if err = conn(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				err = market.ErrorNotFound
+			}
+		}
+	}
+
+	return
+}
+
+func (r *UserRepository) prepareArgs(data user.Entity) (sets []string, args []any) {
+	if data.PasswordHash != nil {
+		args = append(args, data.PasswordHash)
+		sets = append(sets, fmt.Sprintf("password_hash=$%d", len(args)))
+	}
+
+	if data.Role != nil {
+		args = append(args, data.Role)
+		sets = append(sets, fmt.Sprintf("role=$%d", len(args)))
+	}
+
+	if data.IsActive != nil {
+		args = append(args, data.IsActive)
+		sets = append(sets, fmt.Sprintf("is_active=$%d", len(args)))
+	}
+
+	return
+}
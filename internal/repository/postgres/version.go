@@ -0,0 +1,37 @@
+package postgres
+
+import (
+	"context"
+	"exchanger/pkg/market"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+)
+
+// versionCondition adds the version the change is made for to the args, zero version matches any
+func versionCondition(version int, args []any) (condition string, dest []any) {
+	if version == 0 {
+		return "", args
+	}
+	dest = append(args, version)
+
+	return fmt.Sprintf(" AND version=$%d", len(dest)), dest
+}
+
+// missingOrConflict tells the missing or deleted row from the row of another version once the change matched nothing
+func missingOrConflict(ctx context.Context, db *sqlx.DB, table, id string, version int) (err error) {
+	if version == 0 {
+		return market.ErrorNotFound
+	}
+
+	var exists bool
+	query := fmt.Sprintf("SELECT EXISTS (SELECT 1 FROM %s WHERE id=$1 AND deleted_at IS NULL)", table)
+	if err = conn(ctx, db).GetContext(ctx, &exists, query, id); err != nil {
+		return
+	}
+
+	if exists {
+		return market.ErrorConflict
+	}
+
+	return market.ErrorNotFound
+}
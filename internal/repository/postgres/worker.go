@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"github.com/jmoiron/sqlx"
 	"strings"
+	"time"
 )
 
 type WorkerRepository struct {
@@ -21,26 +22,35 @@ func NewWorkerRepository(db *sqlx.DB) *WorkerRepository {
 	}
 }
 
-func (r *WorkerRepository) List(ctx context.Context) (dest []worker.Entity, err error) {
-	query := `
-		SELECT id, full_name, position, description
+func (r *WorkerRepository) List(ctx context.Context, q market.Query) (dest []worker.Entity, total int, err error) {
+	where, order, args := prepareQuery(q)
+	where = excludeDeleted(ctx, where)
+
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM workers %s`, where)
+	if err = conn(ctx, r.db).GetContext(ctx, &total, query, args...); err != nil {
+		return
+	}
+
+	query = fmt.Sprintf(`
+		SELECT id, full_name, pseudonym, position, description, COALESCE(user_id::text, '') AS user_id, version, deleted_at
 		FROM workers
-		ORDER BY id`
+		%s
+		%s`, where, order)
 
-	err = r.db.SelectContext(ctx, &dest, query)
+	err = conn(ctx, r.db).SelectContext(ctx, &dest, query, args...)
 
 	return
 }
 
 func (r *WorkerRepository) Add(ctx context.Context, data worker.Entity) (id string, err error) {
 	query := `
-		INSERT INTO workers (full_name, position, description)
-		VALUES ($1, $2, $3)
+		INSERT INTO workers (full_name, pseudonym, position, description, user_id)
+		VALUES ($1, $2, $3, $4, NULLIF($5, '')::uuid)
 		RETURNING id`
 
-	args := []any{data.FullName, data.Position, data.Description}
+	args := []any{data.FullName, data.Pseudonym, data.Position, data.Description, data.UserID}
 
-	err = r.db.QueryRowContext(ctx, query, args...).Scan(&id)
+	err = conn(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(&id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			err = market.ErrorNotFound
@@ -51,14 +61,14 @@ func (r *WorkerRepository) Add(ctx context.Context, data worker.Entity) (id stri
 }
 
 func (r *WorkerRepository) Get(ctx context.Context, id string) (dest worker.Entity, err error) {
-	query := `
-		SELECT id, full_name, position, description
+	query := fmt.Sprintf(`
+		SELECT id, full_name, pseudonym, position, description, COALESCE(user_id::text, '') AS user_id, version, deleted_at
 		FROM workers
-		WHERE id=$1`
+		%s`, excludeDeleted(ctx, "WHERE id=$1"))
 
 	args := []any{id}
 
-	if err = r.db.GetContext(ctx, &dest, query, args...); err != nil {
+	if err = conn(ctx, r.db).GetContext(ctx, &dest, query, args...); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			err = market.ErrorNotFound
 		}
@@ -72,12 +82,16 @@ func (r *WorkerRepository) Update(ctx context.Context, id string, data worker.En
 	if len(args) > 0 {
 
 		args = append(args, id)
-		sets = append(sets, "updated_at=CURRENT_TIMESTAMP")
-		query := fmt.Sprintf("UPDATE workers SET %r WHERE id=$%d RETURNING id", strings.Join(sets, ", "), len(args))
+		sets = append(sets, "updated_at=CURRENT_TIMESTAMP", "version=version+1")
+		where := fmt.Sprintf("id=$%d AND deleted_at IS NULL", len(args))
 
-		if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		var condition string
+		condition, args = versionCondition(data.Version, args)
+		query := fmt.Sprintf("UPDATE workers SET %s WHERE %s%s RETURNING id", strings.Join(sets, ", "), where, condition)
+
+		if err = conn(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
 			if errors.Is(err, sql.ErrNoRows) {
-				err = market.ErrorNotFound
+				err = missingOrConflict(ctx, r.db, "workers", id, data.Version)
 			}
 		}
 	}
@@ -96,23 +110,79 @@ func (r *WorkerRepository) prepareArgs(data worker.Entity) (sets []string, args
 		sets = append(sets, fmt.Sprintf("full_name=$%d", len(args)))
 	}
 
+	if data.Pseudonym != nil {
+		args = append(args, data.Pseudonym)
+		sets = append(sets, fmt.Sprintf("pseudonym=$%d", len(args)))
+	}
+
 	if data.Position != nil {
-		args = append(args, data.FullName)
+		args = append(args, data.Position)
 		sets = append(sets, fmt.Sprintf("position=$%d", len(args)))
 	}
 
 	return
 }
 
-func (r *WorkerRepository) Delete(ctx context.Context, id string) (err error) {
+func (r *WorkerRepository) Delete(ctx context.Context, id string, version int) (err error) {
+	args := []any{id}
+
+	condition, args := versionCondition(version, args)
+	query := fmt.Sprintf(`
+		UPDATE workers
+		SET deleted_at=CURRENT_TIMESTAMP AT TIME ZONE 'UTC', version=version+1
+		WHERE id=$1 AND deleted_at IS NULL%s
+		RETURNING id`, condition)
+
+	if err = conn(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = missingOrConflict(ctx, r.db, "workers", id, version)
+		}
+	}
+
+	return
+}
+
+func (r *WorkerRepository) Restore(ctx context.Context, id string) (err error) {
+	query := `
+		UPDATE workers
+		SET deleted_at=NULL, version=version+1
+		WHERE id=$1 AND deleted_at IS NOT NULL
+		RETURNING id`
+
+	args := []any{id}
+
+	if err = conn(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = market.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *WorkerRepository) ListDeleted(ctx context.Context, before time.Time) (dest []worker.Entity, err error) {
+	query := `
+		SELECT id, full_name, pseudonym, position, description, COALESCE(user_id::text, '') AS user_id, version, deleted_at
+		FROM workers
+		WHERE deleted_at<$1
+		ORDER BY deleted_at`
+
+	args := []any{before.UTC()}
+
+	err = conn(ctx, r.db).SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+func (r *WorkerRepository) Purge(ctx context.Context, id string) (err error) {
 	query := `
 		DELETE FROM workers
-		WHERE id=$1
+		WHERE id=$1 AND deleted_at IS NOT NULL
 		RETURNING id`
 
 	args := []any{id}
 
-	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+	if err = conn(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			err = market.ErrorNotFound
 		}
@@ -1,13 +1,24 @@
 package repository
 
 import (
+	"context"
+	"exchanger/internal/domain/audit"
+	"exchanger/internal/domain/client"
 	"exchanger/internal/domain/customer"
+	"exchanger/internal/domain/event"
 	"exchanger/internal/domain/hire"
+	"exchanger/internal/domain/payment"
+	"exchanger/internal/domain/proposal"
+	"exchanger/internal/domain/rate"
+	"exchanger/internal/domain/token"
+	"exchanger/internal/domain/user"
 	"exchanger/internal/domain/worker"
 	"exchanger/internal/repository/memory"
 	"exchanger/internal/repository/mongo"
 	"exchanger/internal/repository/postgres"
+	"exchanger/pkg/log"
 	"exchanger/pkg/market"
+	"fmt"
 )
 
 // Configuration is an alias for a function that will take in a pointer to a Repository and modify it
@@ -18,9 +29,24 @@ type Repository struct {
 	mongo    market.Mongo
 	postgres market.SQLX
 
-	Customer customer.Repository
-	Hire     hire.Repository
-	Worker   worker.Repository
+	// UnitOfWork runs the calls of several repositories in one transaction of the store, it is nil for
+	// the standalone mongo server and the memory one doesn't roll back
+	UnitOfWork market.UnitOfWork
+
+	Customer        customer.Repository
+	Hire            hire.Repository
+	HireHistory     hire.HistoryRepository
+	Worker          worker.Repository
+	Proposal        proposal.Repository
+	Payment         payment.Repository
+	PaymentCallback payment.CallbackRepository
+	PaymentHistory  payment.HistoryRepository
+	Rate            rate.Repository
+	User            user.Repository
+	Client          client.Repository
+	Token           token.Repository
+	Audit           audit.Repository
+	Outbox          event.Repository
 }
 
 // New takes a variable amount of Configuration functions and returns a new Repository
@@ -58,46 +84,112 @@ func WithMemoryStore() Configuration {
 		// Create the memory store, if we needed parameters, such as connection strings they could be inputted here
 		s.Customer = memory.NewCustomerRepository()
 		s.Hire = memory.NewHireRepository()
+		s.HireHistory = memory.NewHireHistoryRepository()
 		s.Worker = memory.NewWorkerRepository()
+		s.Proposal = memory.NewProposalRepository()
+		s.Payment = memory.NewPaymentRepository()
+		s.PaymentCallback = memory.NewPaymentCallbackRepository()
+		s.PaymentHistory = memory.NewPaymentHistoryRepository()
+		s.Rate = memory.NewRateRepository()
+		s.User = memory.NewUserRepository()
+		s.Client = memory.NewClientRepository()
+		s.Token = memory.NewTokenRepository()
+		s.Audit = memory.NewAuditRepository()
+		s.Outbox = memory.NewOutboxRepository()
+		s.UnitOfWork = memory.NewUnitOfWork()
 
 		return
 	}
 }
 
 // WithMongoStore applies a mongo store to the Repository
-func WithMongoStore(url, name string) Configuration {
+func WithMongoStore(url, name string, maxPoolSize, minPoolSize int) Configuration {
 	return func(s *Repository) (err error) {
 		// Create the mongo store, if we needed parameters, such as connection strings they could be inputted here
-		s.mongo, err = market.NewMongo(url)
+		s.mongo, err = market.NewMongo(url, maxPoolSize, minPoolSize)
 		if err != nil {
-			return
+			return fmt.Errorf("mongo store is unreachable: %w", err)
 		}
 		database := s.mongo.Client.Database(name)
 
+		proposals := mongo.NewProposalRepository(database)
+		if err = proposals.CreateIndexes(context.Background()); err != nil {
+			return fmt.Errorf("mongo store indexing failed: %w", err)
+		}
+
+		payments := mongo.NewPaymentRepository(database)
+		if err = payments.CreateIndexes(context.Background()); err != nil {
+			return fmt.Errorf("mongo store indexing failed: %w", err)
+		}
+
+		audits := mongo.NewAuditRepository(database)
+		if err = audits.CreateIndexes(context.Background()); err != nil {
+			return fmt.Errorf("mongo store indexing failed: %w", err)
+		}
+
+		outbox := mongo.NewOutboxRepository(database)
+		if err = outbox.CreateIndexes(context.Background()); err != nil {
+			return fmt.Errorf("mongo store indexing failed: %w", err)
+		}
+
 		s.Customer = mongo.NewCustomerRepository(database)
 		s.Hire = mongo.NewHireRepository(database)
+		s.HireHistory = mongo.NewHireHistoryRepository(database)
 		s.Worker = mongo.NewWorkerRepository(database)
+		s.Proposal = proposals
+		s.Payment = payments
+		s.PaymentCallback = mongo.NewPaymentCallbackRepository(database)
+		s.PaymentHistory = mongo.NewPaymentHistoryRepository(database)
+		s.Rate = mongo.NewRateRepository(database)
+		s.User = mongo.NewUserRepository(database)
+		s.Client = mongo.NewClientRepository(database)
+		s.Token = mongo.NewTokenRepository(database)
+		s.Audit = audits
+		s.Outbox = outbox
+
+		// the standalone server has no transactions, the calls of a unit of work are made one by one there
+		transactional, err := mongo.SupportsTransactions(context.Background(), s.mongo.Client)
+		if err != nil {
+			return fmt.Errorf("mongo store is unreachable: %w", err)
+		}
+		if !transactional {
+			log.LoggerFromContext(context.Background()).Warn("mongo store isn't a replica set, the units of work run without transactions")
+			return
+		}
+		s.UnitOfWork = mongo.NewUnitOfWork(s.mongo.Client)
 
 		return
 	}
 }
 
 // WithPostgresStore applies a postgres store to the Repository
-func WithPostgresStore(dataSourceName string) Configuration {
+func WithPostgresStore(dataSourceName string, maxOpenConns, maxIdleConns int) Configuration {
 	return func(s *Repository) (err error) {
 		// Create the postgres store, if we needed parameters, such as connection strings they could be inputted here
-		s.postgres, err = market.NewSQL(dataSourceName)
+		s.postgres, err = market.NewSQL(dataSourceName, maxOpenConns, maxIdleConns)
 		if err != nil {
-			return
+			return fmt.Errorf("postgres store is unreachable: %w", err)
 		}
 
 		if err = market.Migrate(dataSourceName); err != nil {
-			return
+			return fmt.Errorf("postgres store migration failed: %w", err)
 		}
 
 		s.Customer = postgres.NewCustomerRepository(s.postgres.Client)
 		s.Hire = postgres.NewHireRepository(s.postgres.Client)
+		s.HireHistory = postgres.NewHireHistoryRepository(s.postgres.Client)
 		s.Worker = postgres.NewWorkerRepository(s.postgres.Client)
+		s.Proposal = postgres.NewProposalRepository(s.postgres.Client)
+		s.Payment = postgres.NewPaymentRepository(s.postgres.Client)
+		s.PaymentCallback = postgres.NewPaymentCallbackRepository(s.postgres.Client)
+		s.PaymentHistory = postgres.NewPaymentHistoryRepository(s.postgres.Client)
+		s.Rate = postgres.NewRateRepository(s.postgres.Client)
+		s.User = postgres.NewUserRepository(s.postgres.Client)
+		s.Client = postgres.NewClientRepository(s.postgres.Client)
+		s.Token = postgres.NewTokenRepository(s.postgres.Client)
+		s.Audit = postgres.NewAuditRepository(s.postgres.Client)
+		s.Outbox = postgres.NewOutboxRepository(s.postgres.Client)
+		s.UnitOfWork = postgres.NewUnitOfWork(s.postgres.Client)
 
 		return
 	}
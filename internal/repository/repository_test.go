@@ -0,0 +1,882 @@
+package repository_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"exchanger/internal/domain/audit"
+	"exchanger/internal/domain/customer"
+	"exchanger/internal/domain/event"
+	"exchanger/internal/domain/hire"
+	"exchanger/internal/domain/payment"
+	"exchanger/internal/domain/worker"
+	"exchanger/internal/repository"
+	"exchanger/pkg/market"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// crud is the part of the customer, worker and hire repositories every backend must agree on
+type crud[T any] interface {
+	List(ctx context.Context, q market.Query) (dest []T, total int, err error)
+	Add(ctx context.Context, data T) (id string, err error)
+	Get(ctx context.Context, id string) (dest T, err error)
+	Update(ctx context.Context, id string, data T) (err error)
+	Delete(ctx context.Context, id string, version int) (err error)
+	Restore(ctx context.Context, id string) (err error)
+	ListDeleted(ctx context.Context, before time.Time) (dest []T, err error)
+	Purge(ctx context.Context, id string) (err error)
+}
+
+// conformance describes the entity of a repository to the suite
+type conformance[T any] struct {
+	repository crud[T]
+
+	// fixture returns a new entity tagged with the value of the filter column
+	fixture func(t *testing.T, tag string) T
+	// column is the column the entities are filtered by in the list
+	column string
+	// patch returns the partial update of the entity and the entity expected after it
+	patch func(data T) (update, want T)
+
+	idOf        func(data T) string
+	withID      func(data T, id string) T
+	withVersion func(data T, version int) T
+	deletedAt   func(data T) *time.Time
+	equal       func(a, b T) bool
+}
+
+// backends returns the stores the suite runs against, postgres and mongo run only when
+// TEST_POSTGRES_DSN or TEST_MONGO_DSN points to a local database
+func backends(t *testing.T) map[string]*repository.Repository {
+	stores := map[string]repository.Configuration{
+		"memory": repository.WithMemoryStore(),
+	}
+
+	if dsn := os.Getenv("TEST_POSTGRES_DSN"); dsn != "" {
+		stores["postgres"] = repository.WithPostgresStore(dsn, 5, 1)
+	}
+
+	if dsn := os.Getenv("TEST_MONGO_DSN"); dsn != "" {
+		stores["mongo"] = repository.WithMongoStore(dsn, "exchanger_test", 5, 1)
+	}
+
+	repositories := make(map[string]*repository.Repository, len(stores))
+	for name, store := range stores {
+		r, err := repository.New(store)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		t.Cleanup(r.Close)
+		repositories[name] = r
+	}
+
+	return repositories
+}
+
+func TestCustomerRepository(t *testing.T) {
+	for name, r := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			runConformance(t, customerConformance(r))
+		})
+	}
+}
+
+func TestWorkerRepository(t *testing.T) {
+	for name, r := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			runConformance(t, conformance[worker.Entity]{
+				repository: r.Worker,
+				fixture: func(t *testing.T, tag string) worker.Entity {
+					return worker.Entity{
+						FullName:    pointer("Ann Lee"),
+						Pseudonym:   pointer(tag),
+						Description: pointer("builds sites"),
+						Position:    pointer("developer"),
+					}
+				},
+				column: "pseudonym",
+				patch: func(data worker.Entity) (update, want worker.Entity) {
+					update = worker.Entity{Position: pointer("designer")}
+					want = data
+					want.Position = update.Position
+					return
+				},
+				idOf: func(data worker.Entity) string {
+					return data.ID
+				},
+				withID: func(data worker.Entity, id string) worker.Entity {
+					data.ID = id
+					return data
+				},
+				withVersion: func(data worker.Entity, version int) worker.Entity {
+					data.Version = version
+					return data
+				},
+				deletedAt: func(data worker.Entity) *time.Time {
+					return data.DeletedAt
+				},
+				equal: func(a, b worker.Entity) bool {
+					return reflect.DeepEqual(a, b)
+				},
+			})
+		})
+	}
+}
+
+func TestHireRepository(t *testing.T) {
+	for name, r := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			customers := customerConformance(r)
+
+			runConformance(t, conformance[hire.Entity]{
+				repository: r.Hire,
+				fixture: func(t *testing.T, tag string) hire.Entity {
+					// the hire belongs to a customer that exists in every backend
+					customerID, err := r.Customer.Add(context.Background(), customers.fixture(t, uuid.NewString()))
+					if err != nil {
+						t.Fatalf("add customer: %v", err)
+					}
+					amount := decimal.RequireFromString("1500.50")
+
+					return hire.Entity{
+						JobName:     pointer("landing page"),
+						Amount:      &amount,
+						Currency:    pointer(hire.DefaultCurrency),
+						Description: pointer("a page of the product"),
+						Position:    pointer(tag),
+						CustomerID:  customerID,
+						Status:      hire.StatusDraft,
+					}
+				},
+				column: "position",
+				patch: func(data hire.Entity) (update, want hire.Entity) {
+					amount := decimal.RequireFromString("2000")
+					update = hire.Entity{Amount: &amount, Status: hire.StatusOpen}
+					want = data
+					want.Amount, want.Status = update.Amount, update.Status
+					return
+				},
+				idOf: func(data hire.Entity) string {
+					return data.ID
+				},
+				withID: func(data hire.Entity, id string) hire.Entity {
+					data.ID = id
+					return data
+				},
+				withVersion: func(data hire.Entity, version int) hire.Entity {
+					data.Version = version
+					return data
+				},
+				deletedAt: func(data hire.Entity) *time.Time {
+					return data.DeletedAt
+				},
+				equal: func(a, b hire.Entity) bool {
+					// the backends keep the scale of the amount their own way
+					if (a.Amount == nil) != (b.Amount == nil) || a.Amount != nil && !a.Amount.Equal(*b.Amount) {
+						return false
+					}
+					a.Amount, b.Amount = nil, nil
+					return reflect.DeepEqual(a, b)
+				},
+			})
+		})
+	}
+}
+
+func TestHireSearch(t *testing.T) {
+	ctx := context.Background()
+
+	for name, r := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			customerID, err := r.Customer.Add(ctx, customerConformance(r).fixture(t, uuid.NewString()))
+			if err != nil {
+				t.Fatalf("add customer: %v", err)
+			}
+
+			// the tag keeps the hires apart from the ones the other tests leave in the store
+			tag := "x" + strings.ReplaceAll(uuid.NewString(), "-", "")
+			fixtures := []struct {
+				jobName, position, description, currency, amount string
+			}{
+				{"Landing page " + tag, "developer", "pages of the site", "USD", "100"},
+				{"Mobile app " + tag, "page designer", "the landing flow", "EUR", "200"},
+				{tag + " backend", "developer", "the api of the homepage", "EUR", "300"},
+			}
+
+			ids := make([]string, len(fixtures))
+			for i, fixture := range fixtures {
+				amount := decimal.RequireFromString(fixture.amount)
+				if ids[i], err = r.Hire.Add(ctx, hire.Entity{
+					JobName:     pointer(fixture.jobName),
+					Amount:      &amount,
+					Currency:    pointer(fixture.currency),
+					Description: pointer(fixture.description),
+					Position:    pointer(fixture.position),
+					CustomerID:  customerID,
+					Status:      hire.StatusDraft,
+				}); err != nil {
+					t.Fatalf("add hire: %v", err)
+				}
+			}
+
+			eur, from := "EUR", decimal.RequireFromString("250")
+			tests := []struct {
+				name   string
+				search hire.Search
+				want   []string
+			}{
+				{"every term starts a word", hire.Search{Text: tag + " PAG"}, []string{ids[0], ids[1]}},
+				{"the middle of a word doesn't match", hire.Search{Text: tag + " age"}, nil},
+				{"the operators are words as well", hire.Search{Text: tag + " -page"}, []string{ids[0], ids[1]}},
+				{"the amounts are compared within the currency", hire.Search{Text: tag, Currency: eur, AmountFrom: &from}, []string{ids[2]}},
+				// the hires ranked the same are ordered by id
+				{"the currency filters alone", hire.Search{Text: tag, Currency: eur}, sorted(ids[1], ids[2])},
+			}
+
+			for _, tt := range tests {
+				t.Run(tt.name, func(t *testing.T) {
+					tt.search.Query = market.Query{Limit: 10}
+
+					got, total, err := r.Hire.Search(ctx, tt.search)
+					if err != nil {
+						t.Fatalf("search: %v", err)
+					}
+
+					var gotIDs []string
+					for _, data := range got {
+						gotIDs = append(gotIDs, data.ID)
+					}
+					if total != len(tt.want) || !reflect.DeepEqual(gotIDs, tt.want) {
+						t.Errorf("search: got %v of %d, want %v", gotIDs, total, tt.want)
+					}
+				})
+			}
+
+			got, _, err := r.Hire.Search(ctx, hire.Search{Text: tag + " page", Query: market.Query{Limit: 10}})
+			if err != nil || len(got) != 2 {
+				t.Fatalf("search: got %d hires and %v, want 2", len(got), err)
+			}
+
+			// the job name counts twice and the description once, the position is left out
+			if want := 2*hire.Weights["job_name"] + hire.Weights["description"]; got[0].Rank < want-1e-9 || got[0].Rank > want+1e-9 {
+				t.Errorf("rank: got %v, want %v", got[0].Rank, want)
+			}
+			if want := "Landing <b>page</b> <b>" + tag + "</b>"; got[0].JobNameHighlight != want {
+				t.Errorf("highlight: got %q, want %q", got[0].JobNameHighlight, want)
+			}
+		})
+	}
+}
+
+func TestPaymentRepository(t *testing.T) {
+	ctx := context.Background()
+
+	for name, r := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			customerID, err := r.Customer.Add(ctx, customerConformance(r).fixture(t, uuid.NewString()))
+			if err != nil {
+				t.Fatalf("add customer: %v", err)
+			}
+			amount := decimal.RequireFromString("1500")
+			hireID, err := r.Hire.Add(ctx, hire.Entity{
+				JobName:     pointer("landing page"),
+				Amount:      &amount,
+				Currency:    pointer(hire.DefaultCurrency),
+				Description: pointer("a page of the product"),
+				Position:    pointer("developer"),
+				CustomerID:  customerID,
+				Status:      hire.StatusDraft,
+			})
+			if err != nil {
+				t.Fatalf("add hire: %v", err)
+			}
+
+			fixture := func(status string) payment.Entity {
+				return payment.Entity{
+					InvoiceID:  uuid.NewString()[:15],
+					HireID:     hireID,
+					CustomerID: customerID,
+					Amount:     pointer(1500),
+					Currency:   pointer(hire.DefaultCurrency),
+					Status:     status,
+					CreatedAt:  time.Now().UTC().Truncate(time.Second),
+				}
+			}
+
+			first := fixture(payment.StatusPending)
+			if err = r.Payment.Add(ctx, first); err != nil {
+				t.Fatalf("add: %v", err)
+			}
+
+			// the hire keeps one pending or funded payment at a time
+			if err = r.Payment.Add(ctx, fixture(payment.StatusPending)); !errors.Is(err, payment.ErrorActivePayment) {
+				t.Fatalf("add second active: got %v, want %v", err, payment.ErrorActivePayment)
+			}
+
+			// the taken invoice id tells apart from the active payment of the hire
+			taken := fixture(payment.StatusFailed)
+			taken.InvoiceID = first.InvoiceID
+			if err = r.Payment.Add(ctx, taken); !errors.Is(err, market.ErrorAlreadyExists) {
+				t.Fatalf("add taken invoice id: got %v, want %v", err, market.ErrorAlreadyExists)
+			}
+			if err = r.Payment.Add(ctx, fixture(payment.StatusFailed)); err != nil {
+				t.Fatalf("add inactive: %v", err)
+			}
+
+			if err = r.Payment.Update(ctx, first.InvoiceID, payment.Entity{Status: payment.StatusExpired}); err != nil {
+				t.Fatalf("update: %v", err)
+			}
+			if err = r.Payment.Add(ctx, fixture(payment.StatusPending)); err != nil {
+				t.Errorf("add after expiry: %v", err)
+			}
+		})
+	}
+}
+
+func TestAuditRepository(t *testing.T) {
+	ctx := context.Background()
+
+	for name, r := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			id, other := uuid.NewString(), uuid.NewString()
+			created := time.Now().UTC().Truncate(time.Second)
+
+			records := []audit.Entity{
+				{Entity: audit.EntityCustomer, EntityID: id, Action: audit.ActionUpdate, CreatedAt: created.Add(time.Second),
+					Diff: audit.Diff{"fullName": {Before: "Ann", After: "Ann Lee"}}},
+				{Entity: audit.EntityCustomer, EntityID: id, Action: audit.ActionAdd, ActorID: id, RequestID: "request",
+					Diff: audit.Diff{"fullName": {After: "Ann"}}, CreatedAt: created},
+				{Entity: audit.EntityCustomer, EntityID: other, Action: audit.ActionAdd, CreatedAt: created},
+				{Entity: audit.EntityWorker, EntityID: id, Action: audit.ActionAdd, CreatedAt: created},
+			}
+			for _, data := range records {
+				if _, err := r.Audit.Add(ctx, data); err != nil {
+					t.Fatalf("add: %v", err)
+				}
+			}
+
+			got, err := r.Audit.List(ctx, audit.EntityCustomer, id)
+			if err != nil {
+				t.Fatalf("list: %v", err)
+			}
+
+			// only the changes of the entity come back, the oldest one first
+			if len(got) != 2 || got[0].Action != audit.ActionAdd || got[1].Action != audit.ActionUpdate {
+				t.Fatalf("list: got %+v, want the add and then the update", got)
+			}
+
+			if got[0].ActorID != id || got[0].RequestID != "request" {
+				t.Errorf("list: got actor %q and request %q, want %q and %q", got[0].ActorID, got[0].RequestID, id, "request")
+			}
+
+			if !reflect.DeepEqual(got[1].Diff, records[0].Diff) {
+				t.Errorf("list: got diff %+v, want %+v", got[1].Diff, records[0].Diff)
+			}
+		})
+	}
+}
+
+func TestOutboxRepository(t *testing.T) {
+	ctx := context.Background()
+
+	for name, r := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			// the events are older than any other pending one, so that they're listed first
+			created := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+			ids := make([]string, 3)
+			for i := range ids {
+				data := event.Entity{
+					Type:      event.HireCreated,
+					EntityID:  uuid.NewString(),
+					Payload:   `{"status": "draft"}`,
+					CreatedAt: created.Add(time.Duration(i) * time.Second),
+				}
+
+				id, err := r.Outbox.Add(ctx, data)
+				if err != nil {
+					t.Fatalf("add: %v", err)
+				}
+				ids[i] = id
+			}
+			t.Cleanup(func() {
+				for _, id := range ids {
+					r.Outbox.MarkPublished(ctx, id, time.Now())
+				}
+			})
+
+			got, err := r.Outbox.ListPending(ctx, 2)
+			if err != nil {
+				t.Fatalf("list pending: %v", err)
+			}
+			if len(got) != 2 || got[0].ID != ids[0] || got[1].ID != ids[1] {
+				t.Fatalf("list pending: got %+v, want the two oldest events", got)
+			}
+
+			var payload map[string]string
+			if err = json.Unmarshal([]byte(got[0].Payload), &payload); err != nil || payload["status"] != "draft" {
+				t.Errorf("list pending: got payload %q, want the added one", got[0].Payload)
+			}
+
+			if err = r.Outbox.MarkPublished(ctx, ids[0], time.Now()); err != nil {
+				t.Fatalf("mark published: %v", err)
+			}
+			if err = r.Outbox.MarkFailed(ctx, ids[1], "unavailable"); err != nil {
+				t.Fatalf("mark failed: %v", err)
+			}
+
+			// the published event is gone, the failed one stays pending with its attempt counted
+			got, err = r.Outbox.ListPending(ctx, 2)
+			if err != nil {
+				t.Fatalf("list pending: %v", err)
+			}
+			if len(got) != 2 || got[0].ID != ids[1] || got[1].ID != ids[2] {
+				t.Fatalf("list pending: got %+v, want the failed and the last event", got)
+			}
+			if got[0].Attempts != 1 || got[0].LastError != "unavailable" {
+				t.Errorf("list pending: got %d attempts with %q, want 1 with %q", got[0].Attempts, got[0].LastError, "unavailable")
+			}
+
+			if err = r.Outbox.MarkPublished(ctx, uuid.NewString(), time.Now()); !errors.Is(err, market.ErrorNotFound) {
+				t.Errorf("mark published missing: got %v, want %v", err, market.ErrorNotFound)
+			}
+		})
+	}
+}
+
+func customerConformance(r *repository.Repository) conformance[customer.Entity] {
+	return conformance[customer.Entity]{
+		repository: r.Customer,
+		fixture: func(t *testing.T, tag string) customer.Entity {
+			return customer.Entity{
+				FullName:  pointer("Ann Lee"),
+				Pseudonym: pointer(tag),
+			}
+		},
+		column: "pseudonym",
+		patch: func(data customer.Entity) (update, want customer.Entity) {
+			update = customer.Entity{FullName: pointer("Ann Smith")}
+			want = data
+			want.FullName = update.FullName
+			return
+		},
+		idOf: func(data customer.Entity) string {
+			return data.ID
+		},
+		withID: func(data customer.Entity, id string) customer.Entity {
+			data.ID = id
+			return data
+		},
+		withVersion: func(data customer.Entity, version int) customer.Entity {
+			data.Version = version
+			return data
+		},
+		deletedAt: func(data customer.Entity) *time.Time {
+			return data.DeletedAt
+		},
+		equal: func(a, b customer.Entity) bool {
+			return reflect.DeepEqual(a, b)
+		},
+	}
+}
+
+func runConformance[T any](t *testing.T, s conformance[T]) {
+	ctx := context.Background()
+
+	add := func(t *testing.T, tag string) (id string, data T) {
+		t.Helper()
+
+		data = s.fixture(t, tag)
+		id, err := s.repository.Add(ctx, data)
+		if err != nil {
+			t.Fatalf("add: %v", err)
+		}
+		if id == "" {
+			t.Fatal("add: id is blank")
+		}
+
+		// every backend starts the entity at the first version
+		return id, s.withVersion(s.withID(data, id), 1)
+	}
+
+	// the ids of missing entities are valid uuids, so that postgres doesn't reject them as malformed
+	missing := func() string {
+		return uuid.NewString()
+	}
+
+	tests := []struct {
+		name string
+		run  func(t *testing.T)
+	}{
+		{
+			name: "get returns the added entity",
+			run: func(t *testing.T) {
+				id, want := add(t, uuid.NewString())
+
+				got, err := s.repository.Get(ctx, id)
+				if err != nil {
+					t.Fatalf("get: %v", err)
+				}
+				if !s.equal(got, want) {
+					t.Fatalf("get: got %+v, want %+v", got, want)
+				}
+			},
+		},
+		{
+			name: "get of a missing entity is not found",
+			run: func(t *testing.T) {
+				if _, err := s.repository.Get(ctx, missing()); !errors.Is(err, market.ErrorNotFound) {
+					t.Fatalf("get: got %v, want %v", err, market.ErrorNotFound)
+				}
+			},
+		},
+		{
+			name: "update changes only the given fields",
+			run: func(t *testing.T) {
+				id, data := add(t, uuid.NewString())
+				update, want := s.patch(data)
+				want = s.withVersion(want, 2)
+
+				if err := s.repository.Update(ctx, id, update); err != nil {
+					t.Fatalf("update: %v", err)
+				}
+
+				got, err := s.repository.Get(ctx, id)
+				if err != nil {
+					t.Fatalf("get: %v", err)
+				}
+				if !s.equal(got, want) {
+					t.Fatalf("get: got %+v, want %+v", got, want)
+				}
+			},
+		},
+		{
+			name: "update of a missing entity is not found and adds nothing",
+			run: func(t *testing.T) {
+				id := missing()
+				update, _ := s.patch(s.fixture(t, uuid.NewString()))
+
+				if err := s.repository.Update(ctx, id, update); !errors.Is(err, market.ErrorNotFound) {
+					t.Fatalf("update: got %v, want %v", err, market.ErrorNotFound)
+				}
+				if _, err := s.repository.Get(ctx, id); !errors.Is(err, market.ErrorNotFound) {
+					t.Fatalf("get: got %v, want %v", err, market.ErrorNotFound)
+				}
+			},
+		},
+		{
+			name: "update at the version read increments it and the stale one conflicts",
+			run: func(t *testing.T) {
+				id, data := add(t, uuid.NewString())
+				update, want := s.patch(data)
+				want = s.withVersion(want, 2)
+
+				if err := s.repository.Update(ctx, id, s.withVersion(update, 1)); err != nil {
+					t.Fatalf("update: %v", err)
+				}
+				if err := s.repository.Update(ctx, id, s.withVersion(update, 1)); !errors.Is(err, market.ErrorConflict) {
+					t.Fatalf("update: got %v, want %v", err, market.ErrorConflict)
+				}
+
+				got, err := s.repository.Get(ctx, id)
+				if err != nil {
+					t.Fatalf("get: %v", err)
+				}
+				if !s.equal(got, want) {
+					t.Fatalf("get: got %+v, want %+v", got, want)
+				}
+			},
+		},
+		{
+			name: "update of a missing entity at a version is not found",
+			run: func(t *testing.T) {
+				update, _ := s.patch(s.fixture(t, uuid.NewString()))
+
+				if err := s.repository.Update(ctx, missing(), s.withVersion(update, 1)); !errors.Is(err, market.ErrorNotFound) {
+					t.Fatalf("update: got %v, want %v", err, market.ErrorNotFound)
+				}
+			},
+		},
+		{
+			name: "delete at a stale version conflicts and keeps the entity",
+			run: func(t *testing.T) {
+				id, data := add(t, uuid.NewString())
+				update, _ := s.patch(data)
+
+				if err := s.repository.Update(ctx, id, update); err != nil {
+					t.Fatalf("update: %v", err)
+				}
+				if err := s.repository.Delete(ctx, id, 1); !errors.Is(err, market.ErrorConflict) {
+					t.Fatalf("delete: got %v, want %v", err, market.ErrorConflict)
+				}
+				if _, err := s.repository.Get(ctx, id); err != nil {
+					t.Fatalf("get: %v", err)
+				}
+				if err := s.repository.Delete(ctx, id, 2); err != nil {
+					t.Fatalf("delete: %v", err)
+				}
+			},
+		},
+		{
+			name: "delete removes the entity",
+			run: func(t *testing.T) {
+				id, _ := add(t, uuid.NewString())
+
+				if err := s.repository.Delete(ctx, id, 0); err != nil {
+					t.Fatalf("delete: %v", err)
+				}
+				if _, err := s.repository.Get(ctx, id); !errors.Is(err, market.ErrorNotFound) {
+					t.Fatalf("get: got %v, want %v", err, market.ErrorNotFound)
+				}
+			},
+		},
+		{
+			name: "delete hides the entity until it's restored",
+			run: func(t *testing.T) {
+				tag := uuid.NewString()
+				id, want := add(t, tag)
+
+				if err := s.repository.Delete(ctx, id, 1); err != nil {
+					t.Fatalf("delete: %v", err)
+				}
+				if err := s.repository.Update(ctx, id, want); !errors.Is(err, market.ErrorNotFound) {
+					t.Fatalf("update: got %v, want %v", err, market.ErrorNotFound)
+				}
+
+				deleted, err := s.repository.Get(market.WithDeleted(ctx), id)
+				if err != nil {
+					t.Fatalf("get deleted: %v", err)
+				}
+				if s.deletedAt(deleted) == nil {
+					t.Fatal("get deleted: deleted at is blank")
+				}
+
+				q := market.NewQuery().Where(s.column, tag)
+				if _, total, err := s.repository.List(ctx, q); err != nil || total != 0 {
+					t.Fatalf("list: got total %d and %v, want none", total, err)
+				}
+				if _, total, err := s.repository.List(market.WithDeleted(ctx), q); err != nil || total != 1 {
+					t.Fatalf("list deleted: got total %d and %v, want 1", total, err)
+				}
+
+				if err := s.repository.Restore(ctx, id); err != nil {
+					t.Fatalf("restore: %v", err)
+				}
+				if err := s.repository.Restore(ctx, id); !errors.Is(err, market.ErrorNotFound) {
+					t.Fatalf("restore: got %v, want %v", err, market.ErrorNotFound)
+				}
+
+				got, err := s.repository.Get(ctx, id)
+				if err != nil {
+					t.Fatalf("get: %v", err)
+				}
+				if want = s.withVersion(want, 3); !s.equal(got, want) {
+					t.Fatalf("get: got %+v, want %+v", got, want)
+				}
+			},
+		},
+		{
+			name: "purge removes only the deleted entity for good",
+			run: func(t *testing.T) {
+				id, _ := add(t, uuid.NewString())
+
+				if err := s.repository.Purge(ctx, id); !errors.Is(err, market.ErrorNotFound) {
+					t.Fatalf("purge: got %v, want %v", err, market.ErrorNotFound)
+				}
+				if err := s.repository.Delete(ctx, id, 0); err != nil {
+					t.Fatalf("delete: %v", err)
+				}
+
+				deleted, err := s.repository.ListDeleted(ctx, time.Now().Add(time.Minute))
+				if err != nil {
+					t.Fatalf("list deleted: %v", err)
+				}
+				listed := false
+				for _, data := range deleted {
+					listed = listed || s.idOf(data) == id
+				}
+				if !listed {
+					t.Fatalf("list deleted: %s is missing", id)
+				}
+
+				if err := s.repository.Purge(ctx, id); err != nil {
+					t.Fatalf("purge: %v", err)
+				}
+				if _, err := s.repository.Get(market.WithDeleted(ctx), id); !errors.Is(err, market.ErrorNotFound) {
+					t.Fatalf("get: got %v, want %v", err, market.ErrorNotFound)
+				}
+				if err := s.repository.Restore(ctx, id); !errors.Is(err, market.ErrorNotFound) {
+					t.Fatalf("restore: got %v, want %v", err, market.ErrorNotFound)
+				}
+			},
+		},
+		{
+			name: "delete of a missing entity is not found",
+			run: func(t *testing.T) {
+				if err := s.repository.Delete(ctx, missing(), 0); !errors.Is(err, market.ErrorNotFound) {
+					t.Fatalf("delete: got %v, want %v", err, market.ErrorNotFound)
+				}
+			},
+		},
+		{
+			name: "list filters, counts and pages",
+			run: func(t *testing.T) {
+				tag := uuid.NewString()
+				add(t, uuid.NewString())
+
+				want := make(map[string]bool)
+				for i := 0; i < 3; i++ {
+					id, _ := add(t, tag)
+					want[id] = true
+				}
+
+				q := market.NewQuery().Where(s.column, tag)
+				q.Limit = 2
+
+				seen := make(map[string]bool)
+				for _, offset := range []int{0, 2} {
+					q.Offset = offset
+
+					page, total, err := s.repository.List(ctx, q)
+					if err != nil {
+						t.Fatalf("list: %v", err)
+					}
+					if total != len(want) {
+						t.Fatalf("list: got total %d, want %d", total, len(want))
+					}
+					size := len(want) - offset
+					if size > q.Limit {
+						size = q.Limit
+					}
+					if len(page) != size {
+						t.Fatalf("list: got %d entities at offset %d, want %d", len(page), offset, size)
+					}
+
+					for _, data := range page {
+						id := s.idOf(data)
+						if !want[id] || seen[id] {
+							t.Fatalf("list: unexpected entity %s at offset %d", id, offset)
+						}
+						seen[id] = true
+					}
+				}
+			},
+		},
+		{
+			name: "list breaks the ties by id in the order direction",
+			run: func(t *testing.T) {
+				tag := uuid.NewString()
+
+				ids := make([]string, 3)
+				for i := range ids {
+					ids[i], _ = add(t, tag)
+				}
+				sort.Strings(ids)
+
+				for _, order := range []string{market.OrderAsc, market.OrderDesc} {
+					q := market.NewQuery().Where(s.column, tag)
+					q.Sort, q.Order = s.column, order
+
+					page, _, err := s.repository.List(ctx, q)
+					if err != nil {
+						t.Fatalf("list: %v", err)
+					}
+
+					got := make([]string, 0, len(page))
+					for _, data := range page {
+						got = append(got, s.idOf(data))
+					}
+
+					want := append([]string(nil), ids...)
+					if order == market.OrderDesc {
+						sort.Sort(sort.Reverse(sort.StringSlice(want)))
+					}
+					if !reflect.DeepEqual(got, want) {
+						t.Errorf("list %s: got %v, want %v", order, got, want)
+					}
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, tt.run)
+	}
+}
+
+func TestUnitOfWork(t *testing.T) {
+	ctx := context.Background()
+	failure := errors.New("failure")
+
+	for name, r := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			if r.UnitOfWork == nil {
+				t.Skip("the store runs without transactions")
+			}
+
+			// run adds a customer in a unit of work, alone or nested in another one, and fails after the add when asked
+			run := func(fail, nested bool) (id string, err error) {
+				work := func(ctx context.Context) (err error) {
+					if id, err = r.Customer.Add(ctx, customer.Entity{FullName: pointer("Ann Lee"), Pseudonym: pointer(uuid.NewString())}); err != nil {
+						return
+					}
+					if fail {
+						return failure
+					}
+					return
+				}
+
+				err = r.UnitOfWork.Do(ctx, func(ctx context.Context) error {
+					if nested {
+						return r.UnitOfWork.Do(ctx, work)
+					}
+					return work(ctx)
+				})
+
+				return
+			}
+
+			for _, nested := range []bool{false, true} {
+				id, err := run(false, nested)
+				if err != nil {
+					t.Fatalf("commit: %v", err)
+				}
+				if _, err = r.Customer.Get(ctx, id); err != nil {
+					t.Errorf("commit: get: %v", err)
+				}
+
+				id, err = run(true, nested)
+				if !errors.Is(err, failure) {
+					t.Fatalf("rollback: got %v, want %v", err, failure)
+				}
+
+				// the memory store has no rollback and keeps the changes made before the failure
+				_, err = r.Customer.Get(ctx, id)
+				if name == "memory" {
+					if err != nil {
+						t.Errorf("rollback: get: %v, want the change kept", err)
+					}
+				} else if !errors.Is(err, market.ErrorNotFound) {
+					t.Errorf("rollback: get: got %v, want %v", err, market.ErrorNotFound)
+				}
+			}
+		})
+	}
+}
+
+func sorted(values ...string) []string {
+	sort.Strings(values)
+	return values
+}
+
+func pointer[T any](value T) *T {
+	return &value
+}
@@ -1,27 +1,68 @@
 package auth
 
 import (
+	"context"
 	"errors"
+	"exchanger/internal/domain/client"
+	"exchanger/internal/domain/user"
+	"exchanger/pkg/log"
+	"exchanger/pkg/market"
 	"github.com/go-chi/oauth"
+	"go.uber.org/zap"
 	"net/http"
 )
 
-// ValidateUser checks validation of username and password, it returns error if credentials are wrong
-func (*Service) ValidateUser(username, password, scope string, r *http.Request) error {
-	if username == "user01" && password == "12345" {
-		return nil
+// ValidateUser checks validation of username and password, it returns error if credentials are wrong.
+// The password is compared for the missing user as well and the missing or inactive user is
+// ErrorInvalidCredentials, so that neither the timing nor the error tells which usernames exist
+func (s *Service) ValidateUser(username, password, scope string, r *http.Request) error {
+	data, err := s.getUser(r.Context(), username)
+	if err != nil {
+		comparePassword(dummyHash, password)
+		return ErrorInvalidCredentials
 	}
 
-	return errors.New("wrong user")
+	if !comparePassword(*data.PasswordHash, password) || !*data.IsActive {
+		return ErrorInvalidCredentials
+	}
+
+	return nil
 }
 
-// ValidateClient checks validations of clientID and secret if credentials are wrong then returns error
-func (*Service) ValidateClient(clientID, clientSecret, scope string, r *http.Request) error {
-	if clientID == "abcdef" && clientSecret == "12345" {
-		return nil
+// ValidateClient checks validations of clientID and secret if credentials are wrong then returns error.
+// The secret is compared for the missing client as well and the missing, inactive or wrong client is
+// ErrorInvalidCredentials, so that neither the timing nor the error tells which client ids exist
+func (s *Service) ValidateClient(clientID, clientSecret, scope string, r *http.Request) error {
+	ctx := r.Context()
+
+	data, err := s.clientRepository.Get(ctx, clientID)
+	if err != nil {
+		comparePassword(dummyHash, clientSecret)
+		if !errors.Is(err, market.ErrorNotFound) {
+			log.LoggerFromContext(ctx).Named("ValidateClient").Error("failed to get client", zap.Error(err))
+			return err
+		}
+		return ErrorInvalidCredentials
 	}
 
-	return errors.New("wrong client")
+	if !comparePassword(*data.SecretHash, clientSecret) || !*data.IsActive {
+		return ErrorInvalidCredentials
+	}
+
+	owner, err := s.userRepository.Get(ctx, data.UserID)
+	if err != nil {
+		if !errors.Is(err, market.ErrorNotFound) {
+			log.LoggerFromContext(ctx).Named("ValidateClient").Error("failed to get owner", zap.Error(err))
+			return err
+		}
+		return ErrorInvalidCredentials
+	}
+
+	if !*owner.IsActive {
+		return ErrorInvalidCredentials
+	}
+
+	return nil
 }
 
 // ValidateCode Validates token ID
@@ -30,26 +71,80 @@ func (*Service) ValidateCode(clientID, clientSecret, code, redirectURI string, r
 }
 
 // AddClaims provides additional claims to the token
-func (*Service) AddClaims(tokenType oauth.TokenType, credential, tokenID, scope string, r *http.Request) (map[string]string, error) {
+func (s *Service) AddClaims(tokenType oauth.TokenType, credential, tokenID, scope string, r *http.Request) (map[string]string, error) {
 	claims := make(map[string]string)
-	claims["id"] = "1001"
-	claims["data"] = `{"order_date":"2016-12-14","order_id":"9999"}`
+	claims["token_id"] = tokenID
+
+	switch tokenType {
+	case oauth.UserToken:
+		data, err := s.getUser(r.Context(), credential)
+		if err != nil {
+			return nil, err
+		}
+		claims["id"] = data.ID
+		claims["user_id"] = data.ID
+		claims["role"] = *data.Role
+	case oauth.ClientToken:
+		data, err := s.getClient(r.Context(), credential)
+		if err != nil {
+			return nil, err
+		}
+		owner, err := s.userRepository.Get(r.Context(), data.UserID)
+		if err != nil {
+			return nil, err
+		}
+		claims["id"] = data.ID
+		claims["user_id"] = data.UserID
+		claims["role"] = *owner.Role
+	}
+
 	return claims, nil
 }
 
 // AddProperties provides additional information to the token response
-func (*Service) AddProperties(tokenType oauth.TokenType, credential, tokenID, scope string, r *http.Request) (map[string]string, error) {
+func (s *Service) AddProperties(tokenType oauth.TokenType, credential, tokenID, scope string, r *http.Request) (map[string]string, error) {
 	props := make(map[string]string)
-	props["name"] = "Gopher"
+
+	switch tokenType {
+	case oauth.UserToken:
+		props["name"] = credential
+	case oauth.ClientToken:
+		data, err := s.getClient(r.Context(), credential)
+		if err != nil {
+			return nil, err
+		}
+		props["name"] = *data.Name
+	}
+
 	return props, nil
 }
 
-// ValidateTokenID validates token ID
-func (*Service) ValidateTokenID(tokenType oauth.TokenType, credential, tokenID, refreshTokenID string) error {
-	return nil
+// getUser returns the user by username hiding the reason of a missing account
+func (s *Service) getUser(ctx context.Context, username string) (data user.Entity, err error) {
+	logger := log.LoggerFromContext(ctx).Named("getUser").With(zap.String("username", username))
+
+	data, err = s.userRepository.GetByUsername(ctx, username)
+	if err != nil {
+		if !errors.Is(err, market.ErrorNotFound) {
+			logger.Error("failed to get", zap.Error(err))
+		}
+		return data, ErrorInvalidCredentials
+	}
+
+	return
 }
 
-// StoreTokenID saves user's client id
-func (*Service) StoreTokenID(tokenType oauth.TokenType, credential, tokenID, refreshTokenID string) error {
-	return nil
+// getClient returns the client by id hiding the reason of a missing account
+func (s *Service) getClient(ctx context.Context, id string) (data client.Entity, err error) {
+	logger := log.LoggerFromContext(ctx).Named("getClient").With(zap.String("id", id))
+
+	data, err = s.clientRepository.Get(ctx, id)
+	if err != nil {
+		if !errors.Is(err, market.ErrorNotFound) {
+			logger.Error("failed to get", zap.Error(err))
+		}
+		return data, ErrorInvalidCredentials
+	}
+
+	return
 }
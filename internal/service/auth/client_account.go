@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"context"
+	"exchanger/internal/domain/client"
+	"exchanger/pkg/log"
+	"exchanger/pkg/market"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+func (s *Service) AddClient(ctx context.Context, credential string, req client.Request) (res client.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("AddClient")
+
+	owner, err := s.userRepository.GetByUsername(ctx, credential)
+	if err != nil {
+		if errors.Is(err, market.ErrorNotFound) {
+			return res, market.ErrorForbidden
+		}
+		logger.Error("failed to get owner", zap.Error(err))
+		return
+	}
+
+	secret, err := generateSecret()
+	if err != nil {
+		logger.Error("failed to generate secret", zap.Error(err))
+		return
+	}
+
+	secretHash, err := hashPassword(secret)
+	if err != nil {
+		logger.Error("failed to hash secret", zap.Error(err))
+		return
+	}
+	isActive := true
+
+	data := client.Entity{
+		Name:       &req.Name,
+		SecretHash: &secretHash,
+		UserID:     owner.ID,
+		IsActive:   &isActive,
+	}
+
+	data.ID, err = s.clientRepository.Add(ctx, data)
+	if err != nil {
+		logger.Error("failed to add", zap.Error(err))
+		return
+	}
+	res = client.ParseFromEntity(data)
+	res.Secret = secret
+
+	return
+}
+
+func (s *Service) DeactivateClient(ctx context.Context, id, credential string) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("DeactivateClient").With(zap.String("id", id))
+
+	data, err := s.clientRepository.Get(ctx, id)
+	if err != nil {
+		if !errors.Is(err, market.ErrorNotFound) {
+			logger.Error("failed to get", zap.Error(err))
+		}
+		return
+	}
+
+	if _, err = s.getOwnUser(ctx, data.UserID, credential); err != nil {
+		if errors.Is(err, market.ErrorNotFound) {
+			err = market.ErrorForbidden
+		}
+		return
+	}
+	isActive := false
+
+	err = s.clientRepository.Update(ctx, id, client.Entity{IsActive: &isActive})
+	if err != nil {
+		if !errors.Is(err, market.ErrorNotFound) {
+			logger.Error("failed to update by id", zap.Error(err))
+		}
+		return
+	}
+
+	if _, err = s.tokenRepository.RevokeByCredential(ctx, id); err != nil {
+		logger.Error("failed to revoke tokens", zap.Error(err))
+		return
+	}
+
+	return
+}
@@ -0,0 +1,83 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"exchanger/internal/domain/client"
+	"exchanger/internal/domain/user"
+	"exchanger/internal/service/auth"
+	"net/http/httptest"
+	"testing"
+)
+
+// failingClients fails every read of the clients
+type failingClients struct {
+	client.Repository
+}
+
+func (failingClients) Get(ctx context.Context, id string) (client.Entity, error) {
+	return client.Entity{}, errors.New("unavailable")
+}
+
+func TestValidateClient(t *testing.T) {
+	ctx := context.Background()
+	r := newRepositories(t)
+	s := newAuthService(t, r)
+
+	// active and inactive clients of an active owner and a client of a deactivated owner
+	clients := make(map[string]client.Response)
+	for _, username := range []string{"ann", "bob"} {
+		if _, err := s.Register(ctx, user.Request{Username: username, Password: "password", Role: user.RoleCustomer}); err != nil {
+			t.Fatalf("Register() error = %v", err)
+		}
+	}
+	for name, owner := range map[string]string{"active": "ann", "inactive": "ann", "orphan": "bob"} {
+		res, err := s.AddClient(ctx, owner, client.Request{Name: name})
+		if err != nil {
+			t.Fatalf("AddClient() error = %v", err)
+		}
+		clients[name] = res
+	}
+	if err := s.DeactivateClient(ctx, clients["inactive"].ID, "ann"); err != nil {
+		t.Fatalf("DeactivateClient() error = %v", err)
+	}
+	bob, _ := r.User.GetByUsername(ctx, "bob")
+	if err := s.DeactivateUser(ctx, bob.ID, "bob"); err != nil {
+		t.Fatalf("DeactivateUser() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		id      string
+		secret  string
+		wantErr error
+	}{
+		{name: "valid", id: clients["active"].ID, secret: clients["active"].Secret},
+		{name: "wrong secret", id: clients["active"].ID, secret: "secret", wantErr: auth.ErrorInvalidCredentials},
+		{name: "unknown client", id: "unknown", secret: "secret", wantErr: auth.ErrorInvalidCredentials},
+		{name: "inactive client", id: clients["inactive"].ID, secret: clients["inactive"].Secret, wantErr: auth.ErrorInvalidCredentials},
+		{name: "inactive client with wrong secret", id: clients["inactive"].ID, secret: "secret", wantErr: auth.ErrorInvalidCredentials},
+		{name: "deactivated owner", id: clients["orphan"].ID, secret: clients["orphan"].Secret, wantErr: auth.ErrorInvalidCredentials},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.ValidateClient(tt.id, tt.secret, "", httptest.NewRequest("POST", "/token", nil))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ValidateClient() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("repository failure isn't a wrong credential", func(t *testing.T) {
+		failing, err := auth.New(auth.WithUserRepository(r.User), auth.WithClientRepository(failingClients{}))
+		if err != nil {
+			t.Fatalf("auth.New() error = %v", err)
+		}
+
+		err = failing.ValidateClient("id", "secret", "", httptest.NewRequest("POST", "/token", nil))
+		if err == nil || errors.Is(err, auth.ErrorInvalidCredentials) {
+			t.Errorf("ValidateClient() error = %v, want the repository error", err)
+		}
+	})
+}
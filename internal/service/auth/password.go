@@ -0,0 +1,35 @@
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// dummyHash is compared with the password of a missing user, so that it takes as long as the existing one
+var dummyHash, _ = hashPassword("dummy password")
+
+// hashPassword returns a bcrypt hash of the password, the salt is generated and embedded by bcrypt
+func hashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+
+	return string(hash), nil
+}
+
+// comparePassword reports whether the password matches the bcrypt hash
+func comparePassword(hash, password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
+
+// generateSecret returns a random hex encoded client secret
+func generateSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(buf), nil
+}
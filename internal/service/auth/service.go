@@ -1,16 +1,41 @@
 package auth
 
+import (
+	"errors"
+	"exchanger/internal/domain/client"
+	"exchanger/internal/domain/token"
+	"exchanger/internal/domain/user"
+	"fmt"
+	"github.com/go-chi/oauth"
+	"strings"
+)
+
+var (
+	ErrorInvalidCredentials = errors.New("invalid credentials")
+	ErrorTokenRevoked       = errors.New("token is revoked")
+	ErrorInvalidToken       = errors.New("invalid token")
+	ErrorTokenExpired       = errors.New("token expired")
+)
+
 // Configuration is an alias for a function that will take in a pointer to a Service and modify it
 type Configuration func(s *Service) error
 
 // Service is an implementation of the Service
-type Service struct{}
+type Service struct {
+	userRepository   user.Repository
+	clientRepository client.Repository
+	tokenRepository  token.Repository
+	admins           map[string]string
+	tokenProvider    *oauth.TokenProvider
+}
 
 // New takes a variable amount of Configuration functions and returns a new Service
 // Each Configuration will be called in the order they are passed in
 func New(configs ...Configuration) (s *Service, err error) {
 	// Add the service
-	s = &Service{}
+	s = &Service{
+		admins: make(map[string]string),
+	}
 
 	// Apply all Configurations passed in
 	for _, cfg := range configs {
@@ -21,3 +46,51 @@ func New(configs ...Configuration) (s *Service, err error) {
 	}
 	return
 }
+
+// WithUserRepository applies a given user repository to the Service
+func WithUserRepository(userRepository user.Repository) Configuration {
+	return func(s *Service) error {
+		s.userRepository = userRepository
+		return nil
+	}
+}
+
+// WithClientRepository applies a given client repository to the Service
+func WithClientRepository(clientRepository client.Repository) Configuration {
+	return func(s *Service) error {
+		s.clientRepository = clientRepository
+		return nil
+	}
+}
+
+// WithTokenRepository applies a given token repository to the Service
+func WithTokenRepository(tokenRepository token.Repository) Configuration {
+	return func(s *Service) error {
+		s.tokenRepository = tokenRepository
+		return nil
+	}
+}
+
+// WithAdmins applies the admin accounts SeedAdmins creates to the Service, each one is given as "username:password".
+// The usernames of the admins can't be registered by anyone
+func WithAdmins(credentials ...string) Configuration {
+	return func(s *Service) error {
+		for _, credential := range credentials {
+			username, password, _ := strings.Cut(credential, ":")
+			if username == "" || len(password) < user.MinPasswordLength {
+				return fmt.Errorf("admin %q: must be a username and a password of at least %d characters", username, user.MinPasswordLength)
+			}
+			s.admins[username] = password
+		}
+		return nil
+	}
+}
+
+// WithTokenSalt applies the salt the bearer tokens are encrypted with to the Service,
+// it must be the same salt the bearer server issues the tokens with
+func WithTokenSalt(salt string) Configuration {
+	return func(s *Service) error {
+		s.tokenProvider = oauth.NewTokenProvider(oauth.NewSHA256RC4TokenSecurityProvider([]byte(salt)))
+		return nil
+	}
+}
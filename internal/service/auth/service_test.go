@@ -0,0 +1,36 @@
+package auth_test
+
+import (
+	"exchanger/internal/repository"
+	"exchanger/internal/service/auth"
+	"testing"
+)
+
+// newRepositories returns the repositories of a fresh memory store
+func newRepositories(t *testing.T) *repository.Repository {
+	t.Helper()
+
+	r, err := repository.New(repository.WithMemoryStore())
+	if err != nil {
+		t.Fatalf("repository.New() error = %v", err)
+	}
+
+	return r
+}
+
+// newAuthService returns the service over the repositories, the admins are given as "username:password"
+func newAuthService(t *testing.T, r *repository.Repository, admins ...string) *auth.Service {
+	t.Helper()
+
+	s, err := auth.New(
+		auth.WithUserRepository(r.User),
+		auth.WithClientRepository(r.Client),
+		auth.WithTokenRepository(r.Token),
+		auth.WithAdmins(admins...),
+		auth.WithTokenSalt("salt"))
+	if err != nil {
+		t.Fatalf("auth.New() error = %v", err)
+	}
+
+	return s
+}
@@ -0,0 +1,163 @@
+package auth
+
+import (
+	"context"
+	"exchanger/internal/domain/token"
+	"exchanger/internal/domain/user"
+	"exchanger/pkg/log"
+	"exchanger/pkg/market"
+	"github.com/go-chi/oauth"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"strings"
+	"time"
+)
+
+// timeout bounds the token store calls made by the bearer server, it gives no context of its own
+const timeout = 10 * time.Second
+
+// ValidateTokenID validates the refresh token pair and rotates it, so the same refresh token
+// can't be used twice. A replayed refresh token revokes every token of the credential.
+func (s *Service) ValidateTokenID(tokenType oauth.TokenType, credential, tokenID, refreshTokenID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	logger := log.LoggerFromContext(ctx).Named("ValidateTokenID").With(zap.String("credential", credential), zap.String("token_id", tokenID))
+
+	err := s.tokenRepository.Rotate(ctx, tokenID, refreshTokenID)
+	if err == nil {
+		return nil
+	}
+
+	if !errors.Is(err, market.ErrorNotFound) {
+		logger.Error("failed to rotate", zap.Error(err))
+		return err
+	}
+
+	data, err := s.tokenRepository.Get(ctx, tokenID)
+	if err == nil && data.Credential == credential && data.RevokedAt == nil {
+		logger.Warn("refresh token reuse detected, revoking all tokens")
+
+		if _, err = s.tokenRepository.RevokeByCredential(ctx, credential); err != nil {
+			logger.Error("failed to revoke by credential", zap.Error(err))
+		}
+	}
+
+	return ErrorTokenRevoked
+}
+
+// StoreTokenID saves the issued token pair of the credential
+func (s *Service) StoreTokenID(tokenType oauth.TokenType, credential, tokenID, refreshTokenID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	logger := log.LoggerFromContext(ctx).Named("StoreTokenID").With(zap.String("credential", credential), zap.String("token_id", tokenID))
+
+	data := token.Entity{
+		ID:             tokenID,
+		RefreshTokenID: refreshTokenID,
+		Credential:     credential,
+		TokenType:      string(tokenType),
+		CreatedAt:      time.Now(),
+	}
+
+	if err := s.tokenRepository.Add(ctx, data); err != nil {
+		logger.Error("failed to add", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// CheckToken returns an error when the access token is unknown or revoked
+func (s *Service) CheckToken(ctx context.Context, tokenID string) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("CheckToken").With(zap.String("token_id", tokenID))
+
+	data, err := s.tokenRepository.Get(ctx, tokenID)
+	if err != nil {
+		if !errors.Is(err, market.ErrorNotFound) {
+			logger.Error("failed to get", zap.Error(err))
+			return
+		}
+		return ErrorTokenRevoked
+	}
+
+	if data.RevokedAt != nil {
+		return ErrorTokenRevoked
+	}
+
+	return
+}
+
+// Authenticate decrypts the bearer token of the authorization header and returns it with the actor it's issued to,
+// the token must be neither expired nor revoked. The actor has the current role of the user the token is issued to.
+// HTTP and gRPC requests are authenticated the same way
+func (s *Service) Authenticate(ctx context.Context, header string) (data *oauth.Token, actor user.Actor, err error) {
+	if s.tokenProvider == nil || len(header) < 7 || !strings.EqualFold(header[:7], "bearer ") {
+		err = ErrorInvalidToken
+		return
+	}
+
+	data, err = s.tokenProvider.DecryptToken(header[7:])
+	if err != nil {
+		data, err = nil, ErrorInvalidToken
+		return
+	}
+
+	if time.Now().UTC().After(data.CreationDate.Add(data.ExpiresIn)) {
+		err = ErrorTokenExpired
+		return
+	}
+
+	if err = s.CheckToken(ctx, data.Claims["token_id"]); err != nil {
+		return
+	}
+
+	// the role is read from the user rather than the claims, so that the changed role applies to the issued tokens
+	owner, err := s.userRepository.Get(ctx, data.Claims["user_id"])
+	if err != nil {
+		if !errors.Is(err, market.ErrorNotFound) {
+			log.LoggerFromContext(ctx).Named("Authenticate").Error("failed to get user", zap.Error(err))
+			return
+		}
+		err = ErrorTokenRevoked
+		return
+	}
+
+	actor = user.Actor{
+		ID:         data.Claims["user_id"],
+		Credential: data.Credential,
+		Role:       *owner.Role,
+	}
+
+	return
+}
+
+func (s *Service) Logout(ctx context.Context, tokenID string) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("Logout").With(zap.String("token_id", tokenID))
+
+	// the unknown token has nothing to revoke
+	err = s.tokenRepository.Revoke(ctx, tokenID)
+	if err != nil {
+		if !errors.Is(err, market.ErrorNotFound) {
+			logger.Error("failed to revoke", zap.Error(err))
+			return
+		}
+		err = nil
+	}
+
+	return
+}
+
+func (s *Service) RevokeTokens(ctx context.Context, credential string) (res token.RevokeResponse, err error) {
+	logger := log.LoggerFromContext(ctx).Named("RevokeTokens").With(zap.String("credential", credential))
+
+	res.Credential = credential
+	res.Revoked, err = s.tokenRepository.RevokeByCredential(ctx, credential)
+	if err != nil {
+		logger.Error("failed to revoke by credential", zap.Error(err))
+		return
+	}
+
+	return
+}
@@ -0,0 +1,124 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"exchanger/internal/service/auth"
+	"testing"
+
+	"github.com/go-chi/oauth"
+)
+
+// storeTokens stores the token pairs of the credential, each pair is the token id and its refresh token id
+func storeTokens(t *testing.T, s *auth.Service, credential string, pairs ...[2]string) {
+	t.Helper()
+
+	for _, pair := range pairs {
+		if err := s.StoreTokenID(oauth.UserToken, credential, pair[0], pair[1]); err != nil {
+			t.Fatalf("StoreTokenID() error = %v", err)
+		}
+	}
+}
+
+// checkTokens checks that each token is valid or revoked as wanted
+func checkTokens(t *testing.T, s *auth.Service, want map[string]error) {
+	t.Helper()
+
+	for id, wantErr := range want {
+		if err := s.CheckToken(context.Background(), id); !errors.Is(err, wantErr) {
+			t.Errorf("CheckToken(%s) error = %v, want %v", id, err, wantErr)
+		}
+	}
+}
+
+func TestRefreshRotation(t *testing.T) {
+	s := newAuthService(t, newRepositories(t))
+	storeTokens(t, s, "ann", [2]string{"first", "first-refresh"})
+
+	if err := s.ValidateTokenID(oauth.UserToken, "ann", "first", "first-refresh"); err != nil {
+		t.Fatalf("ValidateTokenID() error = %v", err)
+	}
+	// the bearer server stores the pair it issues for the rotated one
+	storeTokens(t, s, "ann", [2]string{"second", "second-refresh"})
+
+	if err := s.ValidateTokenID(oauth.UserToken, "ann", "second", "second-refresh"); err != nil {
+		t.Fatalf("ValidateTokenID() of the new pair error = %v", err)
+	}
+	checkTokens(t, s, map[string]error{"first": nil, "second": nil})
+}
+
+func TestRefreshReuseRevokesFamily(t *testing.T) {
+	s := newAuthService(t, newRepositories(t))
+	storeTokens(t, s, "ann", [2]string{"first", "first-refresh"}, [2]string{"device", "device-refresh"})
+	storeTokens(t, s, "bob", [2]string{"other", "other-refresh"})
+
+	if err := s.ValidateTokenID(oauth.UserToken, "ann", "first", "first-refresh"); err != nil {
+		t.Fatalf("ValidateTokenID() error = %v", err)
+	}
+	storeTokens(t, s, "ann", [2]string{"second", "second-refresh"})
+
+	// the rotated refresh token is replayed, every token of the credential goes with it
+	if err := s.ValidateTokenID(oauth.UserToken, "ann", "first", "first-refresh"); !errors.Is(err, auth.ErrorTokenRevoked) {
+		t.Fatalf("ValidateTokenID() of the reused token error = %v, want %v", err, auth.ErrorTokenRevoked)
+	}
+	checkTokens(t, s, map[string]error{
+		"first":  auth.ErrorTokenRevoked,
+		"second": auth.ErrorTokenRevoked,
+		"device": auth.ErrorTokenRevoked,
+		"other":  nil,
+	})
+
+	// the pair issued after the reuse can't be refreshed either
+	if err := s.ValidateTokenID(oauth.UserToken, "ann", "second", "second-refresh"); !errors.Is(err, auth.ErrorTokenRevoked) {
+		t.Errorf("ValidateTokenID() of the revoked pair error = %v, want %v", err, auth.ErrorTokenRevoked)
+	}
+}
+
+func TestRefreshRejected(t *testing.T) {
+	tests := []struct {
+		name                    string
+		credential, id, refresh string
+	}{
+		{name: "unknown token", credential: "ann", id: "unknown", refresh: "unknown-refresh"},
+		{name: "token of another credential", credential: "bob", id: "first", refresh: "first-refresh"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newAuthService(t, newRepositories(t))
+			storeTokens(t, s, "ann", [2]string{"first", "first-refresh"})
+			storeTokens(t, s, "bob", [2]string{"other", "other-refresh"})
+
+			if err := s.ValidateTokenID(oauth.UserToken, tt.credential, tt.id, tt.refresh+"-forged"); !errors.Is(err, auth.ErrorTokenRevoked) {
+				t.Fatalf("ValidateTokenID() error = %v, want %v", err, auth.ErrorTokenRevoked)
+			}
+
+			// the token that isn't the credential's own revokes nothing
+			checkTokens(t, s, map[string]error{"other": nil})
+		})
+	}
+}
+
+func TestLogout(t *testing.T) {
+	s := newAuthService(t, newRepositories(t))
+	storeTokens(t, s, "ann", [2]string{"first", "first-refresh"}, [2]string{"device", "device-refresh"})
+	ctx := context.Background()
+
+	if err := s.Logout(ctx, "first"); err != nil {
+		t.Fatalf("Logout() error = %v", err)
+	}
+	checkTokens(t, s, map[string]error{"first": auth.ErrorTokenRevoked, "device": nil})
+
+	// the refresh token of the logged out pair is refused without revoking the other devices
+	if err := s.ValidateTokenID(oauth.UserToken, "ann", "first", "first-refresh"); !errors.Is(err, auth.ErrorTokenRevoked) {
+		t.Fatalf("ValidateTokenID() after logout error = %v, want %v", err, auth.ErrorTokenRevoked)
+	}
+	checkTokens(t, s, map[string]error{"device": nil})
+
+	// logging out twice or with an unknown token isn't an error
+	for _, id := range []string{"first", "unknown"} {
+		if err := s.Logout(ctx, id); err != nil {
+			t.Errorf("Logout(%s) error = %v", id, err)
+		}
+	}
+}
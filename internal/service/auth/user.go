@@ -0,0 +1,168 @@
+package auth
+
+import (
+	"context"
+	"exchanger/internal/domain/user"
+	"exchanger/pkg/log"
+	"exchanger/pkg/market"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+func (s *Service) Register(ctx context.Context, req user.Request) (res user.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("Register").With(zap.String("username", req.Username))
+
+	// the admins are seeded, their usernames are taken even before the accounts are created
+	if _, ok := s.admins[req.Username]; ok {
+		err = market.ErrorAlreadyExists
+		return
+	}
+
+	passwordHash, err := hashPassword(req.Password)
+	if err != nil {
+		logger.Error("failed to hash password", zap.Error(err))
+		return
+	}
+	isActive := true
+
+	data := user.Entity{
+		Username:     &req.Username,
+		PasswordHash: &passwordHash,
+		Role:         &req.Role,
+		IsActive:     &isActive,
+	}
+
+	data.ID, err = s.userRepository.Add(ctx, data)
+	if err != nil {
+		if !errors.Is(err, market.ErrorAlreadyExists) {
+			logger.Error("failed to add", zap.Error(err))
+		}
+		return
+	}
+	res = user.ParseFromEntity(data)
+
+	return
+}
+
+// SeedAdmins creates the configured admin accounts missing in the repository, the user who has taken
+// the username of an admin before is left as it is and only logged
+func (s *Service) SeedAdmins(ctx context.Context) (err error) {
+	for username, password := range s.admins {
+		logger := log.LoggerFromContext(ctx).Named("SeedAdmins").With(zap.String("username", username))
+
+		data, err := s.userRepository.GetByUsername(ctx, username)
+		if err == nil {
+			if *data.Role != user.RoleAdmin {
+				logger.Warn("username of the admin is taken by another user")
+			}
+			continue
+		}
+		if !errors.Is(err, market.ErrorNotFound) {
+			logger.Error("failed to get", zap.Error(err))
+			return err
+		}
+
+		passwordHash, err := hashPassword(password)
+		if err != nil {
+			logger.Error("failed to hash password", zap.Error(err))
+			return err
+		}
+		role, isActive := user.RoleAdmin, true
+
+		data = user.Entity{
+			Username:     &username,
+			PasswordHash: &passwordHash,
+			Role:         &role,
+			IsActive:     &isActive,
+		}
+		if _, err = s.userRepository.Add(ctx, data); err != nil && !errors.Is(err, market.ErrorAlreadyExists) {
+			logger.Error("failed to add", zap.Error(err))
+			return err
+		}
+	}
+
+	return
+}
+
+func (s *Service) ChangePassword(ctx context.Context, id, credential string, req user.PasswordRequest) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("ChangePassword").With(zap.String("id", id))
+
+	data, err := s.getOwnUser(ctx, id, credential)
+	if err != nil {
+		return
+	}
+
+	if !comparePassword(*data.PasswordHash, req.OldPassword) {
+		return ErrorInvalidCredentials
+	}
+
+	passwordHash, err := hashPassword(req.NewPassword)
+	if err != nil {
+		logger.Error("failed to hash password", zap.Error(err))
+		return
+	}
+
+	err = s.userRepository.Update(ctx, id, user.Entity{PasswordHash: &passwordHash})
+	if err != nil && !errors.Is(err, market.ErrorNotFound) {
+		logger.Error("failed to update by id", zap.Error(err))
+		return
+	}
+
+	return
+}
+
+func (s *Service) DeactivateUser(ctx context.Context, id, credential string) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("DeactivateUser").With(zap.String("id", id))
+
+	data, err := s.getOwnUser(ctx, id, credential)
+	if err != nil {
+		return
+	}
+	isActive := false
+
+	err = s.userRepository.Update(ctx, id, user.Entity{IsActive: &isActive})
+	if err != nil {
+		if !errors.Is(err, market.ErrorNotFound) {
+			logger.Error("failed to update by id", zap.Error(err))
+		}
+		return
+	}
+
+	if _, err = s.tokenRepository.RevokeByCredential(ctx, *data.Username); err != nil {
+		logger.Error("failed to revoke tokens", zap.Error(err))
+		return
+	}
+
+	return
+}
+
+func (s *Service) SetRole(ctx context.Context, id string, req user.RoleRequest) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("SetRole").With(zap.String("id", id))
+
+	err = s.userRepository.Update(ctx, id, user.Entity{Role: &req.Role})
+	if err != nil && !errors.Is(err, market.ErrorNotFound) {
+		logger.Error("failed to update by id", zap.Error(err))
+		return
+	}
+
+	return
+}
+
+// getOwnUser returns the user only when it belongs to the token credential
+func (s *Service) getOwnUser(ctx context.Context, id, credential string) (data user.Entity, err error) {
+	logger := log.LoggerFromContext(ctx).Named("getOwnUser").With(zap.String("id", id))
+
+	data, err = s.userRepository.Get(ctx, id)
+	if err != nil {
+		if !errors.Is(err, market.ErrorNotFound) {
+			logger.Error("failed to get", zap.Error(err))
+		}
+		return
+	}
+
+	if *data.Username != credential {
+		return data, market.ErrorForbidden
+	}
+
+	return
+}
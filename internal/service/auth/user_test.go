@@ -0,0 +1,94 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"exchanger/internal/domain/user"
+	"exchanger/internal/service/auth"
+	"exchanger/pkg/market"
+	"testing"
+)
+
+func TestRegisterRefusesAdminUsername(t *testing.T) {
+	ctx := context.Background()
+	r := newRepositories(t)
+	s := newAuthService(t, r, "root:secret123")
+
+	tests := []struct {
+		name     string
+		seeded   bool
+		username string
+		wantErr  error
+	}{
+		{name: "admin before the seeding", username: "root", wantErr: market.ErrorAlreadyExists},
+		{name: "admin after the seeding", seeded: true, username: "root", wantErr: market.ErrorAlreadyExists},
+		{name: "other username", seeded: true, username: "ann"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.seeded {
+				if err := s.SeedAdmins(ctx); err != nil {
+					t.Fatalf("SeedAdmins() error = %v", err)
+				}
+			}
+
+			res, err := s.Register(ctx, user.Request{Username: tt.username, Password: "password", Role: user.RoleCustomer})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Register() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && res.Role != user.RoleCustomer {
+				t.Errorf("role = %s, want %s", res.Role, user.RoleCustomer)
+			}
+		})
+	}
+
+	// the seeded admin keeps the admin role on the user
+	data, err := r.User.GetByUsername(ctx, "root")
+	if err != nil {
+		t.Fatalf("GetByUsername() error = %v", err)
+	}
+	if *data.Role != user.RoleAdmin {
+		t.Errorf("role = %s, want %s", *data.Role, user.RoleAdmin)
+	}
+}
+
+func TestSeedAdmins(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("taken username isn't promoted", func(t *testing.T) {
+		r := newRepositories(t)
+		if _, err := newAuthService(t, r).Register(ctx, user.Request{Username: "root", Password: "password", Role: user.RoleCustomer}); err != nil {
+			t.Fatalf("Register() error = %v", err)
+		}
+
+		if err := newAuthService(t, r, "root:secret123").SeedAdmins(ctx); err != nil {
+			t.Fatalf("SeedAdmins() error = %v", err)
+		}
+
+		data, err := r.User.GetByUsername(ctx, "root")
+		if err != nil {
+			t.Fatalf("GetByUsername() error = %v", err)
+		}
+		if *data.Role != user.RoleCustomer {
+			t.Errorf("role = %s, want %s", *data.Role, user.RoleCustomer)
+		}
+	})
+
+	t.Run("seeding twice keeps one admin", func(t *testing.T) {
+		s := newAuthService(t, newRepositories(t), "root:secret123")
+		for i := 0; i < 2; i++ {
+			if err := s.SeedAdmins(ctx); err != nil {
+				t.Fatalf("SeedAdmins() error = %v", err)
+			}
+		}
+	})
+
+	for _, admin := range []string{"root", "root:short", ":secret123"} {
+		t.Run("invalid "+admin, func(t *testing.T) {
+			if _, err := auth.New(auth.WithAdmins(admin)); err == nil {
+				t.Errorf("auth.New() error = nil, want the admin %q refused", admin)
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package exchange
+
+import (
+	"context"
+	"exchanger/internal/domain/rate"
+	"exchanger/internal/provider/currency"
+	"exchanger/pkg/log"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ratesTimeout limits the wait for the national bank when the rates aren't cached or stored yet
+const ratesTimeout = 10 * time.Second
+
+func (s *Service) ListRates(ctx context.Context, date time.Time) (res []rate.Response, err error) {
+	table, err := s.GetTable(ctx, date)
+	if err != nil {
+		return
+	}
+	res = rate.ParseFromEntities(table.Items)
+	for i := range res {
+		res[i].Stale = table.Stale
+	}
+
+	return
+}
+
+func (s *Service) GetRate(ctx context.Context, code string, date time.Time) (res rate.Response, err error) {
+	table, err := s.GetTable(ctx, date)
+	if err != nil {
+		return
+	}
+
+	for _, data := range table.Items {
+		if strings.EqualFold(code, data.Code) {
+			res = rate.ParseFromEntity(data)
+			res.Stale = table.Stale
+			return
+		}
+	}
+	err = errors.Wrap(ErrorUnknownCurrency, code)
+
+	return
+}
+
+// Convert converts the amount by the rates of the date, the currencies other than tenge are crossed through it
+func (s *Service) Convert(ctx context.Context, req rate.ConvertRequest) (res rate.ConvertResponse, err error) {
+	table, err := s.GetTable(ctx, req.Date)
+	if err != nil {
+		return
+	}
+
+	dest, value, err := table.Convert(req.Amount, req.From, req.To)
+	if err != nil {
+		err = errors.Wrap(ErrorUnknownCurrency, err.Error())
+		return
+	}
+
+	res = rate.ConvertResponse{
+		From:   req.From,
+		To:     req.To,
+		Amount: req.Amount,
+		Result: dest,
+		Rate:   value,
+		Base:   table.Base,
+		Date:   table.Date.Format(rate.DateLayout),
+		Stale:  table.Stale,
+	}
+
+	return
+}
+
+// GetTable returns the rates of the date, zero date stands for today. The rates of today come from the client cache,
+// the rates of the past days are fetched once from the primary source and then read from the repository
+func (s *Service) GetTable(ctx context.Context, date time.Time) (dest rate.Table, err error) {
+	logger := log.LoggerFromContext(ctx).Named("GetTable").With(zap.Time("date", date))
+
+	today := truncateDate(time.Now())
+	if !date.IsZero() {
+		date = truncateDate(date)
+	}
+
+	switch {
+	case date.After(today):
+		err = errors.Wrap(ErrorFutureDate, date.Format(rate.DateLayout))
+		return
+	case date.IsZero() || date.Equal(today):
+		if s.currencyClient == nil {
+			err = ErrorRatesDisabled
+			return
+		}
+
+		ratesCtx, cancel := context.WithTimeout(ctx, ratesTimeout)
+		defer cancel()
+
+		rates, err := s.currencyClient.GetLatestRates(ratesCtx)
+		if err != nil {
+			logger.Error("failed to get latest rates", zap.Error(err))
+			return dest, errors.Wrap(ErrorRatesUnavailable, err.Error())
+		}
+
+		dest = parseTable(truncateDate(rates.Date), rates)
+		dest.Stale = rates.Stale
+
+		return dest, nil
+	}
+
+	items, err := s.rateRepository.List(ctx, date)
+	if err != nil {
+		logger.Error("failed to select", zap.Error(err))
+		return
+	}
+
+	if len(items) > 0 {
+		dest = rate.Table{Date: date, Base: items[0].Base, Items: items}
+		return
+	}
+
+	if s.currencyClient == nil {
+		err = ErrorRatesDisabled
+		return
+	}
+
+	ratesCtx, cancel := context.WithTimeout(ctx, ratesTimeout)
+	defer cancel()
+
+	rates, err := s.currencyClient.GetRates(ratesCtx, date)
+	if err != nil {
+		logger.Error("failed to get rates", zap.Error(err))
+		err = errors.Wrap(ErrorRatesUnavailable, err.Error())
+		return
+	}
+
+	// only the rates the primary source gives for the very date are stored, the rates of a fallback source
+	// or of another day are served under their own source and date and asked for again the next time
+	day := truncateDate(rates.Date)
+	if rates.Source != s.currencyClient.PrimarySource() || !day.Equal(date) {
+		logger.Warn("rates aren't stored", zap.String("source", rates.Source), zap.Time("rates_date", day))
+		dest = parseTable(day, rates)
+		return
+	}
+
+	dest = parseTable(date, rates)
+	if err = s.rateRepository.Add(ctx, dest.Items); err != nil {
+		logger.Error("failed to insert", zap.Error(err))
+		return
+	}
+
+	return
+}
+
+// parseTable turns the rates of the source into the rates of the date
+func parseTable(date time.Time, rates currency.Rates) (dest rate.Table) {
+	dest = rate.Table{
+		Date:  date,
+		Base:  rates.Base,
+		Items: make([]rate.Entity, 0, len(rates.Items)),
+	}
+
+	for _, item := range rates.Items {
+		quant, err := strconv.Atoi(strings.TrimSpace(item.Quant))
+		if err != nil || quant <= 0 {
+			quant = 1
+		}
+
+		code := strings.ToUpper(item.Title)
+		dest.Items = append(dest.Items, rate.Entity{
+			ID:     rate.NewID(date, code),
+			Date:   date,
+			Code:   code,
+			Name:   item.Fullname,
+			Value:  item.Rate,
+			Quant:  quant,
+			Base:   rates.Base,
+			Source: rates.Source,
+		})
+	}
+
+	return
+}
+
+// truncateDate drops the time of the day keeping the calendar date it has in its location
+func truncateDate(datetime time.Time) time.Time {
+	year, month, day := datetime.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,102 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"exchanger/internal/provider/currency"
+	"exchanger/internal/repository/memory"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// daySource answers with the rates dated offset days from the date asked for, or fails
+type daySource struct {
+	name   string
+	offset int
+	fail   bool
+}
+
+func (s daySource) Name() string {
+	return s.name
+}
+
+func (s daySource) GetRates(ctx context.Context, datetime time.Time) (dest currency.Rates, err error) {
+	if s.fail {
+		return dest, errors.New("unavailable")
+	}
+
+	dest = currency.Rates{
+		Date:  datetime.AddDate(0, 0, s.offset),
+		Items: []currency.Rate{{Title: "USD", Fullname: "US dollar", Rate: decimal.NewFromInt(450), Quant: "1"}},
+	}
+
+	return
+}
+
+func TestGetTableStoresPrimarySourceOnly(t *testing.T) {
+	date := time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		sources  []currency.RateSource
+		wantDate time.Time
+		source   string
+		stored   bool
+	}{
+		{
+			name:     "primary source of the date",
+			sources:  []currency.RateSource{daySource{name: "primary"}, daySource{name: "fallback"}},
+			wantDate: date,
+			source:   "primary",
+			stored:   true,
+		},
+		{
+			name:     "primary source of another day",
+			sources:  []currency.RateSource{daySource{name: "primary", offset: -3}},
+			wantDate: date.AddDate(0, 0, -3),
+			source:   "primary",
+		},
+		{
+			name:     "fallback source",
+			sources:  []currency.RateSource{daySource{name: "primary", fail: true}, daySource{name: "fallback"}},
+			wantDate: date,
+			source:   "fallback",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := currency.New(currency.Credentials{}, currency.WithSources(tt.sources...))
+			if err != nil {
+				t.Fatalf("currency.New() error = %v", err)
+			}
+
+			rates := memory.NewRateRepository()
+			s, err := New(WithRateRepository(rates), WithCurrencyClient(client))
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			table, err := s.GetTable(context.Background(), date)
+			if err != nil {
+				t.Fatalf("GetTable() error = %v", err)
+			}
+			if !table.Date.Equal(tt.wantDate) {
+				t.Errorf("Date = %v, want %v", table.Date, tt.wantDate)
+			}
+			if len(table.Items) != 1 || table.Items[0].Source != tt.source || !table.Items[0].Date.Equal(tt.wantDate) {
+				t.Errorf("Items = %+v, want the rate of %s dated %v", table.Items, tt.source, tt.wantDate)
+			}
+
+			stored, err := rates.List(context.Background(), date)
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			if (len(stored) > 0) != tt.stored {
+				t.Errorf("stored = %d rates, want stored %v", len(stored), tt.stored)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package exchange
+
+import (
+	"errors"
+	"exchanger/internal/domain/rate"
+	"exchanger/internal/provider/currency"
+)
+
+var (
+	ErrorRatesDisabled    = errors.New("currency rates are not configured")
+	ErrorRatesUnavailable = errors.New("currency rates cannot be fetched")
+	ErrorUnknownCurrency  = errors.New("currency has no rate")
+	ErrorFutureDate       = errors.New("rates are not published for the date yet")
+)
+
+// Configuration is an alias for a function that will take in a pointer to a Service and modify it
+type Configuration func(s *Service) error
+
+// Service is an implementation of the Service
+type Service struct {
+	rateRepository rate.Repository
+	currencyClient *currency.Client
+}
+
+// New takes a variable amount of Configuration functions and returns a new Service
+// Each Configuration will be called in the order they are passed in
+func New(configs ...Configuration) (s *Service, err error) {
+	// Add the service
+	s = &Service{}
+
+	// Apply all Configurations passed in
+	for _, cfg := range configs {
+		// Pass the service into the configuration function
+		if err = cfg(s); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// WithRateRepository applies a given rate repository to the Service
+func WithRateRepository(rateRepository rate.Repository) Configuration {
+	return func(s *Service) error {
+		s.rateRepository = rateRepository
+		return nil
+	}
+}
+
+// WithCurrencyClient applies a given national bank rates client to the Service, only the stored rates are served without it
+func WithCurrencyClient(currencyClient *currency.Client) Configuration {
+	return func(s *Service) error {
+		s.currencyClient = currencyClient
+		return nil
+	}
+}
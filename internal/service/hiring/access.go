@@ -0,0 +1,75 @@
+package hiring
+
+import (
+	"context"
+	"exchanger/internal/domain/user"
+	"exchanger/pkg/log"
+	"exchanger/pkg/market"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// authorize returns market.ErrorForbidden unless the actor of the context owns the objects of the user
+func authorize(ctx context.Context, userID string) error {
+	actor, ok := user.ActorFromContext(ctx)
+	if !ok || !actor.Owns(userID) {
+		return market.ErrorForbidden
+	}
+
+	return nil
+}
+
+// authorizeAdmin returns market.ErrorForbidden unless the actor of the context is an admin
+func authorizeAdmin(ctx context.Context) error {
+	actor, ok := user.ActorFromContext(ctx)
+	if !ok || !actor.IsAdmin() {
+		return market.ErrorForbidden
+	}
+
+	return nil
+}
+
+// authorizeCustomer checks that the actor of the context owns the customer
+func (s *Service) authorizeCustomer(ctx context.Context, id string) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("authorizeCustomer").With(zap.String("id", id))
+
+	data, err := s.getCustomer(ctx, id)
+	if err != nil {
+		if !errors.Is(err, market.ErrorNotFound) {
+			logger.Error("failed to get", zap.Error(err))
+		}
+		return
+	}
+
+	return authorize(ctx, data.UserID)
+}
+
+// authorizeHire checks that the actor of the context owns the customer of the hire
+func (s *Service) authorizeHire(ctx context.Context, id string) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("authorizeHire").With(zap.String("id", id))
+
+	data, err := s.getHire(ctx, id)
+	if err != nil {
+		if !errors.Is(err, market.ErrorNotFound) {
+			logger.Error("failed to get", zap.Error(err))
+		}
+		return
+	}
+
+	return s.authorizeCustomer(ctx, data.CustomerID)
+}
+
+// authorizeWorker checks that the actor of the context owns the worker
+func (s *Service) authorizeWorker(ctx context.Context, id string) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("authorizeWorker").With(zap.String("id", id))
+
+	data, err := s.getWorker(ctx, id)
+	if err != nil {
+		if !errors.Is(err, market.ErrorNotFound) {
+			logger.Error("failed to get", zap.Error(err))
+		}
+		return
+	}
+
+	return authorize(ctx, data.UserID)
+}
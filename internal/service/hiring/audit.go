@@ -0,0 +1,67 @@
+package hiring
+
+import (
+	"context"
+	"exchanger/internal/domain/audit"
+	"exchanger/internal/domain/user"
+	"exchanger/pkg/log"
+	"go.uber.org/zap"
+	"time"
+)
+
+// ListAudit returns the changes of the entity from the oldest one, only admins read them
+func (s *Service) ListAudit(ctx context.Context, entity, id string) (res []audit.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("ListAudit").With(zap.String("entity", entity), zap.String("id", id))
+
+	if err = authorizeAdmin(ctx); err != nil {
+		return
+	}
+
+	if s.auditRepository == nil {
+		err = ErrorAuditDisabled
+		return
+	}
+
+	data, err := s.auditRepository.List(ctx, entity, id)
+	if err != nil {
+		logger.Error("failed to select", zap.Error(err))
+		return
+	}
+	res = audit.ParseFromEntities(data)
+
+	return
+}
+
+// audit records the change of the entity made by the actor of the context in the request of the context,
+// the state is nil before the entity is added and after it's purged
+func (s *Service) audit(ctx context.Context, entity, id, action string, before, after any) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("audit").With(zap.String("entity", entity), zap.String("id", id))
+
+	if s.auditRepository == nil {
+		return
+	}
+
+	diff, err := audit.NewDiff(before, after)
+	if err != nil {
+		logger.Error("failed to compare", zap.Error(err))
+		return
+	}
+
+	data := audit.Entity{
+		Entity:    entity,
+		EntityID:  id,
+		Action:    action,
+		RequestID: audit.RequestIDFromContext(ctx),
+		Diff:      diff,
+		CreatedAt: time.Now(),
+	}
+	if actor, ok := user.ActorFromContext(ctx); ok {
+		data.ActorID, data.ActorRole = actor.ID, actor.Role
+	}
+
+	if _, err = s.auditRepository.Add(ctx, data); err != nil {
+		logger.Error("failed to add", zap.Error(err))
+	}
+
+	return
+}
@@ -0,0 +1,79 @@
+package hiring
+
+import (
+	"context"
+	"exchanger/internal/domain/customer"
+	"exchanger/internal/domain/hire"
+	"exchanger/internal/domain/worker"
+	"exchanger/pkg/log"
+	"exchanger/pkg/market"
+	"go.uber.org/zap"
+)
+
+// getCustomer reads the customer through the cache when the cache is applied, the cache holds no deleted ones
+func (s *Service) getCustomer(ctx context.Context, id string) (customer.Entity, error) {
+	if s.customerCache != nil && !market.IncludesDeleted(ctx) {
+		return s.customerCache.Get(ctx, id)
+	}
+
+	return s.customerRepository.Get(ctx, id)
+}
+
+// getWorker reads the worker through the cache when the cache is applied, the cache holds no deleted ones
+func (s *Service) getWorker(ctx context.Context, id string) (worker.Entity, error) {
+	if s.workerCache != nil && !market.IncludesDeleted(ctx) {
+		return s.workerCache.Get(ctx, id)
+	}
+
+	return s.workerRepository.Get(ctx, id)
+}
+
+// getHire reads the hire through the cache when the cache is applied, the cache holds no deleted ones
+func (s *Service) getHire(ctx context.Context, id string) (hire.Entity, error) {
+	if s.hireCache != nil && !market.IncludesDeleted(ctx) {
+		return s.hireCache.Get(ctx, id)
+	}
+
+	return s.hireRepository.Get(ctx, id)
+}
+
+// invalidateCustomer drops the changed customer from the cache once the unit of work it's changed in is committed,
+// so that the cache isn't filled again with the customer of before the commit. The failure is only logged
+// since the entry expires anyway
+func (s *Service) invalidateCustomer(ctx context.Context, id string) {
+	if s.customerCache == nil {
+		return
+	}
+
+	afterCommit(ctx, func(ctx context.Context) {
+		if err := s.customerCache.Delete(ctx, id); err != nil {
+			log.LoggerFromContext(ctx).Named("invalidateCustomer").Warn("failed to delete from cache", zap.String("id", id), zap.Error(err))
+		}
+	})
+}
+
+// invalidateWorker drops the changed worker from the cache
+func (s *Service) invalidateWorker(ctx context.Context, id string) {
+	if s.workerCache == nil {
+		return
+	}
+
+	afterCommit(ctx, func(ctx context.Context) {
+		if err := s.workerCache.Delete(ctx, id); err != nil {
+			log.LoggerFromContext(ctx).Named("invalidateWorker").Warn("failed to delete from cache", zap.String("id", id), zap.Error(err))
+		}
+	})
+}
+
+// invalidateHire drops the changed hire from the cache
+func (s *Service) invalidateHire(ctx context.Context, id string) {
+	if s.hireCache == nil {
+		return
+	}
+
+	afterCommit(ctx, func(ctx context.Context) {
+		if err := s.hireCache.Delete(ctx, id); err != nil {
+			log.LoggerFromContext(ctx).Named("invalidateHire").Warn("failed to delete from cache", zap.String("id", id), zap.Error(err))
+		}
+	})
+}
@@ -0,0 +1,152 @@
+package hiring
+
+import (
+	"context"
+	"errors"
+	"exchanger/internal/domain/hire"
+	"exchanger/pkg/market"
+	"testing"
+)
+
+// hireCache records the hires deleted from it
+type hireCache struct {
+	deleted []string
+}
+
+func (c *hireCache) Get(ctx context.Context, id string) (dest hire.Entity, err error) {
+	err = market.ErrorNotFound
+	return
+}
+
+func (c *hireCache) Delete(ctx context.Context, id string) (err error) {
+	c.deleted = append(c.deleted, id)
+	return
+}
+
+func (c *hireCache) Stats() market.CacheStats {
+	return market.CacheStats{}
+}
+
+func TestInvalidateAfterCommit(t *testing.T) {
+	failure := errors.New("failure")
+
+	tests := []struct {
+		name string
+		// run invalidates the hire "id" and fails when it was deleted before the outermost commit
+		run  func(s *Service, cache *hireCache) (err error)
+		want int
+	}{
+		{
+			name: "outside of a unit of work",
+			run: func(s *Service, cache *hireCache) error {
+				s.invalidateHire(context.Background(), "id")
+				return nil
+			},
+			want: 1,
+		},
+		{
+			name: "committed",
+			run: func(s *Service, cache *hireCache) error {
+				return s.inTransaction(context.Background(), func(ctx context.Context) error {
+					s.invalidateHire(ctx, "id")
+					if len(cache.deleted) != 0 {
+						return errors.New("deleted before the commit")
+					}
+					return nil
+				})
+			},
+			want: 1,
+		},
+		{
+			name: "rolled back",
+			run: func(s *Service, cache *hireCache) error {
+				err := s.inTransaction(context.Background(), func(ctx context.Context) error {
+					s.invalidateHire(ctx, "id")
+					return failure
+				})
+				if !errors.Is(err, failure) {
+					return err
+				}
+				return nil
+			},
+		},
+		{
+			name: "nested committed",
+			run: func(s *Service, cache *hireCache) error {
+				return s.inTransaction(context.Background(), func(ctx context.Context) error {
+					if err := s.inTransaction(ctx, func(ctx context.Context) error {
+						s.invalidateHire(ctx, "id")
+						return nil
+					}); err != nil {
+						return err
+					}
+					if len(cache.deleted) != 0 {
+						return errors.New("deleted after the inner commit")
+					}
+					return nil
+				})
+			},
+			want: 1,
+		},
+		{
+			name: "nested rolled back by the outer",
+			run: func(s *Service, cache *hireCache) error {
+				err := s.inTransaction(context.Background(), func(ctx context.Context) error {
+					if err := s.inTransaction(ctx, func(ctx context.Context) error {
+						s.invalidateHire(ctx, "id")
+						return nil
+					}); err != nil {
+						return err
+					}
+					return failure
+				})
+				if !errors.Is(err, failure) {
+					return err
+				}
+				return nil
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := &hireCache{}
+			s := newService(t, newMemoryRepositories(t), WithHireCache(cache))
+
+			if err := tt.run(s, cache); err != nil {
+				t.Fatalf("run error = %v", err)
+			}
+
+			if len(cache.deleted) != tt.want {
+				t.Errorf("deleted = %v, want %d deletes", cache.deleted, tt.want)
+			}
+		})
+	}
+}
+
+func TestChangeHireStatusInvalidates(t *testing.T) {
+	repositories := newMemoryRepositories(t)
+	cache := &hireCache{}
+	s := newService(t, repositories, WithHireCache(cache))
+	ctx, id := addHire(t, repositories, hire.StatusOpen)
+
+	data, err := repositories.Hire.Get(ctx, id)
+	if err != nil {
+		t.Fatalf("Hire.Get() error = %v", err)
+	}
+
+	// the illegal transition changes nothing to drop
+	if _, err = s.changeHireStatus(ctx, data, hire.StatusCompleted); !errors.Is(err, ErrorHireTransition) {
+		t.Fatalf("changeHireStatus() error = %v, want %v", err, ErrorHireTransition)
+	}
+	if len(cache.deleted) != 0 {
+		t.Fatalf("deleted = %v after the illegal transition, want none", cache.deleted)
+	}
+
+	if _, err = s.changeHireStatus(ctx, data, hire.StatusInProgress); err != nil {
+		t.Fatalf("changeHireStatus() error = %v", err)
+	}
+	if len(cache.deleted) != 1 || cache.deleted[0] != id {
+		t.Errorf("deleted = %v, want [%s]", cache.deleted, id)
+	}
+}
@@ -0,0 +1,54 @@
+package hiring
+
+import (
+	"context"
+	"exchanger/internal/domain/hire"
+	"exchanger/internal/service/exchange"
+	"exchanger/pkg/log"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"time"
+)
+
+// convertHires adds the budgets of the hires in the currency by the latest rates,
+// the hires in a currency the rates don't know are left without a conversion
+func (s *Service) convertHires(ctx context.Context, res []hire.Response, currency string) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("convertHires").With(zap.String("currency", currency))
+
+	if currency == "" {
+		return
+	}
+
+	if s.exchangeService == nil {
+		err = ErrorCurrencyDisabled
+		return
+	}
+
+	rates, err := s.exchangeService.GetTable(ctx, time.Time{})
+	if err != nil {
+		logger.Error("failed to get rates", zap.Error(err))
+		if errors.Is(err, exchange.ErrorRatesDisabled) {
+			err = ErrorCurrencyDisabled
+			return
+		}
+		err = errors.Wrap(ErrorRatesUnavailable, err.Error())
+		return
+	}
+
+	if _, err = rates.Find(currency); err != nil {
+		err = errors.Wrap(ErrorUnknownCurrency, currency)
+		return
+	}
+
+	for i := range res {
+		amount, rate, err := rates.Convert(res[i].Amount, res[i].Currency, currency)
+		if err != nil {
+			logger.Warn("failed to convert", zap.String("id", res[i].ID), zap.Error(err))
+			continue
+		}
+		res[i].Converted = hire.NewConversion(amount, currency, rate, rates.Date)
+		res[i].Converted.Stale = rates.Stale
+	}
+
+	return
+}
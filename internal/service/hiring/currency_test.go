@@ -0,0 +1,126 @@
+package hiring
+
+import (
+	"context"
+	"errors"
+	"exchanger/internal/domain/hire"
+	"exchanger/internal/provider/currency"
+	"exchanger/internal/repository/memory"
+	"exchanger/internal/service/exchange"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// rateSource gives the same rates for every date or fails
+type rateSource struct {
+	fail bool
+}
+
+func (s rateSource) Name() string {
+	return "stub"
+}
+
+func (s rateSource) GetRates(ctx context.Context, datetime time.Time) (dest currency.Rates, err error) {
+	if s.fail {
+		return dest, errors.New("unavailable")
+	}
+
+	dest = currency.Rates{
+		Date: datetime,
+		Items: []currency.Rate{
+			{Title: "USD", Rate: decimal.RequireFromString("450"), Quant: "1"},
+			{Title: "JPY", Rate: decimal.RequireFromString("300"), Quant: "100"},
+		},
+	}
+
+	return
+}
+
+// newExchangeService returns the exchange service over the rate source
+func newExchangeService(t *testing.T, source currency.RateSource) *exchange.Service {
+	t.Helper()
+
+	client, err := currency.New(currency.Credentials{}, currency.WithSources(source))
+	if err != nil {
+		t.Fatalf("currency.New() error = %v", err)
+	}
+
+	s, err := exchange.New(exchange.WithRateRepository(memory.NewRateRepository()), exchange.WithCurrencyClient(client))
+	if err != nil {
+		t.Fatalf("exchange.New() error = %v", err)
+	}
+
+	return s
+}
+
+func TestConvertHires(t *testing.T) {
+	budgets := func() []hire.Response {
+		return []hire.Response{
+			{ID: "tenge", Amount: decimal.RequireFromString("9000"), Currency: "KZT"},
+			{ID: "yen", Amount: decimal.RequireFromString("15000"), Currency: "JPY"},
+			{ID: "dollar", Amount: decimal.RequireFromString("20"), Currency: "USD"},
+			{ID: "unknown", Amount: decimal.RequireFromString("20"), Currency: "XXX"},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		source   currency.RateSource
+		currency string
+		want     map[string]string
+		wantErr  error
+	}{
+		{
+			name:     "to a currency",
+			source:   rateSource{},
+			currency: "USD",
+			want:     map[string]string{"tenge": "20", "yen": "100", "dollar": "20"},
+		},
+		{
+			name:     "to the base currency",
+			source:   rateSource{},
+			currency: "KZT",
+			want:     map[string]string{"tenge": "9000", "yen": "45000", "dollar": "9000"},
+		},
+		{name: "no currency asked for", source: rateSource{}, want: map[string]string{}},
+		{name: "unknown currency", source: rateSource{}, currency: "XXX", wantErr: ErrorUnknownCurrency},
+		{name: "rates unavailable", source: rateSource{fail: true}, currency: "USD", wantErr: ErrorRatesUnavailable},
+		{name: "rates not configured", currency: "USD", wantErr: ErrorCurrencyDisabled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var configs []Configuration
+			if tt.source != nil {
+				configs = append(configs, WithExchangeService(newExchangeService(t, tt.source)))
+			}
+			s := newService(t, newMemoryRepositories(t), configs...)
+
+			res := budgets()
+			err := s.convertHires(context.Background(), res, tt.currency)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("convertHires() error = %v, want %v", err, tt.wantErr)
+			}
+
+			for _, data := range res {
+				want, ok := tt.want[data.ID]
+				if !ok {
+					if data.Converted != nil {
+						t.Errorf("%s: Converted = %+v, want none", data.ID, data.Converted)
+					}
+					continue
+				}
+
+				if data.Converted == nil || !data.Converted.Amount.Equal(decimal.RequireFromString(want)) || data.Converted.Currency != tt.currency {
+					t.Errorf("%s: Converted = %+v, want %s %s", data.ID, data.Converted, want, tt.currency)
+					continue
+				}
+				if data.Converted.RateDate != time.Now().Format(hire.RateDateLayout) {
+					t.Errorf("%s: RateDate = %s, want today", data.ID, data.Converted.RateDate)
+				}
+			}
+		})
+	}
+}
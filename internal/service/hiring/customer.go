@@ -2,22 +2,25 @@ package hiring
 
 import (
 	"context"
+	"exchanger/internal/domain/audit"
 	"exchanger/internal/domain/customer"
+	"exchanger/internal/domain/user"
 	"exchanger/pkg/log"
 	"exchanger/pkg/market"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 )
 
-func (s *Service) ListCustomers(ctx context.Context) (res []customer.Response, err error) {
+func (s *Service) ListCustomers(ctx context.Context, q market.Query) (res []customer.Response, page market.Page, err error) {
 	logger := log.LoggerFromContext(ctx).Named("ListCustomers")
 
-	data, err := s.customerRepository.List(ctx)
+	data, total, err := s.customerRepository.List(ctx, q)
 	if err != nil {
 		logger.Error("failed to select", zap.Error(err))
 		return
 	}
 	res = customer.ParseFromEntities(data)
+	page = q.Page(total)
 
 	return
 }
@@ -25,14 +28,26 @@ func (s *Service) ListCustomers(ctx context.Context) (res []customer.Response, e
 func (s *Service) AddCustomer(ctx context.Context, req customer.Request) (res customer.Response, err error) {
 	logger := log.LoggerFromContext(ctx).Named("AddCustomer")
 
+	actor, ok := user.ActorFromContext(ctx)
+	if !ok {
+		return res, market.ErrorForbidden
+	}
+
 	data := customer.Entity{
 		FullName:  &req.FullName,
 		Pseudonym: &req.Pseudonym,
+		UserID:    actor.ID,
 	}
 
-	data.ID, err = s.customerRepository.Add(ctx, data)
+	err = s.inTransaction(ctx, func(ctx context.Context) (err error) {
+		if data.ID, err = s.customerRepository.Add(ctx, data); err != nil {
+			logger.Error("failed to add", zap.Error(err))
+			return
+		}
+
+		return s.recordAdded(ctx, audit.EntityCustomer, data.ID, s.customerState)
+	})
 	if err != nil {
-		logger.Error("failed to add", zap.Error(err))
 		return
 	}
 	res = customer.ParseFromEntity(data)
@@ -43,9 +58,11 @@ func (s *Service) AddCustomer(ctx context.Context, req customer.Request) (res cu
 func (s *Service) GetCustomer(ctx context.Context, id string) (res customer.Response, err error) {
 	logger := log.LoggerFromContext(ctx).Named("GetCustomer").With(zap.String("id", id))
 
-	data, err := s.customerRepository.Get(ctx, id)
-	if err != nil && !errors.Is(err, market.ErrorNotFound) {
-		logger.Error("failed to get", zap.Error(err))
+	data, err := s.getCustomer(ctx, id)
+	if err != nil {
+		if !errors.Is(err, market.ErrorNotFound) {
+			logger.Error("failed to get", zap.Error(err))
+		}
 		return
 	}
 	res = customer.ParseFromEntity(data)
@@ -53,31 +70,72 @@ func (s *Service) GetCustomer(ctx context.Context, id string) (res customer.Resp
 	return
 }
 
-func (s *Service) UpdateCustomer(ctx context.Context, id string, req customer.Request) (err error) {
-	logger := log.LoggerFromContext(ctx).Named("UpdateCustomer").With(zap.String("id", id))
+// UpdateCustomer changes the customer at the version it was read at, the customer changed since is market.ErrorConflict
+func (s *Service) UpdateCustomer(ctx context.Context, id string, req customer.Request, version int) (err error) {
+	return s.PatchCustomer(ctx, id, req.Patch(), version)
+}
+
+// PatchCustomer changes only the fields the patch sets, the same way UpdateCustomer changes them all
+func (s *Service) PatchCustomer(ctx context.Context, id string, req customer.Patch, version int) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("PatchCustomer").With(zap.String("id", id))
+
+	if err = s.authorizeCustomer(ctx, id); err != nil {
+		return
+	}
 
 	data := customer.Entity{
-		FullName:  &req.FullName,
-		Pseudonym: &req.Pseudonym,
+		FullName:  req.FullName,
+		Pseudonym: req.Pseudonym,
+		Version:   version,
 	}
 
-	err = s.customerRepository.Update(ctx, id, data)
-	if err != nil && !errors.Is(err, market.ErrorNotFound) {
+	err = s.recorded(ctx, audit.EntityCustomer, id, audit.ActionUpdate, s.customerState, func(ctx context.Context) error {
+		return s.customerRepository.Update(ctx, id, data)
+	})
+	if err != nil && !errors.Is(err, market.ErrorNotFound) && !errors.Is(err, market.ErrorConflict) {
 		logger.Error("failed to update by id", zap.Error(err))
 		return
 	}
+	s.invalidateCustomer(ctx, id)
 
 	return
 }
 
-func (s *Service) DeleteCustomer(ctx context.Context, id string) (err error) {
+func (s *Service) DeleteCustomer(ctx context.Context, id string, version int) (err error) {
 	logger := log.LoggerFromContext(ctx).Named("DeleteCustomer").With(zap.String("id", id))
 
-	err = s.customerRepository.Delete(ctx, id)
-	if err != nil && !errors.Is(err, market.ErrorNotFound) {
+	if err = s.authorizeCustomer(ctx, id); err != nil {
+		return
+	}
+
+	err = s.recorded(ctx, audit.EntityCustomer, id, audit.ActionDelete, s.customerState, func(ctx context.Context) error {
+		return s.customerRepository.Delete(ctx, id, version)
+	})
+	if err != nil && !errors.Is(err, market.ErrorNotFound) && !errors.Is(err, market.ErrorConflict) {
 		logger.Error("failed to delete", zap.Error(err))
 		return
 	}
+	s.invalidateCustomer(ctx, id)
+
+	return
+}
+
+// RestoreCustomer brings the deleted customer back, only admins restore
+func (s *Service) RestoreCustomer(ctx context.Context, id string) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("RestoreCustomer").With(zap.String("id", id))
+
+	if err = authorizeAdmin(ctx); err != nil {
+		return
+	}
+
+	err = s.recorded(ctx, audit.EntityCustomer, id, audit.ActionRestore, s.customerState, func(ctx context.Context) error {
+		return s.customerRepository.Restore(ctx, id)
+	})
+	if err != nil && !errors.Is(err, market.ErrorNotFound) {
+		logger.Error("failed to restore", zap.Error(err))
+		return
+	}
+	s.invalidateCustomer(ctx, id)
 
 	return
 }
@@ -2,6 +2,7 @@ package hiring
 
 import (
 	"context"
+	"exchanger/internal/domain/audit"
 	"exchanger/internal/domain/hire"
 	"exchanger/pkg/log"
 	"exchanger/pkg/market"
@@ -9,15 +10,33 @@ import (
 	"go.uber.org/zap"
 )
 
-func (s *Service) ListHires(ctx context.Context) (res []hire.Response, err error) {
+// ListHires returns the page of hires with their budgets converted into the currency unless it's blank
+func (s *Service) ListHires(ctx context.Context, q market.Query, currency string) (res []hire.Response, page market.Page, err error) {
 	logger := log.LoggerFromContext(ctx).Named("ListHires")
 
-	data, err := s.hireRepository.List(ctx)
+	data, total, err := s.hireRepository.List(ctx, q)
 	if err != nil {
 		logger.Error("failed to select", zap.Error(err))
 		return
 	}
 	res = hire.ParseFromEntities(data)
+	page = q.Page(total)
+
+	err = s.convertHires(ctx, res, currency)
+
+	return
+}
+
+func (s *Service) SearchHires(ctx context.Context, req hire.Search) (res []hire.SearchResponse, page market.Page, err error) {
+	logger := log.LoggerFromContext(ctx).Named("SearchHires").With(zap.String("text", req.Text))
+
+	data, total, err := s.hireRepository.Search(ctx, req)
+	if err != nil {
+		logger.Error("failed to search", zap.Error(err))
+		return
+	}
+	res = hire.ParseFromSearchEntities(data)
+	page = req.Page(total)
 
 	return
 }
@@ -25,17 +44,29 @@ func (s *Service) ListHires(ctx context.Context) (res []hire.Response, err error
 func (s *Service) AddHire(ctx context.Context, req hire.Request) (res hire.Response, err error) {
 	logger := log.LoggerFromContext(ctx).Named("AddHire")
 
+	if err = s.authorizeCustomer(ctx, req.CustomerID); err != nil {
+		return
+	}
+
 	data := hire.Entity{
 		JobName:     &req.JobName,
 		Amount:      &req.Amount,
+		Currency:    &req.Currency,
 		Description: &req.Description,
 		Position:    &req.Position,
 		CustomerID:  req.CustomerID,
+		Status:      hire.StatusDraft,
 	}
 
-	data.ID, err = s.hireRepository.Add(ctx, data)
+	err = s.inTransaction(ctx, func(ctx context.Context) (err error) {
+		if data.ID, err = s.hireRepository.Add(ctx, data); err != nil {
+			logger.Error("failed to add", zap.Error(err))
+			return
+		}
+
+		return s.recordAdded(ctx, audit.EntityHire, data.ID, s.hireState)
+	})
 	if err != nil {
-		logger.Error("failed to add", zap.Error(err))
 		return
 	}
 	res = hire.ParseFromEntity(data)
@@ -43,47 +74,130 @@ func (s *Service) AddHire(ctx context.Context, req hire.Request) (res hire.Respo
 	return
 }
 
-func (s *Service) GetHire(ctx context.Context, id string) (res hire.Response, err error) {
+// GetHire returns the hire with its budget converted into the currency unless it's blank
+func (s *Service) GetHire(ctx context.Context, id, currency string) (res hire.Response, err error) {
 	logger := log.LoggerFromContext(ctx).Named("GetHire").With(zap.String("id", id))
 
-	data, err := s.hireRepository.Get(ctx, id)
-	if err != nil && !errors.Is(err, market.ErrorNotFound) {
-		logger.Error("failed to get", zap.Error(err))
+	data, err := s.getHire(ctx, id)
+	if err != nil {
+		if !errors.Is(err, market.ErrorNotFound) {
+			logger.Error("failed to get", zap.Error(err))
+		}
 		return
 	}
 	res = hire.ParseFromEntity(data)
 
+	converted := []hire.Response{res}
+	if err = s.convertHires(ctx, converted, currency); err != nil {
+		return
+	}
+	res = converted[0]
+
 	return
 }
 
-func (s *Service) UpdateHire(ctx context.Context, id string, req hire.Request) (err error) {
-	logger := log.LoggerFromContext(ctx).Named("UpdateHire").With(zap.String("id", id))
+// UpdateHire changes the hire at the version it was read at, the hire changed since is market.ErrorConflict
+func (s *Service) UpdateHire(ctx context.Context, id string, req hire.Request, version int) (err error) {
+	return s.PatchHire(ctx, id, req.Patch(), version)
+}
+
+// PatchHire changes only the fields the patch sets, the same way UpdateHire changes them all
+func (s *Service) PatchHire(ctx context.Context, id string, req hire.Patch, version int) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("PatchHire").With(zap.String("id", id))
+
+	if err = s.authorizeHire(ctx, id); err != nil {
+		return
+	}
+
+	current, err := s.hireRepository.Get(ctx, id)
+	if err != nil {
+		logger.Error("failed to get", zap.Error(err))
+		return
+	}
+
+	if field, locked := hire.LockedField(current, req); locked {
+		err = errors.Wrap(ErrorHireLocked, field)
+		return
+	}
 
 	data := hire.Entity{
-		JobName:     &req.JobName,
-		Amount:      &req.Amount,
-		Description: &req.Description,
-		Position:    &req.Position,
-		CustomerID:  req.CustomerID,
+		JobName:     req.JobName,
+		Amount:      req.Amount,
+		Currency:    req.Currency,
+		Description: req.Description,
+		Position:    req.Position,
+		Version:     version,
 	}
 
-	err = s.hireRepository.Update(ctx, id, data)
-	if err != nil && !errors.Is(err, market.ErrorNotFound) {
+	if req.CustomerID != nil {
+		data.CustomerID = *req.CustomerID
+	}
+
+	err = s.recorded(ctx, audit.EntityHire, id, audit.ActionUpdate, s.hireState, func(ctx context.Context) error {
+		return s.hireRepository.Update(ctx, id, data)
+	})
+	if err != nil && !errors.Is(err, market.ErrorNotFound) && !errors.Is(err, market.ErrorConflict) {
 		logger.Error("failed to update by id", zap.Error(err))
 		return
 	}
+	s.invalidateHire(ctx, id)
 
 	return
 }
 
-func (s *Service) DeleteHire(ctx context.Context, id string) (err error) {
+func (s *Service) DeleteHire(ctx context.Context, id string, version int) (err error) {
 	logger := log.LoggerFromContext(ctx).Named("DeleteHire").With(zap.String("id", id))
 
-	err = s.hireRepository.Delete(ctx, id)
+	if err = s.authorizeHire(ctx, id); err != nil {
+		return
+	}
+
+	// the hire having payments is kept, the proposals and status history of the deleted one
+	// stay until it's purged so that it can be restored
+	err = s.inTransaction(ctx, func(ctx context.Context) (err error) {
+		payments, err := s.paymentRepository.List(ctx, id)
+		if err != nil {
+			logger.Error("failed to select payments", zap.Error(err))
+			return
+		}
+
+		if len(payments) > 0 {
+			return ErrorPaymentExists
+		}
+
+		err = s.recorded(ctx, audit.EntityHire, id, audit.ActionDelete, s.hireState, func(ctx context.Context) error {
+			return s.hireRepository.Delete(ctx, id, version)
+		})
+		if err != nil && !errors.Is(err, market.ErrorNotFound) && !errors.Is(err, market.ErrorConflict) {
+			logger.Error("failed to delete", zap.Error(err))
+		}
+
+		return
+	})
+	if err != nil {
+		return
+	}
+	s.invalidateHire(ctx, id)
+
+	return
+}
+
+// RestoreHire brings the deleted hire back, only admins restore
+func (s *Service) RestoreHire(ctx context.Context, id string) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("RestoreHire").With(zap.String("id", id))
+
+	if err = authorizeAdmin(ctx); err != nil {
+		return
+	}
+
+	err = s.recorded(ctx, audit.EntityHire, id, audit.ActionRestore, s.hireState, func(ctx context.Context) error {
+		return s.hireRepository.Restore(ctx, id)
+	})
 	if err != nil && !errors.Is(err, market.ErrorNotFound) {
-		logger.Error("failed to delete", zap.Error(err))
+		logger.Error("failed to restore", zap.Error(err))
 		return
 	}
+	s.invalidateHire(ctx, id)
 
 	return
 }
@@ -0,0 +1,177 @@
+package hiring
+
+import (
+	"context"
+	"exchanger/internal/domain/audit"
+	"exchanger/internal/domain/hire"
+	"exchanger/internal/domain/proposal"
+	"exchanger/internal/domain/user"
+	"exchanger/pkg/log"
+	"exchanger/pkg/market"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"time"
+)
+
+// PublishHire opens the draft hire to proposals
+func (s *Service) PublishHire(ctx context.Context, id string) (res hire.Response, err error) {
+	if err = s.authorizeHire(ctx, id); err != nil {
+		return
+	}
+
+	return s.transitionHire(ctx, id, hire.StatusOpen)
+}
+
+// CompleteHire finishes the hire and releases its escrow payment to the worker
+func (s *Service) CompleteHire(ctx context.Context, id string) (res hire.Response, err error) {
+	if err = s.authorizeHire(ctx, id); err != nil {
+		return
+	}
+
+	// the payment is released only together with the completed hire
+	err = s.inTransaction(ctx, func(ctx context.Context) (err error) {
+		if res, err = s.transitionHire(ctx, id, hire.StatusCompleted); err != nil {
+			return
+		}
+		return s.releasePayment(ctx, id)
+	})
+
+	return
+}
+
+func (s *Service) CancelHire(ctx context.Context, id string) (res hire.Response, err error) {
+	if err = s.authorizeHire(ctx, id); err != nil {
+		return
+	}
+
+	return s.transitionHire(ctx, id, hire.StatusCancelled)
+}
+
+// DisputeHire can be raised by the customer of the hire or by the worker of its accepted proposal
+func (s *Service) DisputeHire(ctx context.Context, id string) (res hire.Response, err error) {
+	if err = s.authorizeHire(ctx, id); err != nil {
+		if !errors.Is(err, market.ErrorForbidden) {
+			return
+		}
+
+		if err = s.authorizeHireWorker(ctx, id); err != nil {
+			return
+		}
+	}
+
+	return s.transitionHire(ctx, id, hire.StatusDisputed)
+}
+
+// ResolveHire returns the disputed hire to work, the dispute is resolved by admins only
+func (s *Service) ResolveHire(ctx context.Context, id string) (res hire.Response, err error) {
+	if err = authorizeAdmin(ctx); err != nil {
+		return
+	}
+
+	return s.transitionHire(ctx, id, hire.StatusInProgress)
+}
+
+func (s *Service) ListHireHistory(ctx context.Context, id string) (res []hire.HistoryResponse, err error) {
+	logger := log.LoggerFromContext(ctx).Named("ListHireHistory").With(zap.String("id", id))
+
+	if err = s.authorizeHire(ctx, id); err != nil {
+		return
+	}
+
+	data, err := s.hireHistoryRepository.List(ctx, id)
+	if err != nil {
+		logger.Error("failed to select", zap.Error(err))
+		return
+	}
+	res = hire.ParseFromHistoryEntities(data)
+
+	return
+}
+
+func (s *Service) transitionHire(ctx context.Context, id, status string) (res hire.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("transitionHire").With(zap.String("id", id))
+
+	data, err := s.hireRepository.Get(ctx, id)
+	if err != nil {
+		if !errors.Is(err, market.ErrorNotFound) {
+			logger.Error("failed to get", zap.Error(err))
+		}
+		return
+	}
+
+	if data, err = s.changeHireStatus(ctx, data, status); err != nil {
+		return
+	}
+	res = hire.ParseFromEntity(data)
+
+	return
+}
+
+// changeHireStatus moves the hire to the status when the transition is legal and records it in the history,
+// the update is made for the version the transition was checked on so a concurrent change gets market.ErrorConflict
+func (s *Service) changeHireStatus(ctx context.Context, data hire.Entity, status string) (dest hire.Entity, err error) {
+	logger := log.LoggerFromContext(ctx).Named("changeHireStatus").
+		With(zap.String("id", data.ID), zap.String("from", data.Status), zap.String("to", status))
+
+	if !hire.CanTransition(data.Status, status) {
+		err = errors.Wrapf(ErrorHireTransition, "%s to %s", data.Status, status)
+		return
+	}
+
+	history := hire.HistoryEntity{
+		HireID:     data.ID,
+		FromStatus: data.Status,
+		ToStatus:   status,
+		CreatedAt:  time.Now(),
+	}
+	if actor, ok := user.ActorFromContext(ctx); ok {
+		history.UserID = actor.ID
+	}
+
+	// the status isn't changed without its history
+	err = s.inTransaction(ctx, func(ctx context.Context) (err error) {
+		err = s.recorded(ctx, audit.EntityHire, data.ID, audit.ActionUpdate, s.hireState, func(ctx context.Context) error {
+			return s.hireRepository.Update(ctx, data.ID, hire.Entity{Status: status, Version: data.Version})
+		})
+		if err != nil {
+			logger.Error("failed to update by id", zap.Error(err))
+			return
+		}
+
+		if _, err = s.hireHistoryRepository.Add(ctx, history); err != nil {
+			logger.Error("failed to add history", zap.Error(err))
+			return
+		}
+
+		return
+	})
+	if err != nil {
+		return
+	}
+	s.invalidateHire(ctx, data.ID)
+
+	dest = data
+	dest.Status = status
+	dest.Version++
+
+	return
+}
+
+// authorizeHireWorker checks that the actor of the context owns the worker of the accepted proposal of the hire
+func (s *Service) authorizeHireWorker(ctx context.Context, id string) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("authorizeHireWorker").With(zap.String("id", id))
+
+	q := market.NewQuery().Where("status", proposal.StatusAccepted)
+
+	data, _, err := s.proposalRepository.List(ctx, id, q)
+	if err != nil {
+		logger.Error("failed to select proposals", zap.Error(err))
+		return
+	}
+
+	if len(data) == 0 {
+		return market.ErrorForbidden
+	}
+
+	return s.authorizeWorker(ctx, data[0].WorkerID)
+}
@@ -0,0 +1,124 @@
+package hiring
+
+import (
+	"context"
+	"errors"
+	"exchanger/internal/domain/hire"
+	"exchanger/internal/domain/user"
+	"exchanger/pkg/market"
+	"testing"
+)
+
+func TestChangeHireStatus(t *testing.T) {
+	tests := []struct {
+		from, to string
+		legal    bool
+	}{
+		{from: hire.StatusDraft, to: hire.StatusOpen, legal: true},
+		{from: hire.StatusDraft, to: hire.StatusCancelled, legal: true},
+		{from: hire.StatusDraft, to: hire.StatusInProgress},
+		{from: hire.StatusDraft, to: hire.StatusCompleted},
+		{from: hire.StatusOpen, to: hire.StatusDraft, legal: true},
+		{from: hire.StatusOpen, to: hire.StatusInProgress, legal: true},
+		{from: hire.StatusOpen, to: hire.StatusDisputed},
+		{from: hire.StatusOpen, to: hire.StatusCompleted},
+		{from: hire.StatusInProgress, to: hire.StatusCompleted, legal: true},
+		{from: hire.StatusInProgress, to: hire.StatusDisputed, legal: true},
+		{from: hire.StatusInProgress, to: hire.StatusOpen},
+		{from: hire.StatusInProgress, to: hire.StatusDraft},
+		{from: hire.StatusDisputed, to: hire.StatusInProgress, legal: true},
+		{from: hire.StatusDisputed, to: hire.StatusOpen},
+		{from: hire.StatusCompleted, to: hire.StatusCancelled},
+		{from: hire.StatusCompleted, to: hire.StatusInProgress},
+		{from: hire.StatusCancelled, to: hire.StatusOpen},
+		{from: hire.StatusCancelled, to: hire.StatusDraft},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from+" to "+tt.to, func(t *testing.T) {
+			repositories := newMemoryRepositories(t)
+			s := newService(t, repositories)
+			ctx, id := addHire(t, repositories, tt.from)
+
+			data, err := repositories.Hire.Get(ctx, id)
+			if err != nil {
+				t.Fatalf("Hire.Get() error = %v", err)
+			}
+
+			_, err = s.changeHireStatus(ctx, data, tt.to)
+			if tt.legal && err != nil {
+				t.Fatalf("changeHireStatus() error = %v", err)
+			}
+			if !tt.legal && !errors.Is(err, ErrorHireTransition) {
+				t.Fatalf("changeHireStatus() error = %v, want %v", err, ErrorHireTransition)
+			}
+
+			want, records := tt.from, 0
+			if tt.legal {
+				want, records = tt.to, 1
+			}
+
+			if data, _ = repositories.Hire.Get(ctx, id); data.Status != want {
+				t.Errorf("status = %q, want %q", data.Status, want)
+			}
+			if history, _ := repositories.HireHistory.List(ctx, id); len(history) != records {
+				t.Errorf("history = %d records, want %d", len(history), records)
+			}
+		})
+	}
+}
+
+func TestChangeHireStatusLostUpdate(t *testing.T) {
+	repositories := newMemoryRepositories(t)
+	s := newService(t, repositories)
+	ctx, id := addHire(t, repositories, hire.StatusOpen)
+
+	// both transitions are checked on the same read of the open hire
+	data, err := repositories.Hire.Get(ctx, id)
+	if err != nil {
+		t.Fatalf("Hire.Get() error = %v", err)
+	}
+
+	changed, err := s.changeHireStatus(ctx, data, hire.StatusInProgress)
+	if err != nil {
+		t.Fatalf("changeHireStatus() error = %v", err)
+	}
+	if changed.Version != data.Version+1 {
+		t.Errorf("Version = %d, want %d", changed.Version, data.Version+1)
+	}
+
+	if _, err = s.changeHireStatus(ctx, data, hire.StatusCancelled); !errors.Is(err, market.ErrorConflict) {
+		t.Fatalf("changeHireStatus() error = %v, want %v", err, market.ErrorConflict)
+	}
+
+	if data, _ = repositories.Hire.Get(ctx, id); data.Status != hire.StatusInProgress {
+		t.Errorf("status = %q, want %q", data.Status, hire.StatusInProgress)
+	}
+	if history, _ := repositories.HireHistory.List(ctx, id); len(history) != 1 {
+		t.Errorf("history = %d records, want 1", len(history))
+	}
+
+	// the hire returned by the transition carries the new version to the next one
+	if _, err = s.changeHireStatus(ctx, changed, hire.StatusDisputed); err != nil {
+		t.Errorf("changeHireStatus() error = %v", err)
+	}
+}
+
+func TestResolveHire(t *testing.T) {
+	repositories := newMemoryRepositories(t)
+	s := newService(t, repositories)
+	ctx, id := addHire(t, repositories, hire.StatusDisputed)
+
+	if _, err := s.ResolveHire(ctx, id); !errors.Is(err, market.ErrorForbidden) {
+		t.Fatalf("ResolveHire() error = %v, want %v", err, market.ErrorForbidden)
+	}
+
+	admin := user.ContextWithActor(context.Background(), user.Actor{ID: "admin", Role: user.RoleAdmin})
+	res, err := s.ResolveHire(admin, id)
+	if err != nil {
+		t.Fatalf("ResolveHire() error = %v", err)
+	}
+	if res.Status != hire.StatusInProgress {
+		t.Errorf("Status = %q, want %q", res.Status, hire.StatusInProgress)
+	}
+}
@@ -0,0 +1,170 @@
+package hiring
+
+import (
+	"context"
+	"encoding/json"
+	"exchanger/internal/domain/audit"
+	"exchanger/internal/domain/event"
+	"exchanger/pkg/log"
+	"go.uber.org/zap"
+	"sync"
+	"time"
+)
+
+// eventTypes are the domain events the changes of the entities are published as, the purges have none
+var eventTypes = map[string]map[string]string{
+	audit.EntityCustomer: {
+		audit.ActionAdd:     event.CustomerCreated,
+		audit.ActionUpdate:  event.CustomerUpdated,
+		audit.ActionDelete:  event.CustomerDeleted,
+		audit.ActionRestore: event.CustomerRestored,
+	},
+	audit.EntityWorker: {
+		audit.ActionAdd:     event.WorkerRegistered,
+		audit.ActionUpdate:  event.WorkerUpdated,
+		audit.ActionDelete:  event.WorkerDeleted,
+		audit.ActionRestore: event.WorkerRestored,
+	},
+	audit.EntityHire: {
+		audit.ActionAdd:     event.HireCreated,
+		audit.ActionUpdate:  event.HireUpdated,
+		audit.ActionDelete:  event.HireDeleted,
+		audit.ActionRestore: event.HireRestored,
+	},
+}
+
+// emit writes the event of the change to the outbox with the entity after it as the payload,
+// the event is published by the relay once the unit of work it's written in is committed
+func (s *Service) emit(ctx context.Context, entity, id, action string, after any) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("emit").With(zap.String("entity", entity), zap.String("id", id))
+
+	eventType, ok := eventTypes[entity][action]
+	if s.outboxRepository == nil || !ok {
+		return
+	}
+
+	payload, err := json.Marshal(after)
+	if err != nil {
+		logger.Error("failed to marshal", zap.Error(err))
+		return
+	}
+
+	data := event.Entity{
+		Type:      eventType,
+		EntityID:  id,
+		Payload:   string(payload),
+		CreatedAt: time.Now(),
+	}
+
+	if _, err = s.outboxRepository.Add(ctx, data); err != nil {
+		logger.Error("failed to add", zap.Error(err))
+	}
+
+	return
+}
+
+// OutboxRelay publishes the pending events of the outbox in the background in the order they were written,
+// the event that fails to publish holds the ones after it back until the next run
+type OutboxRelay struct {
+	service   *Service
+	publisher event.Publisher
+	interval  time.Duration
+	batch     int
+
+	cancel   context.CancelFunc
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewOutboxRelay returns the relay publishing up to batch pending events through the publisher every interval
+func (s *Service) NewOutboxRelay(publisher event.Publisher, interval time.Duration, batch int) *OutboxRelay {
+	return &OutboxRelay{
+		service:   s,
+		publisher: publisher,
+		interval:  interval,
+		batch:     batch,
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+}
+
+// Start runs the relay in a goroutine until Stop is called
+func (r *OutboxRelay) Start(ctx context.Context) {
+	ctx, r.cancel = context.WithCancel(ctx)
+
+	go func() {
+		defer close(r.done)
+
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		for {
+			r.Relay(ctx)
+
+			select {
+			case <-r.stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop waits for the running relay to finish, the relay is cancelled once the context is done
+func (r *OutboxRelay) Stop(ctx context.Context) (err error) {
+	if r.cancel == nil {
+		return
+	}
+
+	r.stopOnce.Do(func() {
+		close(r.stop)
+	})
+
+	select {
+	case <-r.done:
+	case <-ctx.Done():
+		r.cancel()
+		<-r.done
+		err = ctx.Err()
+	}
+	r.cancel()
+
+	return
+}
+
+// Relay publishes the pending events up to the batch and returns how many were published
+func (r *OutboxRelay) Relay(ctx context.Context) (published int) {
+	logger := log.LoggerFromContext(ctx).Named("OutboxRelay")
+
+	outbox := r.service.outboxRepository
+	if outbox == nil {
+		return
+	}
+
+	data, err := outbox.ListPending(ctx, r.batch)
+	if err != nil {
+		logger.Error("failed to select pending events", zap.Error(err))
+		return
+	}
+
+	for _, object := range data {
+		if err = r.publisher.Publish(ctx, event.ParseFromEntity(object)); err != nil {
+			logger.Warn("failed to publish", zap.String("id", object.ID), zap.String("type", object.Type), zap.Error(err))
+
+			if err = outbox.MarkFailed(ctx, object.ID, err.Error()); err != nil {
+				logger.Error("failed to mark failed", zap.String("id", object.ID), zap.Error(err))
+			}
+			return
+		}
+
+		// the event published but not marked is published again on the next run
+		if err = outbox.MarkPublished(ctx, object.ID, time.Now()); err != nil {
+			logger.Error("failed to mark published", zap.String("id", object.ID), zap.Error(err))
+			return
+		}
+		published++
+	}
+
+	return
+}
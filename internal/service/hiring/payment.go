@@ -0,0 +1,454 @@
+package hiring
+
+import (
+	"context"
+	"exchanger/internal/domain/hire"
+	"exchanger/internal/domain/payment"
+	"exchanger/internal/domain/proposal"
+	"exchanger/internal/provider/epay"
+	"exchanger/pkg/log"
+	"exchanger/pkg/market"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// FundHire creates the escrow payment of the hire amount the customer pays on the payment page,
+// the draft hire is published once the payment is funded
+func (s *Service) FundHire(ctx context.Context, hireID string) (res payment.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("FundHire").With(zap.String("hire_id", hireID))
+
+	if s.epayClient == nil {
+		err = ErrorPaymentDisabled
+		return
+	}
+
+	if err = s.authorizeHire(ctx, hireID); err != nil {
+		return
+	}
+
+	object, err := s.hireRepository.Get(ctx, hireID)
+	if err != nil {
+		logger.Error("failed to get hire", zap.Error(err))
+		return
+	}
+
+	if object.Status != hire.StatusDraft && object.Status != hire.StatusOpen && object.Status != hire.StatusInProgress {
+		err = ErrorHireUnfundable
+		return
+	}
+
+	// the terminal takes whole amounts of its own currency only
+	currency := s.epayClient.Currency()
+	if *object.Currency != currency || !object.Amount.IsInteger() {
+		err = errors.Wrapf(ErrorHireUnfundable, "budget must be a whole amount of %s", currency)
+		return
+	}
+	amount := int(object.Amount.IntPart())
+
+	createdAt := time.Now()
+	data := payment.Entity{
+		HireID:     hireID,
+		CustomerID: object.CustomerID,
+		Amount:     &amount,
+		Currency:   &currency,
+		Status:     payment.StatusPending,
+		CreatedAt:  createdAt,
+		ExpiresAt:  createdAt.Add(s.paymentTTL),
+	}
+
+	// the invoice id is random, the id taken by another payment is drawn again
+	for attempt := 1; ; attempt++ {
+		if data.InvoiceID, err = s.newInvoiceID(); err != nil {
+			logger.Error("failed to generate invoice id", zap.Error(err))
+			return
+		}
+
+		if err = s.addPayment(ctx, data); !errors.Is(err, market.ErrorAlreadyExists) || attempt == invoiceAttempts {
+			break
+		}
+		logger.Warn("invoice id is taken", zap.String("invoice_id", data.InvoiceID))
+	}
+	if err != nil {
+		if errors.Is(err, market.ErrorAlreadyExists) {
+			logger.Error("failed to find a free invoice id", zap.Error(err))
+		}
+		return
+	}
+	res = payment.ParseFromEntity(data)
+
+	return
+}
+
+// addPayment adds the payment unless the hire has an active one already, the stores keep one active payment
+// per hire, so the concurrent requests can't both pass the check
+func (s *Service) addPayment(ctx context.Context, data payment.Entity) error {
+	logger := log.LoggerFromContext(ctx).Named("addPayment").With(zap.String("hire_id", data.HireID))
+
+	return s.inTransaction(ctx, func(ctx context.Context) (err error) {
+		payments, err := s.paymentRepository.List(ctx, data.HireID)
+		if err != nil {
+			logger.Error("failed to select payments", zap.Error(err))
+			return
+		}
+
+		for _, object := range payments {
+			if payment.IsActive(object.Status) {
+				return ErrorPaymentExists
+			}
+		}
+
+		if err = s.paymentRepository.Add(ctx, data); err != nil {
+			if errors.Is(err, payment.ErrorActivePayment) {
+				return ErrorPaymentExists
+			}
+			if !errors.Is(err, market.ErrorAlreadyExists) {
+				logger.Error("failed to add", zap.Error(err))
+			}
+		}
+
+		return
+	})
+}
+
+func (s *Service) ListPayments(ctx context.Context, hireID string) (res []payment.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("ListPayments").With(zap.String("hire_id", hireID))
+
+	if err = s.authorizeHire(ctx, hireID); err != nil {
+		return
+	}
+
+	data, err := s.paymentRepository.List(ctx, hireID)
+	if err != nil {
+		logger.Error("failed to select", zap.Error(err))
+		return
+	}
+	res = payment.ParseFromEntities(data)
+
+	return
+}
+
+// GetPayment returns the payment with the status of the pending one checked at the provider
+func (s *Service) GetPayment(ctx context.Context, invoiceID string) (res payment.Response, err error) {
+	data, err := s.getOwnPayment(ctx, invoiceID)
+	if err != nil {
+		return
+	}
+
+	if data.Status == payment.StatusPending && s.epayClient != nil {
+		data = s.syncPayment(ctx, data)
+	}
+	res = payment.ParseFromEntity(data)
+
+	return
+}
+
+// RenderPaymentPage writes the payment page of the provider, or the page of the payment status once it's paid
+func (s *Service) RenderPaymentPage(ctx context.Context, w http.ResponseWriter, invoiceID string) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("RenderPaymentPage").With(zap.String("invoice_id", invoiceID))
+
+	if s.epayClient == nil {
+		return ErrorPaymentDisabled
+	}
+
+	data, err := s.getOwnPayment(ctx, invoiceID)
+	if err != nil {
+		return
+	}
+
+	src := s.epayClient.NewPaymentRequest(data.InvoiceID, strconv.Itoa(*data.Amount), "hire "+data.HireID)
+	src.AccountID = data.CustomerID
+	src.Status.Transaction.StatusName = data.ProviderStatus
+
+	if err = s.epayClient.PayByPaymentPage(ctx, w, src, data.ExpiresAt); err != nil {
+		logger.Error("failed to render payment page", zap.Error(err))
+		return
+	}
+
+	return
+}
+
+// releasePayment hands the funded payment of the completed hire over to the worker of its accepted proposal
+func (s *Service) releasePayment(ctx context.Context, hireID string) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("releasePayment").With(zap.String("hire_id", hireID))
+
+	payments, err := s.paymentRepository.List(ctx, hireID)
+	if err != nil {
+		logger.Error("failed to select payments", zap.Error(err))
+		return
+	}
+
+	q := market.NewQuery().Where("status", proposal.StatusAccepted)
+	proposals, _, err := s.proposalRepository.List(ctx, hireID, q)
+	if err != nil {
+		logger.Error("failed to select proposals", zap.Error(err))
+		return
+	}
+
+	if len(proposals) == 0 {
+		return
+	}
+
+	for _, data := range payments {
+		if data.Status != payment.StatusFunded {
+			continue
+		}
+
+		releasedAt := time.Now()
+		update := payment.Entity{
+			WorkerID:   proposals[0].WorkerID,
+			Status:     payment.StatusReleased,
+			ReleasedAt: &releasedAt,
+		}
+
+		if err = s.paymentRepository.Update(ctx, data.InvoiceID, update); err != nil {
+			logger.Error("failed to release", zap.String("invoice_id", data.InvoiceID), zap.Error(err))
+			return
+		}
+	}
+
+	return
+}
+
+// syncPayment verifies the pending payment at the provider, the payment stays as it is
+// while the provider doesn't know the transaction yet
+func (s *Service) syncPayment(ctx context.Context, data payment.Entity) (dest payment.Entity) {
+	logger := log.LoggerFromContext(ctx).Named("syncPayment").With(zap.String("invoice_id", data.InvoiceID))
+
+	dest, err := s.verifyPayment(ctx, data)
+	if err != nil {
+		logger.Warn("failed to verify", zap.Error(err))
+		return data
+	}
+
+	return
+}
+
+// verifyPayment updates the payment with the transaction the provider reports for its invoice,
+// a repeated status leaves the payment untouched so that the provider can notify any number of times
+func (s *Service) verifyPayment(ctx context.Context, data payment.Entity) (dest payment.Entity, err error) {
+	logger := log.LoggerFromContext(ctx).Named("verifyPayment").With(zap.String("invoice_id", data.InvoiceID))
+
+	dest = data
+
+	status, err := s.epayClient.GetStatus(ctx, s.epayClient.GlobalToken(), data.InvoiceID)
+	if err != nil {
+		return
+	}
+	transaction := status.Transaction
+
+	if !s.matchTransaction(data, transaction) {
+		logger.Warn("transaction doesn't match", zap.Any("transaction", transaction))
+		err = ErrorPaymentMismatch
+		return
+	}
+
+	providerStatus := transaction.StatusName
+	if providerStatus == "" || providerStatus == data.ProviderStatus {
+		return
+	}
+
+	update := payment.Entity{
+		ProviderStatus: providerStatus,
+	}
+	// the released payment keeps its status, the provider only knows it's charged
+	if data.Status != payment.StatusReleased {
+		update.Status = payment.StatusOf(providerStatus)
+	}
+
+	dest.ProviderStatus = update.ProviderStatus
+	if update.Status != "" {
+		dest.Status = update.Status
+	}
+
+	// the hire follows the payment in the same transaction
+	err = s.inTransaction(ctx, func(ctx context.Context) (err error) {
+		if err = s.paymentRepository.Update(ctx, data.InvoiceID, update); err != nil {
+			logger.Error("failed to update by id", zap.Error(err))
+			return
+		}
+
+		return s.updatePaidHire(ctx, data.Status, dest)
+	})
+	if err != nil {
+		dest = data
+	}
+
+	return
+}
+
+// reconcilePayment verifies the unsettled payment at the provider and expires the one still pending after it was due,
+// the overdue payment expires even when the provider can't tell its transaction since the payment page is closed by then
+func (s *Service) reconcilePayment(ctx context.Context, data payment.Entity, now time.Time) (dest payment.Entity, err error) {
+	logger := log.LoggerFromContext(ctx).Named("reconcilePayment").With(zap.String("invoice_id", data.InvoiceID))
+
+	dest, err = s.verifyPayment(ctx, data)
+	if !payment.IsOverdue(dest, now) {
+		return
+	}
+
+	if err != nil {
+		logger.Warn("failed to verify overdue payment", zap.Error(err))
+	}
+
+	// the payment is read again in the transaction, so that the one a callback has just settled isn't expired,
+	// and the hire the payment opened goes back with it
+	err = s.inTransaction(ctx, func(ctx context.Context) (err error) {
+		current, err := s.paymentRepository.Get(ctx, data.InvoiceID)
+		if err != nil {
+			logger.Error("failed to get", zap.Error(err))
+			return
+		}
+
+		dest = current
+		if !payment.IsOverdue(current, now) {
+			return
+		}
+
+		update := payment.Entity{
+			Status:         payment.StatusExpired,
+			ProviderStatus: payment.ProviderStatusExpired,
+		}
+		if err = s.paymentRepository.Update(ctx, current.InvoiceID, update); err != nil {
+			logger.Error("failed to expire", zap.Error(err))
+			return
+		}
+		dest.Status = update.Status
+		dest.ProviderStatus = update.ProviderStatus
+
+		return s.updatePaidHire(ctx, current.Status, dest)
+	})
+	if err != nil {
+		dest = data
+	}
+
+	return
+}
+
+// updatePaidHire follows the hire of the payment whose status changed from the previous one,
+// the hire the expired payment was to fund is taken out of work as the one of the cancelled payment
+func (s *Service) updatePaidHire(ctx context.Context, previous string, data payment.Entity) (err error) {
+	switch {
+	case data.Status == payment.StatusFunded && previous != payment.StatusFunded:
+		err = s.publishFundedHire(ctx, data.HireID)
+	case previous == payment.StatusFunded && !payment.IsActive(data.Status),
+		data.Status == payment.StatusExpired && previous != payment.StatusExpired:
+		err = s.revokeFundedHire(ctx, data.HireID)
+	}
+
+	return
+}
+
+// matchTransaction checks that the transaction the provider reports is the one of the payment
+func (s *Service) matchTransaction(data payment.Entity, transaction epay.TransactionResponse) bool {
+	return transaction.InvoiceID == data.InvoiceID &&
+		transaction.Amount == *data.Amount &&
+		(transaction.Currency == "" || transaction.Currency == *data.Currency) &&
+		(transaction.Terminal == "" || transaction.Terminal == s.epayClient.TerminalID())
+}
+
+// publishFundedHire opens the draft hire to proposals once its escrow is funded
+func (s *Service) publishFundedHire(ctx context.Context, hireID string) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("publishFundedHire").With(zap.String("hire_id", hireID))
+
+	data, err := s.hireRepository.Get(ctx, hireID)
+	if err != nil {
+		logger.Error("failed to get hire", zap.Error(err))
+		return
+	}
+
+	if data.Status != hire.StatusDraft {
+		return
+	}
+	_, err = s.changeHireStatus(ctx, data, hire.StatusOpen)
+
+	return
+}
+
+// revokeFundedHire takes the hire whose escrow was cancelled by the provider out of work,
+// the open hire goes back to draft and the hire in progress waits for an admin as disputed
+func (s *Service) revokeFundedHire(ctx context.Context, hireID string) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("revokeFundedHire").With(zap.String("hire_id", hireID))
+
+	data, err := s.hireRepository.Get(ctx, hireID)
+	if err != nil {
+		logger.Error("failed to get hire", zap.Error(err))
+		return
+	}
+
+	switch data.Status {
+	case hire.StatusOpen:
+		_, err = s.changeHireStatus(ctx, data, hire.StatusDraft)
+	case hire.StatusInProgress:
+		_, err = s.changeHireStatus(ctx, data, hire.StatusDisputed)
+	}
+
+	return
+}
+
+// HandleEpayCallback stores the raw notification of epay and applies the transaction it is about,
+// the transaction is read back from epay since the notification isn't signed
+func (s *Service) HandleEpayCallback(ctx context.Context, body []byte, remoteAddr string) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("HandleEpayCallback").With(zap.String("remote_addr", remoteAddr))
+
+	notification, parseErr := epay.ParseNotification(body)
+
+	callback := payment.CallbackEntity{
+		InvoiceID:  notification.InvoiceID,
+		Body:       string(body),
+		RemoteAddr: remoteAddr,
+		ReceivedAt: time.Now(),
+	}
+	if _, err = s.callbackRepository.Add(ctx, callback); err != nil {
+		logger.Error("failed to add callback", zap.Error(err))
+		return
+	}
+
+	if parseErr != nil {
+		logger.Warn("failed to parse callback", zap.Error(parseErr))
+		err = errors.Wrap(ErrorInvalidCallback, parseErr.Error())
+		return
+	}
+
+	if s.epayClient == nil {
+		err = ErrorPaymentDisabled
+		return
+	}
+	logger = logger.With(zap.String("invoice_id", notification.InvoiceID))
+
+	data, err := s.paymentRepository.Get(ctx, notification.InvoiceID)
+	if err != nil {
+		if !errors.Is(err, market.ErrorNotFound) {
+			logger.Error("failed to get", zap.Error(err))
+		}
+		return
+	}
+
+	if _, err = s.verifyPayment(ctx, data); err != nil {
+		if !errors.Is(err, ErrorPaymentMismatch) {
+			logger.Error("failed to verify", zap.Error(err))
+		}
+		return
+	}
+
+	return
+}
+
+// getOwnPayment returns the payment of the hire the actor of the context owns
+func (s *Service) getOwnPayment(ctx context.Context, invoiceID string) (data payment.Entity, err error) {
+	logger := log.LoggerFromContext(ctx).Named("getOwnPayment").With(zap.String("invoice_id", invoiceID))
+
+	data, err = s.paymentRepository.Get(ctx, invoiceID)
+	if err != nil {
+		if !errors.Is(err, market.ErrorNotFound) {
+			logger.Error("failed to get", zap.Error(err))
+		}
+		return
+	}
+
+	err = s.authorizeCustomer(ctx, data.CustomerID)
+
+	return
+}
@@ -0,0 +1,266 @@
+package hiring
+
+import (
+	"context"
+	"exchanger/internal/domain/hire"
+	"exchanger/internal/domain/payment"
+	"exchanger/internal/domain/user"
+	"exchanger/internal/provider/epay"
+	"exchanger/pkg/log"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"time"
+)
+
+// ChargePayment captures the authorized amount of the payment, zero amount captures the whole of it
+// and the rest of a partial charge goes back to the customer, the route is left to admins
+func (s *Service) ChargePayment(ctx context.Context, invoiceID string, amount int) (res payment.Response, err error) {
+	return s.operatePayment(ctx, invoiceID, payment.OperationCharge, amount)
+}
+
+// CancelPayment releases the authorized amount of the payment back to the customer
+func (s *Service) CancelPayment(ctx context.Context, invoiceID string) (res payment.Response, err error) {
+	return s.operatePayment(ctx, invoiceID, payment.OperationCancel, 0)
+}
+
+// RefundPayment returns the charged amount of the payment to the customer, zero amount refunds all that is left
+func (s *Service) RefundPayment(ctx context.Context, invoiceID string, amount int) (res payment.Response, err error) {
+	return s.operatePayment(ctx, invoiceID, payment.OperationRefund, amount)
+}
+
+func (s *Service) ListPaymentHistory(ctx context.Context, invoiceID string) (res []payment.HistoryResponse, err error) {
+	logger := log.LoggerFromContext(ctx).Named("ListPaymentHistory").With(zap.String("invoice_id", invoiceID))
+
+	if _, err = s.getOwnPayment(ctx, invoiceID); err != nil {
+		return
+	}
+
+	data, err := s.paymentHistoryRepository.List(ctx, invoiceID)
+	if err != nil {
+		logger.Error("failed to select", zap.Error(err))
+		return
+	}
+	res = payment.ParseFromHistoryEntities(data)
+
+	return
+}
+
+// operatePayment makes the operation on the transaction of the payment after the amount is checked
+// against the transaction the provider reports, the operation is recorded in the payment history
+func (s *Service) operatePayment(ctx context.Context, invoiceID, operation string, amount int) (res payment.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("operatePayment").
+		With(zap.String("invoice_id", invoiceID), zap.String("operation", operation))
+
+	if s.epayClient == nil {
+		err = ErrorPaymentDisabled
+		return
+	}
+
+	data, err := s.getOwnPayment(ctx, invoiceID)
+	if err != nil {
+		return
+	}
+
+	if err = s.authorizePaymentReturn(ctx, data); err != nil {
+		return
+	}
+
+	if data.Status != payment.StatusFunded {
+		err = errors.Wrap(ErrorPaymentState, data.Status)
+		return
+	}
+
+	status, err := s.epayClient.GetStatus(ctx, s.epayClient.GlobalToken(), data.InvoiceID)
+	if err != nil {
+		logger.Error("failed to get status", zap.Error(err))
+		return
+	}
+	transaction := status.Transaction
+
+	if !s.matchTransaction(data, transaction) {
+		logger.Warn("transaction doesn't match", zap.Any("transaction", transaction))
+		err = ErrorPaymentMismatch
+		return
+	}
+
+	// the amounts already charged and refunded come from the operations made, the stored totals may lag behind
+	operations, err := s.paymentHistoryRepository.List(ctx, data.InvoiceID)
+	if err != nil {
+		logger.Error("failed to select history", zap.Error(err))
+		return
+	}
+
+	update, amount, err := preparePaymentOperation(data, operations, transaction, operation, amount)
+	if err != nil {
+		return
+	}
+
+	token := s.epayClient.GlobalToken()
+	switch operation {
+	case payment.OperationCharge:
+		err = s.epayClient.Charge(ctx, token, transaction.ID, amount)
+	case payment.OperationCancel:
+		err = s.epayClient.Cancel(ctx, token, transaction.ID)
+	case payment.OperationRefund:
+		err = s.epayClient.Refund(ctx, token, transaction.ID, amount)
+	}
+	if err != nil {
+		logger.Error("failed to operate", zap.Error(err))
+		return
+	}
+
+	dest := data
+	dest.ProviderStatus = update.ProviderStatus
+	if update.Status != "" {
+		dest.Status = update.Status
+	}
+	if update.ChargedAmount != nil {
+		dest.ChargedAmount = update.ChargedAmount
+	}
+	if update.RefundedAmount != nil {
+		dest.RefundedAmount = update.RefundedAmount
+	}
+
+	history := payment.HistoryEntity{
+		InvoiceID:  data.InvoiceID,
+		Operation:  operation,
+		Amount:     amount,
+		FromStatus: data.ProviderStatus,
+		ToStatus:   dest.ProviderStatus,
+		CreatedAt:  time.Now(),
+	}
+	if actor, ok := user.ActorFromContext(ctx); ok {
+		history.UserID = actor.ID
+	}
+
+	// the operation made at the provider is recorded with its history and the hire following it at once
+	err = s.inTransaction(ctx, func(ctx context.Context) (err error) {
+		if err = s.paymentRepository.Update(ctx, data.InvoiceID, update); err != nil {
+			logger.Error("failed to update by id", zap.Error(err))
+			return
+		}
+
+		if _, err = s.paymentHistoryRepository.Add(ctx, history); err != nil {
+			logger.Error("failed to add history", zap.Error(err))
+			return
+		}
+
+		return s.updatePaidHire(ctx, data.Status, dest)
+	})
+	if err != nil {
+		return
+	}
+	res = payment.ParseFromEntity(dest)
+
+	return
+}
+
+// preparePaymentOperation checks that the operation fits the transaction and the operations already made on it
+// and returns the payment update with the amount the operation is made for
+func preparePaymentOperation(data payment.Entity, operations []payment.HistoryEntity, transaction epay.TransactionResponse, operation string, amount int) (update payment.Entity, dest int, err error) {
+	if amount < 0 {
+		err = ErrorPaymentAmount
+		return
+	}
+	charged, refunded := paidAmounts(data, operations, transaction)
+
+	switch operation {
+	case payment.OperationCharge, payment.OperationCancel:
+		if transaction.StatusName != "AUTH" || charged > 0 {
+			err = errors.Wrap(ErrorPaymentState, transaction.StatusName)
+			return
+		}
+
+		dest = transaction.Amount
+		if operation == payment.OperationCancel {
+			update.ProviderStatus = "CANCEL"
+			update.Status = payment.StatusRefunded
+			return
+		}
+		update.ProviderStatus = "CHARGE"
+
+		if amount != 0 {
+			dest = amount
+		}
+		if dest > transaction.Amount {
+			err = ErrorPaymentAmount
+			return
+		}
+		update.ChargedAmount = &dest
+	case payment.OperationRefund:
+		if transaction.StatusName != "CHARGE" && transaction.StatusName != "REFUND" {
+			err = errors.Wrap(ErrorPaymentState, transaction.StatusName)
+			return
+		}
+
+		remaining := charged - refunded
+		if remaining <= 0 {
+			err = errors.Wrap(ErrorPaymentState, "nothing left to refund")
+			return
+		}
+
+		dest = remaining
+		if amount != 0 {
+			dest = amount
+		}
+		if dest > remaining {
+			err = ErrorPaymentAmount
+			return
+		}
+		update.ProviderStatus = "REFUND"
+
+		refunded += dest
+		update.RefundedAmount = &refunded
+		if refunded == charged {
+			update.Status = payment.StatusRefunded
+		}
+	}
+
+	return
+}
+
+// paidAmounts sums the amounts charged and refunded by the operations made on the payment, the transaction
+// charged without an operation recorded counts as charged in whole
+func paidAmounts(data payment.Entity, operations []payment.HistoryEntity, transaction epay.TransactionResponse) (charged, refunded int) {
+	for _, operation := range operations {
+		switch operation.Operation {
+		case payment.OperationCharge:
+			charged += operation.Amount
+		case payment.OperationRefund:
+			refunded += operation.Amount
+		}
+	}
+
+	if charged == 0 && transaction.StatusName != "AUTH" {
+		charged = transaction.Amount
+		if data.ChargedAmount != nil {
+			charged = *data.ChargedAmount
+		}
+	}
+
+	return
+}
+
+// authorizePaymentReturn leaves the payment of the hire in work to admins, the customer can take the payment
+// back only before a worker is chosen or once the hire is cancelled
+func (s *Service) authorizePaymentReturn(ctx context.Context, data payment.Entity) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("authorizePaymentReturn").With(zap.String("hire_id", data.HireID))
+
+	if actor, _ := user.ActorFromContext(ctx); actor.IsAdmin() {
+		return
+	}
+
+	object, err := s.hireRepository.Get(ctx, data.HireID)
+	if err != nil {
+		logger.Error("failed to get hire", zap.Error(err))
+		return
+	}
+
+	switch object.Status {
+	case hire.StatusDraft, hire.StatusOpen, hire.StatusCancelled:
+	default:
+		err = ErrorPaymentInWork
+	}
+
+	return
+}
@@ -0,0 +1,132 @@
+package hiring
+
+import (
+	"errors"
+	"exchanger/internal/domain/payment"
+	"exchanger/internal/provider/epay"
+	"testing"
+)
+
+func TestPreparePaymentOperation(t *testing.T) {
+	charge := func(amount int) payment.HistoryEntity {
+		return payment.HistoryEntity{Operation: payment.OperationCharge, Amount: amount}
+	}
+	refund := func(amount int) payment.HistoryEntity {
+		return payment.HistoryEntity{Operation: payment.OperationRefund, Amount: amount}
+	}
+
+	tests := []struct {
+		name       string
+		operations []payment.HistoryEntity
+		status     string
+		operation  string
+		amount     int
+		want       int
+		refunded   int
+		wantStatus string
+		wantErr    error
+	}{
+		{name: "whole charge", status: "AUTH", operation: payment.OperationCharge, want: 500},
+		{name: "partial charge", status: "AUTH", operation: payment.OperationCharge, amount: 300, want: 300},
+		{name: "charge above the authorized amount", status: "AUTH", operation: payment.OperationCharge, amount: 501, wantErr: ErrorPaymentAmount},
+		{name: "second charge", operations: []payment.HistoryEntity{charge(300)}, status: "AUTH", operation: payment.OperationCharge, wantErr: ErrorPaymentState},
+		{name: "negative amount", status: "AUTH", operation: payment.OperationCharge, amount: -1, wantErr: ErrorPaymentAmount},
+		{name: "cancel", status: "AUTH", operation: payment.OperationCancel, want: 500, wantStatus: payment.StatusRefunded},
+		{name: "cancel of the charged payment", status: "CHARGE", operation: payment.OperationCancel, wantErr: ErrorPaymentState},
+		{name: "refund of the authorized payment", status: "AUTH", operation: payment.OperationRefund, wantErr: ErrorPaymentState},
+		{
+			name:       "whole refund",
+			operations: []payment.HistoryEntity{charge(500)},
+			status:     "CHARGE",
+			operation:  payment.OperationRefund,
+			want:       500,
+			refunded:   500,
+			wantStatus: payment.StatusRefunded,
+		},
+		{
+			name:       "partial refund",
+			operations: []payment.HistoryEntity{charge(500)},
+			status:     "CHARGE",
+			operation:  payment.OperationRefund,
+			amount:     200,
+			want:       200,
+			refunded:   200,
+		},
+		{
+			name:       "rest of the partial refunds",
+			operations: []payment.HistoryEntity{charge(500), refund(200), refund(100)},
+			status:     "REFUND",
+			operation:  payment.OperationRefund,
+			want:       200,
+			refunded:   500,
+			wantStatus: payment.StatusRefunded,
+		},
+		{
+			name:       "refund of the partial charge",
+			operations: []payment.HistoryEntity{charge(300)},
+			status:     "CHARGE",
+			operation:  payment.OperationRefund,
+			want:       300,
+			refunded:   300,
+			wantStatus: payment.StatusRefunded,
+		},
+		{
+			name:       "refund above the partial charge",
+			operations: []payment.HistoryEntity{charge(300)},
+			status:     "CHARGE",
+			operation:  payment.OperationRefund,
+			amount:     301,
+			wantErr:    ErrorPaymentAmount,
+		},
+		{
+			name:       "refund above the rest",
+			operations: []payment.HistoryEntity{charge(500), refund(400)},
+			status:     "REFUND",
+			operation:  payment.OperationRefund,
+			amount:     101,
+			wantErr:    ErrorPaymentAmount,
+		},
+		{
+			name:       "refund of the refunded payment",
+			operations: []payment.HistoryEntity{charge(500), refund(300), refund(200)},
+			status:     "REFUND",
+			operation:  payment.OperationRefund,
+			amount:     1,
+			wantErr:    ErrorPaymentState,
+		},
+		{
+			name:       "refund of the payment charged without an operation",
+			status:     "CHARGE",
+			operation:  payment.OperationRefund,
+			want:       500,
+			refunded:   500,
+			wantStatus: payment.StatusRefunded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			amount := 500
+			data := payment.Entity{InvoiceID: "000000000000001", Amount: &amount}
+			transaction := epay.TransactionResponse{InvoiceID: data.InvoiceID, Amount: amount, StatusName: tt.status}
+
+			update, got, err := preparePaymentOperation(data, tt.operations, transaction, tt.operation, tt.amount)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("preparePaymentOperation() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if got != tt.want {
+				t.Errorf("amount = %d, want %d", got, tt.want)
+			}
+			if update.Status != tt.wantStatus {
+				t.Errorf("Status = %q, want %q", update.Status, tt.wantStatus)
+			}
+			if tt.operation == payment.OperationRefund && (update.RefundedAmount == nil || *update.RefundedAmount != tt.refunded) {
+				t.Errorf("RefundedAmount = %v, want %d", update.RefundedAmount, tt.refunded)
+			}
+		})
+	}
+}
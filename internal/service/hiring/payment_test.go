@@ -0,0 +1,74 @@
+package hiring
+
+import (
+	"errors"
+	"exchanger/internal/domain/hire"
+	"exchanger/internal/domain/payment"
+	"exchanger/pkg/market"
+	"testing"
+	"time"
+)
+
+func TestFundHireInvoiceID(t *testing.T) {
+	const taken, free = "000000000000001", "000000000000002"
+
+	tests := []struct {
+		name    string
+		ids     []string
+		active  bool
+		want    string
+		wantErr error
+	}{
+		{name: "taken id is drawn again", ids: []string{taken, free}, want: free},
+		{name: "ids run out", ids: []string{taken}, wantErr: market.ErrorAlreadyExists},
+		{name: "active payment of the hire", ids: []string{free}, active: true, wantErr: ErrorPaymentExists},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repositories := newMemoryRepositories(t)
+			_, epayClient := newEpayClient(t)
+			s := newService(t, repositories, WithEpayClient(epayClient))
+			ctx, id := addHire(t, repositories, hire.StatusDraft)
+
+			// the taken id belongs to the finished payment of another hire
+			amount, currency := 500, "KZT"
+			status, hireID := payment.StatusFailed, "other"
+			if tt.active {
+				status, hireID = payment.StatusPending, id
+			}
+			err := repositories.Payment.Add(ctx, payment.Entity{
+				InvoiceID: taken,
+				HireID:    hireID,
+				Amount:    &amount,
+				Currency:  &currency,
+				Status:    status,
+				CreatedAt: time.Now(),
+			})
+			if err != nil {
+				t.Fatalf("Payment.Add() error = %v", err)
+			}
+
+			// the last id is drawn over and over once the others are used up
+			draws := 0
+			s.newInvoiceID = func() (string, error) {
+				draws++
+				if draws > len(tt.ids) {
+					return tt.ids[len(tt.ids)-1], nil
+				}
+				return tt.ids[draws-1], nil
+			}
+
+			res, err := s.FundHire(ctx, id)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("FundHire() error = %v, want %v", err, tt.wantErr)
+			}
+			if errors.Is(tt.wantErr, market.ErrorAlreadyExists) && (errors.Is(err, ErrorPaymentExists) || draws != invoiceAttempts) {
+				t.Errorf("FundHire() error = %v after %d draws, want the taken id after %d draws", err, draws, invoiceAttempts)
+			}
+			if res.InvoiceID != tt.want {
+				t.Errorf("InvoiceID = %q, want %q", res.InvoiceID, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package hiring
+
+import (
+	"context"
+	"exchanger/internal/domain/hire"
+	"exchanger/internal/domain/proposal"
+	"exchanger/pkg/log"
+	"exchanger/pkg/market"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+func (s *Service) ListProposals(ctx context.Context, hireID string, q market.Query) (res []proposal.Response, page market.Page, err error) {
+	logger := log.LoggerFromContext(ctx).Named("ListProposals").With(zap.String("hire_id", hireID))
+
+	if err = s.authorizeHire(ctx, hireID); err != nil {
+		return
+	}
+
+	data, total, err := s.proposalRepository.List(ctx, hireID, q)
+	if err != nil {
+		logger.Error("failed to select", zap.Error(err))
+		return
+	}
+	res = proposal.ParseFromEntities(data)
+	page = q.Page(total)
+
+	return
+}
+
+func (s *Service) AddProposal(ctx context.Context, hireID string, req proposal.Request) (res proposal.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("AddProposal").With(zap.String("hire_id", hireID))
+
+	if err = s.authorizeWorker(ctx, req.WorkerID); err != nil {
+		return
+	}
+
+	object, err := s.hireRepository.Get(ctx, hireID)
+	if err != nil {
+		if !errors.Is(err, market.ErrorNotFound) {
+			logger.Error("failed to get hire", zap.Error(err))
+		}
+		return
+	}
+
+	if object.Status != hire.StatusOpen {
+		err = ErrorHireClosed
+		return
+	}
+
+	data := proposal.Entity{
+		HireID:      hireID,
+		WorkerID:    req.WorkerID,
+		CoverLetter: &req.CoverLetter,
+		Amount:      &req.Amount,
+		Status:      proposal.StatusPending,
+	}
+
+	data.ID, err = s.proposalRepository.Add(ctx, data)
+	if err != nil {
+		if !errors.Is(err, market.ErrorAlreadyExists) {
+			logger.Error("failed to add", zap.Error(err))
+		}
+		return
+	}
+	res = proposal.ParseFromEntity(data)
+
+	return
+}
+
+// AcceptProposal accepts the proposal, rejects the other pending ones and starts the work on the hire
+func (s *Service) AcceptProposal(ctx context.Context, hireID, id string) (res proposal.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("AcceptProposal").With(zap.String("hire_id", hireID), zap.String("id", id))
+
+	data, err := s.getPendingProposal(ctx, hireID, id)
+	if err != nil {
+		return
+	}
+
+	// the hire starts with the accepted proposal only, the other proposals are rejected at once
+	err = s.inTransaction(ctx, func(ctx context.Context) (err error) {
+		object, err := s.hireRepository.Get(ctx, hireID)
+		if err != nil {
+			logger.Error("failed to get hire", zap.Error(err))
+			return
+		}
+
+		if object.Status != hire.StatusOpen {
+			return ErrorHireClosed
+		}
+
+		if _, err = s.changeHireStatus(ctx, object, hire.StatusInProgress); err != nil {
+			return
+		}
+
+		if err = s.proposalRepository.Update(ctx, id, proposal.Entity{Status: proposal.StatusAccepted}); err != nil {
+			logger.Error("failed to update by id", zap.Error(err))
+			return
+		}
+
+		if err = s.rejectPendingProposals(ctx, hireID); err != nil {
+			logger.Error("failed to reject pending proposals", zap.Error(err))
+			return
+		}
+
+		return
+	})
+	if err != nil {
+		return
+	}
+	data.Status = proposal.StatusAccepted
+	res = proposal.ParseFromEntity(data)
+
+	return
+}
+
+func (s *Service) RejectProposal(ctx context.Context, hireID, id string) (res proposal.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("RejectProposal").With(zap.String("hire_id", hireID), zap.String("id", id))
+
+	data, err := s.getPendingProposal(ctx, hireID, id)
+	if err != nil {
+		return
+	}
+
+	data.Status = proposal.StatusRejected
+	if err = s.proposalRepository.Update(ctx, id, proposal.Entity{Status: data.Status}); err != nil {
+		logger.Error("failed to update by id", zap.Error(err))
+		return
+	}
+	res = proposal.ParseFromEntity(data)
+
+	return
+}
+
+// getPendingProposal returns the pending proposal of the hire the actor of the context owns
+func (s *Service) getPendingProposal(ctx context.Context, hireID, id string) (data proposal.Entity, err error) {
+	logger := log.LoggerFromContext(ctx).Named("getPendingProposal").With(zap.String("hire_id", hireID), zap.String("id", id))
+
+	if err = s.authorizeHire(ctx, hireID); err != nil {
+		return
+	}
+
+	data, err = s.proposalRepository.Get(ctx, id)
+	if err != nil {
+		if !errors.Is(err, market.ErrorNotFound) {
+			logger.Error("failed to get", zap.Error(err))
+		}
+		return
+	}
+
+	if data.HireID != hireID {
+		err = market.ErrorNotFound
+		return
+	}
+
+	if data.Status != proposal.StatusPending {
+		err = ErrorProposalDecided
+		return
+	}
+
+	return
+}
+
+// rejectPendingProposals rejects the proposals of the hire still waiting for the customer
+func (s *Service) rejectPendingProposals(ctx context.Context, hireID string) (err error) {
+	q := market.NewQuery().Where("status", proposal.StatusPending)
+	q.Limit = market.MaxLimit
+
+	for {
+		data, _, err := s.proposalRepository.List(ctx, hireID, q)
+		if err != nil || len(data) == 0 {
+			return err
+		}
+
+		for _, object := range data {
+			if err = s.proposalRepository.Update(ctx, object.ID, proposal.Entity{Status: proposal.StatusRejected}); err != nil {
+				return err
+			}
+		}
+	}
+}
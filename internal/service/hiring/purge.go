@@ -0,0 +1,184 @@
+package hiring
+
+import (
+	"context"
+	"exchanger/internal/domain/audit"
+	"exchanger/internal/domain/customer"
+	"exchanger/internal/domain/hire"
+	"exchanger/internal/domain/worker"
+	"exchanger/pkg/log"
+	"exchanger/pkg/market"
+	"go.uber.org/zap"
+	"sync"
+	"time"
+)
+
+// Purger removes the customers, workers and hires for good in the background
+// once they've stayed deleted for longer than the retention
+type Purger struct {
+	service   *Service
+	interval  time.Duration
+	retention time.Duration
+
+	cancel   context.CancelFunc
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewPurger returns the purger looking for the entities deleted longer than retention ago every interval
+func (s *Service) NewPurger(interval, retention time.Duration) *Purger {
+	return &Purger{
+		service:   s,
+		interval:  interval,
+		retention: retention,
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+}
+
+// Start runs the purger in a goroutine until Stop is called
+func (p *Purger) Start(ctx context.Context) {
+	ctx, p.cancel = context.WithCancel(ctx)
+
+	go func() {
+		defer close(p.done)
+
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+
+		for {
+			p.service.PurgeDeleted(ctx, time.Now().Add(-p.retention))
+
+			select {
+			case <-p.stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop waits for the running purge to finish, the purge is cancelled once the context is done
+func (p *Purger) Stop(ctx context.Context) (err error) {
+	if p.cancel == nil {
+		return
+	}
+
+	p.stopOnce.Do(func() {
+		close(p.stop)
+	})
+
+	select {
+	case <-p.done:
+	case <-ctx.Done():
+		p.cancel()
+		<-p.done
+		err = ctx.Err()
+	}
+	p.cancel()
+
+	return
+}
+
+// PurgeDeleted removes the hires, customers and workers deleted before the time for good and returns how many
+// were removed. The hire goes together with its proposals and status history, the hire having payments
+// is kept as well as the customer of any hire and the worker who made any proposal
+func (s *Service) PurgeDeleted(ctx context.Context, before time.Time) (purged int) {
+	logger := log.LoggerFromContext(ctx).Named("PurgeDeleted").With(zap.Time("before", before))
+
+	hires, err := s.hireRepository.ListDeleted(ctx, before)
+	if err != nil {
+		logger.Error("failed to select hires", zap.Error(err))
+	}
+
+	for _, data := range hires {
+		err = s.inTransaction(ctx, func(ctx context.Context) (err error) {
+			payments, err := s.paymentRepository.List(ctx, data.ID)
+			if err != nil || len(payments) > 0 {
+				return
+			}
+
+			if err = s.proposalRepository.DeleteByHire(ctx, data.ID); err != nil {
+				return
+			}
+
+			if err = s.hireHistoryRepository.DeleteByHire(ctx, data.ID); err != nil {
+				return
+			}
+
+			if err = s.hireRepository.Purge(ctx, data.ID); err != nil {
+				return
+			}
+
+			if err = s.audit(ctx, audit.EntityHire, data.ID, audit.ActionPurge, hire.ParseFromEntity(data), nil); err != nil {
+				return
+			}
+			purged++
+
+			return
+		})
+		if err != nil {
+			logger.Warn("failed to purge hire", zap.String("id", data.ID), zap.Error(err))
+		}
+	}
+
+	customers, err := s.customerRepository.ListDeleted(ctx, before)
+	if err != nil {
+		logger.Error("failed to select customers", zap.Error(err))
+	}
+
+	for _, data := range customers {
+		q := market.NewQuery().Where("customer_id", data.ID)
+		q.Limit = 1
+
+		_, total, err := s.hireRepository.List(market.WithDeleted(ctx), q)
+		if err == nil && total == 0 {
+			err = s.inTransaction(ctx, func(ctx context.Context) (err error) {
+				if err = s.customerRepository.Purge(ctx, data.ID); err != nil {
+					return
+				}
+
+				return s.audit(ctx, audit.EntityCustomer, data.ID, audit.ActionPurge, customer.ParseFromEntity(data), nil)
+			})
+		}
+		if err != nil {
+			logger.Warn("failed to purge customer", zap.String("id", data.ID), zap.Error(err))
+			continue
+		}
+		if total == 0 {
+			purged++
+		}
+	}
+
+	workers, err := s.workerRepository.ListDeleted(ctx, before)
+	if err != nil {
+		logger.Error("failed to select workers", zap.Error(err))
+	}
+
+	for _, data := range workers {
+		count, err := s.proposalRepository.CountByWorker(ctx, data.ID)
+		if err == nil && count == 0 {
+			err = s.inTransaction(ctx, func(ctx context.Context) (err error) {
+				if err = s.workerRepository.Purge(ctx, data.ID); err != nil {
+					return
+				}
+
+				return s.audit(ctx, audit.EntityWorker, data.ID, audit.ActionPurge, worker.ParseFromEntity(data), nil)
+			})
+		}
+		if err != nil {
+			logger.Warn("failed to purge worker", zap.String("id", data.ID), zap.Error(err))
+			continue
+		}
+		if count == 0 {
+			purged++
+		}
+	}
+
+	if purged > 0 {
+		logger.Info("purged deleted entities", zap.Int("count", purged))
+	}
+
+	return
+}
@@ -0,0 +1,157 @@
+package hiring
+
+import (
+	"context"
+	"exchanger/internal/domain/payment"
+	"exchanger/pkg/log"
+	"go.uber.org/zap"
+	"sync"
+	"time"
+)
+
+// PaymentReconciler checks the unsettled payments at the provider in the background,
+// so that the payments the provider never notified about don't stay pending or authorized forever
+type PaymentReconciler struct {
+	service    *Service
+	interval   time.Duration
+	maxBackoff time.Duration
+
+	// checks hold the backoff of the payments the provider didn't change on the last check
+	checks map[string]paymentCheck
+
+	cancel   context.CancelFunc
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+type paymentCheck struct {
+	attempts int
+	next     time.Time
+}
+
+// NewPaymentReconciler returns the reconciler checking the payments every interval,
+// a payment that doesn't change waits twice as long before each next check up to maxBackoff
+func (s *Service) NewPaymentReconciler(interval, maxBackoff time.Duration) *PaymentReconciler {
+	if maxBackoff < interval {
+		maxBackoff = interval
+	}
+
+	return &PaymentReconciler{
+		service:    s,
+		interval:   interval,
+		maxBackoff: maxBackoff,
+		checks:     make(map[string]paymentCheck),
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+}
+
+// Start runs the reconciler in a goroutine until Stop is called
+func (r *PaymentReconciler) Start(ctx context.Context) {
+	ctx, r.cancel = context.WithCancel(ctx)
+
+	go func() {
+		defer close(r.done)
+
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		for {
+			r.Reconcile(ctx, time.Now())
+
+			select {
+			case <-r.stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop waits for the running check to finish, the check is cancelled once the context is done
+func (r *PaymentReconciler) Stop(ctx context.Context) (err error) {
+	if r.cancel == nil {
+		return
+	}
+
+	r.stopOnce.Do(func() {
+		close(r.stop)
+	})
+
+	select {
+	case <-r.done:
+	case <-ctx.Done():
+		r.cancel()
+		<-r.done
+		err = ctx.Err()
+	}
+	r.cancel()
+
+	return
+}
+
+// Reconcile checks the unsettled payments which are due at the moment once
+func (r *PaymentReconciler) Reconcile(ctx context.Context, now time.Time) {
+	logger := log.LoggerFromContext(ctx).Named("PaymentReconciler")
+
+	payments, err := r.service.paymentRepository.ListByStatus(ctx, payment.StatusPending, payment.StatusFunded)
+	if err != nil {
+		logger.Error("failed to select payments", zap.Error(err))
+		return
+	}
+
+	checks := make(map[string]paymentCheck)
+	for _, data := range payments {
+		if !payment.IsUnsettled(data) {
+			continue
+		}
+
+		check, ok := r.checks[data.InvoiceID]
+		if ok && now.Before(check.next) {
+			checks[data.InvoiceID] = check
+			continue
+		}
+
+		select {
+		case <-r.stop:
+			return
+		default:
+		}
+
+		dest, err := r.service.reconcilePayment(ctx, data, now)
+		if err != nil {
+			logger.Warn("failed to reconcile", zap.String("invoice_id", data.InvoiceID), zap.Error(err))
+		}
+
+		if err == nil && dest.ProviderStatus != data.ProviderStatus {
+			if payment.IsUnsettled(dest) {
+				checks[data.InvoiceID] = paymentCheck{next: now.Add(r.interval)}
+			}
+			continue
+		}
+
+		check.attempts++
+		check.next = now.Add(r.backoff(check.attempts))
+		// the pending payment is checked right when it's due whatever the backoff is
+		if dest.Status == payment.StatusPending && now.Before(data.ExpiresAt) && check.next.After(data.ExpiresAt) {
+			check.next = data.ExpiresAt
+		}
+		checks[data.InvoiceID] = check
+	}
+	r.checks = checks
+}
+
+// backoff doubles the interval for each check the payment didn't change on
+func (r *PaymentReconciler) backoff(attempts int) (delay time.Duration) {
+	delay = r.interval
+	for i := 1; i < attempts && delay < r.maxBackoff; i++ {
+		delay *= 2
+	}
+
+	if delay > r.maxBackoff {
+		delay = r.maxBackoff
+	}
+
+	return
+}
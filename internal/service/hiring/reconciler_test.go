@@ -0,0 +1,193 @@
+package hiring
+
+import (
+	"context"
+	"exchanger/internal/domain/hire"
+	"exchanger/internal/domain/payment"
+	"exchanger/internal/provider/epay"
+	"exchanger/internal/provider/epay/epaytest"
+	"exchanger/internal/repository"
+	"testing"
+	"time"
+)
+
+// reconcilerFixture holds a hire with the pending payment of 500 KZT whose transaction epay knows
+type reconcilerFixture struct {
+	epay         *epaytest.Server
+	repositories *repository.Repository
+	service      *Service
+	hireID       string
+	invoiceID    string
+}
+
+func newReconcilerFixture(t *testing.T, hireStatus string, expiresAt time.Time) (f reconcilerFixture) {
+	t.Helper()
+
+	f.repositories = newMemoryRepositories(t)
+	server, epayClient := newEpayClient(t)
+	f.epay = server
+	f.service = newService(t, f.repositories, WithEpayClient(epayClient))
+
+	ctx := context.Background()
+	_, f.hireID = addHire(t, f.repositories, hireStatus)
+
+	amount, currency := 500, "KZT"
+	f.invoiceID = "000000000000001"
+	err := f.repositories.Payment.Add(ctx, payment.Entity{
+		InvoiceID: f.invoiceID,
+		HireID:    f.hireID,
+		Amount:    &amount,
+		Currency:  &currency,
+		Status:    payment.StatusPending,
+		CreatedAt: expiresAt.Add(-time.Hour),
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		t.Fatalf("Payment.Add() error = %v", err)
+	}
+	f.setStatus("NEW")
+
+	return
+}
+
+// setStatus makes epay report the transaction of the payment in the status
+func (f reconcilerFixture) setStatus(status string) {
+	f.epay.SetTransaction(epay.TransactionResponse{
+		ID:         "transaction",
+		InvoiceID:  f.invoiceID,
+		Amount:     500,
+		Currency:   "KZT",
+		Terminal:   "terminal",
+		StatusName: status,
+	})
+}
+
+func (f reconcilerFixture) state(t *testing.T) (payment.Entity, hire.Entity) {
+	t.Helper()
+
+	paymentData, err := f.repositories.Payment.Get(context.Background(), f.invoiceID)
+	if err != nil {
+		t.Fatalf("Payment.Get() error = %v", err)
+	}
+
+	hireData, err := f.repositories.Hire.Get(context.Background(), f.hireID)
+	if err != nil {
+		t.Fatalf("Hire.Get() error = %v", err)
+	}
+
+	return paymentData, hireData
+}
+
+func TestReconcilerBackoff(t *testing.T) {
+	start := time.Now()
+	f := newReconcilerFixture(t, hire.StatusDraft, start.Add(time.Hour))
+	r := f.service.NewPaymentReconciler(time.Minute, 4*time.Minute)
+
+	// the first check sees the transaction as new, then the unchanged payment is checked after 1, 2, 4 and 4 minutes
+	steps := []struct {
+		at     time.Duration
+		checks int
+	}{
+		{at: 0, checks: 1},
+		{at: 30 * time.Second, checks: 1},
+		{at: time.Minute, checks: 2},
+		{at: 2 * time.Minute, checks: 3},
+		{at: 3 * time.Minute, checks: 3},
+		{at: 4 * time.Minute, checks: 4},
+		{at: 7 * time.Minute, checks: 4},
+		{at: 8 * time.Minute, checks: 5},
+		{at: 11 * time.Minute, checks: 5},
+		{at: 12 * time.Minute, checks: 6},
+	}
+
+	for _, step := range steps {
+		r.Reconcile(context.Background(), start.Add(step.at))
+
+		if checks := f.epay.Checks(); checks != step.checks {
+			t.Fatalf("checks at %v = %d, want %d", step.at, checks, step.checks)
+		}
+	}
+
+	// the change of the transaction resets the backoff
+	f.setStatus("AUTH")
+	r.Reconcile(context.Background(), start.Add(16*time.Minute))
+	r.Reconcile(context.Background(), start.Add(17*time.Minute))
+	if checks := f.epay.Checks(); checks != 8 {
+		t.Errorf("checks after the change = %d, want 8", checks)
+	}
+
+	paymentData, hireData := f.state(t)
+	if paymentData.Status != payment.StatusFunded || hireData.Status != hire.StatusOpen {
+		t.Errorf("state = %s/%s, want %s/%s", paymentData.Status, hireData.Status, payment.StatusFunded, hire.StatusOpen)
+	}
+}
+
+func TestReconcilerBackoffWhileEpayFails(t *testing.T) {
+	start := time.Now()
+	f := newReconcilerFixture(t, hire.StatusDraft, start.Add(time.Hour))
+	f.epay.SetFailing(true)
+	r := f.service.NewPaymentReconciler(time.Minute, time.Hour)
+
+	for _, at := range []time.Duration{0, time.Minute, 2 * time.Minute, 3 * time.Minute} {
+		r.Reconcile(context.Background(), start.Add(at))
+	}
+
+	// the failed checks back off from the first one, at 0, 1 and 3 minutes
+	if checks := f.epay.Checks(); checks != 3 {
+		t.Errorf("checks = %d, want 3", checks)
+	}
+}
+
+func TestReconcilerExpiry(t *testing.T) {
+	tests := []struct {
+		name       string
+		hireStatus string
+		epayStatus string
+		failing    bool
+		want       string
+		wantHire   string
+	}{
+		{name: "unpaid payment of the draft hire", hireStatus: hire.StatusDraft, epayStatus: "NEW", want: payment.StatusExpired, wantHire: hire.StatusDraft},
+		{name: "unpaid payment of the open hire", hireStatus: hire.StatusOpen, epayStatus: "NEW", want: payment.StatusExpired, wantHire: hire.StatusDraft},
+		{name: "unpaid payment while epay fails", hireStatus: hire.StatusOpen, failing: true, want: payment.StatusExpired, wantHire: hire.StatusDraft},
+		{name: "payment paid at the last moment", hireStatus: hire.StatusDraft, epayStatus: "AUTH", want: payment.StatusFunded, wantHire: hire.StatusOpen},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Now()
+			f := newReconcilerFixture(t, tt.hireStatus, now.Add(-time.Minute))
+			f.setStatus(tt.epayStatus)
+			f.epay.SetFailing(tt.failing)
+
+			f.service.NewPaymentReconciler(time.Minute, time.Hour).Reconcile(context.Background(), now)
+
+			paymentData, hireData := f.state(t)
+			if paymentData.Status != tt.want || hireData.Status != tt.wantHire {
+				t.Errorf("state = %s/%s, want %s/%s", paymentData.Status, hireData.Status, tt.want, tt.wantHire)
+			}
+		})
+	}
+}
+
+func TestReconcilePaymentSettledMeanwhile(t *testing.T) {
+	now := time.Now()
+	f := newReconcilerFixture(t, hire.StatusDraft, now.Add(-time.Minute))
+	stale, _ := f.state(t)
+
+	// a callback funds the payment after the reconciler has read it and before epay answers it
+	f.setStatus("AUTH")
+	if _, err := f.service.verifyPayment(context.Background(), stale); err != nil {
+		t.Fatalf("verifyPayment() error = %v", err)
+	}
+	f.epay.SetFailing(true)
+
+	if _, err := f.service.reconcilePayment(context.Background(), stale, now); err != nil {
+		t.Fatalf("reconcilePayment() error = %v", err)
+	}
+
+	paymentData, hireData := f.state(t)
+	if paymentData.Status != payment.StatusFunded || hireData.Status != hire.StatusOpen {
+		t.Errorf("state = %s/%s, want %s/%s", paymentData.Status, hireData.Status, payment.StatusFunded, hire.StatusOpen)
+	}
+}
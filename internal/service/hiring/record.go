@@ -0,0 +1,89 @@
+package hiring
+
+import (
+	"context"
+	"exchanger/internal/domain/audit"
+	"exchanger/internal/domain/customer"
+	"exchanger/internal/domain/hire"
+	"exchanger/internal/domain/worker"
+	"exchanger/pkg/market"
+)
+
+// state reads the entity as it's responded, the deleted one as well, so that its changes can be compared
+type state func(ctx context.Context, id string) (any, error)
+
+// recorded makes the change of the entity, records it in the audit and writes its event to the outbox
+// in one unit of work, the entity is read right before and after the change. Without the audit
+// and the outbox repositories the change is made alone
+func (s *Service) recorded(ctx context.Context, entity, id, action string, read state, change func(ctx context.Context) error) error {
+	if s.auditRepository == nil && s.outboxRepository == nil {
+		return change(ctx)
+	}
+
+	return s.inTransaction(ctx, func(ctx context.Context) (err error) {
+		before, err := read(ctx, id)
+		if err != nil {
+			return
+		}
+
+		if err = change(ctx); err != nil {
+			return
+		}
+
+		after, err := read(ctx, id)
+		if err != nil {
+			return
+		}
+
+		if err = s.audit(ctx, entity, id, action, before, after); err != nil {
+			return
+		}
+
+		return s.emit(ctx, entity, id, action, after)
+	})
+}
+
+// recordAdded records the entity that has just been added, it must be called in the unit of work of the add
+func (s *Service) recordAdded(ctx context.Context, entity, id string, read state) (err error) {
+	if s.auditRepository == nil && s.outboxRepository == nil {
+		return
+	}
+
+	after, err := read(ctx, id)
+	if err != nil {
+		return
+	}
+
+	if err = s.audit(ctx, entity, id, audit.ActionAdd, nil, after); err != nil {
+		return
+	}
+
+	return s.emit(ctx, entity, id, audit.ActionAdd, after)
+}
+
+func (s *Service) customerState(ctx context.Context, id string) (any, error) {
+	data, err := s.customerRepository.Get(market.WithDeleted(ctx), id)
+	if err != nil {
+		return nil, err
+	}
+
+	return customer.ParseFromEntity(data), nil
+}
+
+func (s *Service) workerState(ctx context.Context, id string) (any, error) {
+	data, err := s.workerRepository.Get(market.WithDeleted(ctx), id)
+	if err != nil {
+		return nil, err
+	}
+
+	return worker.ParseFromEntity(data), nil
+}
+
+func (s *Service) hireState(ctx context.Context, id string) (any, error) {
+	data, err := s.hireRepository.Get(market.WithDeleted(ctx), id)
+	if err != nil {
+		return nil, err
+	}
+
+	return hire.ParseFromEntity(data), nil
+}
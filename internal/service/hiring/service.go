@@ -1,9 +1,43 @@
 package hiring
 
 import (
+	"errors"
+	"exchanger/internal/domain/audit"
 	"exchanger/internal/domain/customer"
+	"exchanger/internal/domain/event"
 	"exchanger/internal/domain/hire"
+	"exchanger/internal/domain/payment"
+	"exchanger/internal/domain/proposal"
 	"exchanger/internal/domain/worker"
+	"exchanger/internal/provider/epay"
+	"exchanger/internal/service/exchange"
+	"exchanger/pkg/market"
+	"time"
+)
+
+// defaultPaymentTTL is how long the payments wait for the customer unless WithPaymentTTL is given
+const defaultPaymentTTL = 24 * time.Hour
+
+// invoiceAttempts is how many random invoice ids a payment is tried with before the add fails
+const invoiceAttempts = 5
+
+var (
+	ErrorHireClosed       = errors.New("hire is not open to proposals")
+	ErrorProposalDecided  = errors.New("proposal is already accepted or rejected")
+	ErrorHireTransition   = errors.New("hire cannot change to this status")
+	ErrorHireLocked       = errors.New("field is locked in the current hire status")
+	ErrorHireUnfundable   = errors.New("hire cannot be funded in the current status")
+	ErrorPaymentExists    = errors.New("hire is already funded or waits for a payment")
+	ErrorPaymentDisabled  = errors.New("payments are not configured")
+	ErrorInvalidCallback  = errors.New("callback cannot be parsed")
+	ErrorPaymentMismatch  = errors.New("transaction doesn't match the payment")
+	ErrorPaymentState     = errors.New("operation is not allowed in the current payment status")
+	ErrorPaymentAmount    = errors.New("amount exceeds the amount left on the transaction")
+	ErrorPaymentInWork    = errors.New("payment of the hire in work can only be returned by an admin")
+	ErrorCurrencyDisabled = errors.New("currency rates are not configured")
+	ErrorRatesUnavailable = errors.New("currency rates cannot be fetched")
+	ErrorUnknownCurrency  = errors.New("currency has no rate")
+	ErrorAuditDisabled    = errors.New("audit is not configured")
 )
 
 // Configuration is an alias for a function that will take in a pointer to a Service and modify it
@@ -11,19 +45,34 @@ type Configuration func(s *Service) error
 
 // Service is an implementation of the Service
 type Service struct {
-	customerRepository customer.Repository
-	workerRepository   worker.Repository
-	hireRepository     hire.Repository
-	customerCache      customer.Cache
-	// TODO: workerCache
-	// TODO: hireCache
+	customerRepository       customer.Repository
+	workerRepository         worker.Repository
+	hireRepository           hire.Repository
+	hireHistoryRepository    hire.HistoryRepository
+	proposalRepository       proposal.Repository
+	paymentRepository        payment.Repository
+	callbackRepository       payment.CallbackRepository
+	paymentHistoryRepository payment.HistoryRepository
+	epayClient               *epay.Client
+	paymentTTL               time.Duration
+	newInvoiceID             func() (string, error)
+	exchangeService          *exchange.Service
+	customerCache            customer.Cache
+	workerCache              worker.Cache
+	hireCache                hire.Cache
+	unitOfWork               market.UnitOfWork
+	auditRepository          audit.Repository
+	outboxRepository         event.Repository
 }
 
 // New takes a variable amount of Configuration functions and returns a new Service
 // Each Configuration will be called in the order they are passed in
 func New(configs ...Configuration) (s *Service, err error) {
 	// Add the service
-	s = &Service{}
+	s = &Service{
+		paymentTTL:   defaultPaymentTTL,
+		newInvoiceID: payment.NewInvoiceID,
+	}
 
 	// Apply all Configurations passed in
 	for _, cfg := range configs {
@@ -63,6 +112,78 @@ func WithHireRepository(hireRepository hire.Repository) Configuration {
 	}
 }
 
+// WithHireHistoryRepository applies a given hire status history repository to the Service
+func WithHireHistoryRepository(hireHistoryRepository hire.HistoryRepository) Configuration {
+	// Add the hire history repository, if we needed parameters, such as connection strings they could be inputted here
+	return func(s *Service) error {
+		s.hireHistoryRepository = hireHistoryRepository
+		return nil
+	}
+}
+
+// WithProposalRepository applies a given proposal repository to the Service
+func WithProposalRepository(proposalRepository proposal.Repository) Configuration {
+	// Add the proposal repository, if we needed parameters, such as connection strings they could be inputted here
+	return func(s *Service) error {
+		s.proposalRepository = proposalRepository
+		return nil
+	}
+}
+
+// WithPaymentRepository applies a given payment repository to the Service
+func WithPaymentRepository(paymentRepository payment.Repository) Configuration {
+	// Add the payment repository, if we needed parameters, such as connection strings they could be inputted here
+	return func(s *Service) error {
+		s.paymentRepository = paymentRepository
+		return nil
+	}
+}
+
+// WithPaymentCallbackRepository applies a given payment callback repository to the Service
+func WithPaymentCallbackRepository(callbackRepository payment.CallbackRepository) Configuration {
+	// Add the callback repository, if we needed parameters, such as connection strings they could be inputted here
+	return func(s *Service) error {
+		s.callbackRepository = callbackRepository
+		return nil
+	}
+}
+
+// WithPaymentHistoryRepository applies a given payment operation history repository to the Service
+func WithPaymentHistoryRepository(paymentHistoryRepository payment.HistoryRepository) Configuration {
+	// Add the payment history repository, if we needed parameters, such as connection strings they could be inputted here
+	return func(s *Service) error {
+		s.paymentHistoryRepository = paymentHistoryRepository
+		return nil
+	}
+}
+
+// WithEpayClient applies a given epay client to the Service, payments are disabled without it
+func WithEpayClient(epayClient *epay.Client) Configuration {
+	return func(s *Service) error {
+		s.epayClient = epayClient
+		return nil
+	}
+}
+
+// WithPaymentTTL sets how long the payments wait for the customer before they expire
+func WithPaymentTTL(ttl time.Duration) Configuration {
+	return func(s *Service) error {
+		if ttl <= 0 {
+			return errors.New("payment ttl must be positive")
+		}
+		s.paymentTTL = ttl
+		return nil
+	}
+}
+
+// WithExchangeService applies a given exchange service to the Service, the budgets aren't converted without it
+func WithExchangeService(exchangeService *exchange.Service) Configuration {
+	return func(s *Service) error {
+		s.exchangeService = exchangeService
+		return nil
+	}
+}
+
 // WithCustomerCache applies a given author cache to the Service
 func WithCustomerCache(customerCache customer.Cache) Configuration {
 	// return a function that matches the Configuration alias,
@@ -73,6 +194,45 @@ func WithCustomerCache(customerCache customer.Cache) Configuration {
 	}
 }
 
-// TODO: WithWorkerCache
+// WithWorkerCache applies a given worker cache to the Service
+func WithWorkerCache(workerCache worker.Cache) Configuration {
+	return func(s *Service) error {
+		s.workerCache = workerCache
+		return nil
+	}
+}
+
+// WithHireCache applies a given hire cache to the Service
+func WithHireCache(hireCache hire.Cache) Configuration {
+	return func(s *Service) error {
+		s.hireCache = hireCache
+		return nil
+	}
+}
+
+// WithUnitOfWork applies a given unit of work to the Service, the changes of several repositories
+// that must happen together are made in one transaction of the store
+func WithUnitOfWork(unitOfWork market.UnitOfWork) Configuration {
+	return func(s *Service) error {
+		s.unitOfWork = unitOfWork
+		return nil
+	}
+}
 
-// TODO: WithHireCache
+// WithAuditRepository applies a given audit repository to the Service, the changes of the customers, workers
+// and hires are recorded in it together with the changes themselves. Nothing is recorded without it
+func WithAuditRepository(auditRepository audit.Repository) Configuration {
+	return func(s *Service) error {
+		s.auditRepository = auditRepository
+		return nil
+	}
+}
+
+// WithOutboxRepository applies a given outbox to the Service, the events of the changes of the customers, workers
+// and hires are written to it together with the changes themselves. No event is written without it
+func WithOutboxRepository(outboxRepository event.Repository) Configuration {
+	return func(s *Service) error {
+		s.outboxRepository = outboxRepository
+		return nil
+	}
+}
@@ -0,0 +1,93 @@
+package hiring
+
+import (
+	"context"
+	"exchanger/internal/domain/customer"
+	"exchanger/internal/domain/hire"
+	"exchanger/internal/domain/user"
+	"exchanger/internal/provider/epay"
+	"exchanger/internal/provider/epay/epaytest"
+	"exchanger/internal/repository"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+// newMemoryRepositories returns the repositories of a fresh memory store
+func newMemoryRepositories(t *testing.T) *repository.Repository {
+	t.Helper()
+
+	repositories, err := repository.New(repository.WithMemoryStore())
+	if err != nil {
+		t.Fatalf("repository.New() error = %v", err)
+	}
+
+	return repositories
+}
+
+// newService returns the service over the repositories, the configs are applied after the repositories
+func newService(t *testing.T, repositories *repository.Repository, configs ...Configuration) *Service {
+	t.Helper()
+
+	configs = append([]Configuration{
+		WithCustomerRepository(repositories.Customer),
+		WithWorkerRepository(repositories.Worker),
+		WithHireRepository(repositories.Hire),
+		WithHireHistoryRepository(repositories.HireHistory),
+		WithProposalRepository(repositories.Proposal),
+		WithPaymentRepository(repositories.Payment),
+		WithPaymentCallbackRepository(repositories.PaymentCallback),
+		WithPaymentHistoryRepository(repositories.PaymentHistory),
+		WithUnitOfWork(repositories.UnitOfWork),
+	}, configs...)
+
+	s, err := New(configs...)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	return s
+}
+
+// addHire adds the hire of a new customer in the status, the context returned acts as the customer
+func addHire(t *testing.T, repositories *repository.Repository, status string) (context.Context, string) {
+	t.Helper()
+
+	ctx := context.Background()
+	name, currency, amount := "name", "KZT", decimal.NewFromInt(500)
+
+	customerID, err := repositories.Customer.Add(ctx, customer.Entity{FullName: &name, Pseudonym: &name, UserID: "owner"})
+	if err != nil {
+		t.Fatalf("Customer.Add() error = %v", err)
+	}
+
+	id, err := repositories.Hire.Add(ctx, hire.Entity{
+		JobName:     &name,
+		Amount:      &amount,
+		Currency:    &currency,
+		Description: &name,
+		Position:    &name,
+		CustomerID:  customerID,
+		Status:      status,
+	})
+	if err != nil {
+		t.Fatalf("Hire.Add() error = %v", err)
+	}
+
+	return user.ContextWithActor(ctx, user.Actor{ID: "owner", Role: user.RoleCustomer}), id
+}
+
+// newEpayClient returns the client of a new epay stand-in
+func newEpayClient(t *testing.T) (*epaytest.Server, *epay.Client) {
+	t.Helper()
+
+	server := epaytest.NewServer()
+	t.Cleanup(server.Close)
+
+	client, err := epay.New(server.Credentials())
+	if err != nil {
+		t.Fatalf("epay.New() error = %v", err)
+	}
+
+	return server, client
+}
@@ -0,0 +1,46 @@
+package hiring
+
+import (
+	"context"
+)
+
+type commitHooks struct{}
+
+// inTransaction runs fn in one unit of work of the repositories, the repositories must be called with the context
+// given to fn. Without a unit of work applied the calls of fn are made one by one as they are.
+// The outermost call runs the hooks added by afterCommit once fn has succeeded and the unit of work is committed
+func (s *Service) inTransaction(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if _, ok := ctx.Value(commitHooks{}).(*[]func(ctx context.Context)); ok {
+		return s.doInTransaction(ctx, fn)
+	}
+
+	hooks := new([]func(ctx context.Context))
+	if err = s.doInTransaction(context.WithValue(ctx, commitHooks{}, hooks), fn); err != nil {
+		return
+	}
+
+	for _, hook := range *hooks {
+		hook(ctx)
+	}
+
+	return
+}
+
+func (s *Service) doInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if s.unitOfWork == nil {
+		return fn(ctx)
+	}
+
+	return s.unitOfWork.Do(ctx, fn)
+}
+
+// afterCommit runs hook once the outermost unit of work of the context is committed and drops it when the unit
+// of work fails, the hook runs right away outside of a unit of work
+func afterCommit(ctx context.Context, hook func(ctx context.Context)) {
+	if hooks, ok := ctx.Value(commitHooks{}).(*[]func(ctx context.Context)); ok {
+		*hooks = append(*hooks, hook)
+		return
+	}
+
+	hook(ctx)
+}
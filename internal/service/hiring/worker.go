@@ -2,6 +2,8 @@ package hiring
 
 import (
 	"context"
+	"exchanger/internal/domain/audit"
+	"exchanger/internal/domain/user"
 	"exchanger/internal/domain/worker"
 	"exchanger/pkg/log"
 	"exchanger/pkg/market"
@@ -9,15 +11,16 @@ import (
 	"go.uber.org/zap"
 )
 
-func (s *Service) ListWorkers(ctx context.Context) (res []worker.Response, err error) {
+func (s *Service) ListWorkers(ctx context.Context, q market.Query) (res []worker.Response, page market.Page, err error) {
 	logger := log.LoggerFromContext(ctx).Named("ListWorkers")
 
-	data, err := s.workerRepository.List(ctx)
+	data, total, err := s.workerRepository.List(ctx, q)
 	if err != nil {
 		logger.Error("failed to select", zap.Error(err))
 		return
 	}
 	res = worker.ParseFromEntities(data)
+	page = q.Page(total)
 
 	return
 }
@@ -25,16 +28,28 @@ func (s *Service) ListWorkers(ctx context.Context) (res []worker.Response, err e
 func (s *Service) AddWorker(ctx context.Context, req worker.Request) (res worker.Response, err error) {
 	logger := log.LoggerFromContext(ctx).Named("AddWorker")
 
+	actor, ok := user.ActorFromContext(ctx)
+	if !ok {
+		return res, market.ErrorForbidden
+	}
+
 	data := worker.Entity{
 		FullName:    &req.FullName,
 		Pseudonym:   &req.Pseudonym,
 		Description: &req.Description,
 		Position:    &req.Position,
+		UserID:      actor.ID,
 	}
 
-	data.ID, err = s.workerRepository.Add(ctx, data)
+	err = s.inTransaction(ctx, func(ctx context.Context) (err error) {
+		if data.ID, err = s.workerRepository.Add(ctx, data); err != nil {
+			logger.Error("failed to add", zap.Error(err))
+			return
+		}
+
+		return s.recordAdded(ctx, audit.EntityWorker, data.ID, s.workerState)
+	})
 	if err != nil {
-		logger.Error("failed to add", zap.Error(err))
 		return
 	}
 	res = worker.ParseFromEntity(data)
@@ -45,9 +60,11 @@ func (s *Service) AddWorker(ctx context.Context, req worker.Request) (res worker
 func (s *Service) GetWorker(ctx context.Context, id string) (res worker.Response, err error) {
 	logger := log.LoggerFromContext(ctx).Named("GetWorker").With(zap.String("id", id))
 
-	data, err := s.workerRepository.Get(ctx, id)
-	if err != nil && !errors.Is(err, market.ErrorNotFound) {
-		logger.Error("failed to get", zap.Error(err))
+	data, err := s.getWorker(ctx, id)
+	if err != nil {
+		if !errors.Is(err, market.ErrorNotFound) {
+			logger.Error("failed to get", zap.Error(err))
+		}
 		return
 	}
 	res = worker.ParseFromEntity(data)
@@ -55,33 +72,74 @@ func (s *Service) GetWorker(ctx context.Context, id string) (res worker.Response
 	return
 }
 
-func (s *Service) UpdateWorker(ctx context.Context, id string, req worker.Request) (err error) {
-	logger := log.LoggerFromContext(ctx).Named("UpdateWorker").With(zap.String("id", id))
+// UpdateWorker changes the worker at the version it was read at, the worker changed since is market.ErrorConflict
+func (s *Service) UpdateWorker(ctx context.Context, id string, req worker.Request, version int) (err error) {
+	return s.PatchWorker(ctx, id, req.Patch(), version)
+}
+
+// PatchWorker changes only the fields the patch sets, the same way UpdateWorker changes them all
+func (s *Service) PatchWorker(ctx context.Context, id string, req worker.Patch, version int) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("PatchWorker").With(zap.String("id", id))
+
+	if err = s.authorizeWorker(ctx, id); err != nil {
+		return
+	}
 
 	data := worker.Entity{
-		FullName:    &req.FullName,
-		Pseudonym:   &req.Pseudonym,
-		Description: &req.Description,
-		Position:    &req.Position,
+		FullName:    req.FullName,
+		Pseudonym:   req.Pseudonym,
+		Description: req.Description,
+		Position:    req.Position,
+		Version:     version,
 	}
 
-	err = s.workerRepository.Update(ctx, id, data)
-	if err != nil && !errors.Is(err, market.ErrorNotFound) {
+	err = s.recorded(ctx, audit.EntityWorker, id, audit.ActionUpdate, s.workerState, func(ctx context.Context) error {
+		return s.workerRepository.Update(ctx, id, data)
+	})
+	if err != nil && !errors.Is(err, market.ErrorNotFound) && !errors.Is(err, market.ErrorConflict) {
 		logger.Error("failed to update by id", zap.Error(err))
 		return
 	}
+	s.invalidateWorker(ctx, id)
 
 	return
 }
 
-func (s *Service) DeleteWorker(ctx context.Context, id string) (err error) {
+func (s *Service) DeleteWorker(ctx context.Context, id string, version int) (err error) {
 	logger := log.LoggerFromContext(ctx).Named("DeleteWorker").With(zap.String("id", id))
 
-	err = s.workerRepository.Delete(ctx, id)
-	if err != nil && !errors.Is(err, market.ErrorNotFound) {
+	if err = s.authorizeWorker(ctx, id); err != nil {
+		return
+	}
+
+	err = s.recorded(ctx, audit.EntityWorker, id, audit.ActionDelete, s.workerState, func(ctx context.Context) error {
+		return s.workerRepository.Delete(ctx, id, version)
+	})
+	if err != nil && !errors.Is(err, market.ErrorNotFound) && !errors.Is(err, market.ErrorConflict) {
 		logger.Error("failed to delete", zap.Error(err))
 		return
 	}
+	s.invalidateWorker(ctx, id)
+
+	return
+}
+
+// RestoreWorker brings the deleted worker back, only admins restore
+func (s *Service) RestoreWorker(ctx context.Context, id string) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("RestoreWorker").With(zap.String("id", id))
+
+	if err = authorizeAdmin(ctx); err != nil {
+		return
+	}
+
+	err = s.recorded(ctx, audit.EntityWorker, id, audit.ActionRestore, s.workerState, func(ctx context.Context) error {
+		return s.workerRepository.Restore(ctx, id)
+	})
+	if err != nil && !errors.Is(err, market.ErrorNotFound) {
+		logger.Error("failed to restore", zap.Error(err))
+		return
+	}
+	s.invalidateWorker(ctx, id)
 
 	return
 }
@@ -0,0 +1,4 @@
+BEGIN;
+    DROP TABLE IF EXISTS clients CASCADE;
+    DROP TABLE IF EXISTS users CASCADE;
+END;
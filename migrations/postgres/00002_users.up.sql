@@ -0,0 +1,24 @@
+DO $$
+  BEGIN
+    -- TABLES --
+    CREATE TABLE IF NOT EXISTS users (
+        created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        updated_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        id              UUID PRIMARY KEY DEFAULT GEN_RANDOM_UUID(),
+        username        VARCHAR NOT NULL UNIQUE,
+        password_hash   VARCHAR NOT NULL,
+        is_active       BOOLEAN NOT NULL DEFAULT TRUE
+    );
+
+    CREATE TABLE IF NOT EXISTS clients (
+        created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        updated_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        id              UUID PRIMARY KEY DEFAULT GEN_RANDOM_UUID(),
+        user_id         UUID NOT NULL REFERENCES users (id),
+        name            VARCHAR NOT NULL,
+        secret_hash     VARCHAR NOT NULL,
+        is_active       BOOLEAN NOT NULL DEFAULT TRUE
+    );
+
+  COMMIT;
+END $$;
@@ -0,0 +1,18 @@
+DO $$
+  BEGIN
+    -- TABLES --
+    CREATE TABLE IF NOT EXISTS tokens (
+        created_at          TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        id                  UUID PRIMARY KEY,
+        refresh_token_id    UUID NOT NULL,
+        credential          VARCHAR NOT NULL,
+        token_type          VARCHAR NOT NULL,
+        rotated_at          TIMESTAMP,
+        revoked_at          TIMESTAMP
+    );
+
+    -- INDEXES --
+    CREATE INDEX IF NOT EXISTS tokens_credential_idx ON tokens (credential);
+
+  COMMIT;
+END $$;
@@ -0,0 +1,5 @@
+BEGIN;
+    ALTER TABLE workers DROP COLUMN IF EXISTS user_id;
+    ALTER TABLE customers DROP COLUMN IF EXISTS user_id;
+    ALTER TABLE users DROP COLUMN IF EXISTS role;
+END;
@@ -0,0 +1,11 @@
+DO $$
+  BEGIN
+    -- COLUMNS --
+    ALTER TABLE users ADD COLUMN IF NOT EXISTS role VARCHAR NOT NULL DEFAULT 'customer';
+
+    ALTER TABLE customers ADD COLUMN IF NOT EXISTS user_id UUID REFERENCES users (id);
+
+    ALTER TABLE workers ADD COLUMN IF NOT EXISTS user_id UUID REFERENCES users (id);
+
+  COMMIT;
+END $$;
@@ -0,0 +1,4 @@
+BEGIN;
+    DROP INDEX IF EXISTS hires_search_vector_idx;
+    ALTER TABLE hires DROP COLUMN IF EXISTS search_vector;
+END;
@@ -0,0 +1,14 @@
+DO $$
+  BEGIN
+    -- COLUMNS --
+    ALTER TABLE hires ADD COLUMN IF NOT EXISTS search_vector TSVECTOR GENERATED ALWAYS AS (
+        SETWEIGHT(TO_TSVECTOR('english', COALESCE(job_name, '')), 'A') ||
+        SETWEIGHT(TO_TSVECTOR('english', COALESCE(position, '')), 'B') ||
+        SETWEIGHT(TO_TSVECTOR('english', COALESCE(description, '')), 'C')
+    ) STORED;
+
+    -- INDEXES --
+    CREATE INDEX IF NOT EXISTS hires_search_vector_idx ON hires USING GIN (search_vector);
+
+  COMMIT;
+END $$;
@@ -0,0 +1,4 @@
+BEGIN;
+    DROP TABLE IF EXISTS proposals;
+    ALTER TABLE hires DROP COLUMN IF EXISTS status;
+END;
@@ -0,0 +1,20 @@
+DO $$
+  BEGIN
+    -- COLUMNS --
+    ALTER TABLE hires ADD COLUMN IF NOT EXISTS status VARCHAR NOT NULL DEFAULT 'open';
+
+    -- TABLES --
+    CREATE TABLE IF NOT EXISTS proposals (
+        created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        updated_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        id              UUID PRIMARY KEY DEFAULT GEN_RANDOM_UUID(),
+        hire_id         UUID NOT NULL REFERENCES hires (id) ON DELETE CASCADE,
+        worker_id       UUID NOT NULL REFERENCES workers (id),
+        cover_letter    VARCHAR NOT NULL,
+        amount          INT NOT NULL,
+        status          VARCHAR NOT NULL DEFAULT 'pending',
+        UNIQUE (hire_id, worker_id)
+    );
+
+  COMMIT;
+END $$;
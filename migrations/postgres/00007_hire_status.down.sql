@@ -0,0 +1,5 @@
+BEGIN;
+    DROP TABLE IF EXISTS hire_status_history;
+    ALTER TABLE hires ALTER COLUMN status SET DEFAULT 'open';
+    UPDATE hires SET status='closed' WHERE status='in_progress';
+END;
@@ -0,0 +1,22 @@
+DO $$
+  BEGIN
+    -- COLUMNS --
+    UPDATE hires SET status='in_progress' WHERE status='closed';
+
+    ALTER TABLE hires ALTER COLUMN status SET DEFAULT 'draft';
+
+    -- TABLES --
+    CREATE TABLE IF NOT EXISTS hire_status_history (
+        created_at  TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        id          UUID PRIMARY KEY DEFAULT GEN_RANDOM_UUID(),
+        hire_id     UUID NOT NULL REFERENCES hires (id) ON DELETE CASCADE,
+        from_status VARCHAR NOT NULL,
+        to_status   VARCHAR NOT NULL,
+        user_id     UUID REFERENCES users (id)
+    );
+
+    -- INDEXES --
+    CREATE INDEX IF NOT EXISTS hire_status_history_hire_id_idx ON hire_status_history (hire_id);
+
+  COMMIT;
+END $$;
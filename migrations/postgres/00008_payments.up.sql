@@ -0,0 +1,22 @@
+DO $$
+  BEGIN
+    -- TABLES --
+    CREATE TABLE IF NOT EXISTS payments (
+        created_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        updated_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        invoice_id      VARCHAR PRIMARY KEY,
+        hire_id         UUID NOT NULL REFERENCES hires (id),
+        customer_id     UUID NOT NULL REFERENCES customers (id),
+        worker_id       UUID REFERENCES workers (id),
+        amount          INT NOT NULL,
+        currency        VARCHAR NOT NULL,
+        status          VARCHAR NOT NULL,
+        provider_status VARCHAR NOT NULL DEFAULT '',
+        released_at     TIMESTAMP
+    );
+
+    -- INDEXES --
+    CREATE INDEX IF NOT EXISTS payments_hire_id_idx ON payments (hire_id);
+
+  COMMIT;
+END $$;
@@ -0,0 +1,3 @@
+BEGIN;
+    DROP TABLE IF EXISTS payment_callbacks;
+END;
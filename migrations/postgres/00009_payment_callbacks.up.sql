@@ -0,0 +1,16 @@
+DO $$
+  BEGIN
+    -- TABLES --
+    CREATE TABLE IF NOT EXISTS payment_callbacks (
+        received_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        id          UUID PRIMARY KEY DEFAULT GEN_RANDOM_UUID(),
+        invoice_id  VARCHAR NOT NULL DEFAULT '',
+        body        TEXT NOT NULL,
+        remote_addr VARCHAR NOT NULL DEFAULT ''
+    );
+
+    -- INDEXES --
+    CREATE INDEX IF NOT EXISTS payment_callbacks_invoice_id_idx ON payment_callbacks (invoice_id);
+
+  COMMIT;
+END $$;
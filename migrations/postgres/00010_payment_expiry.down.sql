@@ -0,0 +1,4 @@
+BEGIN;
+    DROP INDEX IF EXISTS payments_status_idx;
+    ALTER TABLE payments DROP COLUMN IF EXISTS expires_at;
+END;
@@ -0,0 +1,12 @@
+DO $$
+  BEGIN
+    -- COLUMNS --
+    ALTER TABLE payments ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP;
+    UPDATE payments SET expires_at = created_at + INTERVAL '1 day' WHERE expires_at IS NULL;
+    ALTER TABLE payments ALTER COLUMN expires_at SET NOT NULL;
+
+    -- INDEXES --
+    CREATE INDEX IF NOT EXISTS payments_status_idx ON payments (status);
+
+  COMMIT;
+END $$;
@@ -0,0 +1,5 @@
+BEGIN;
+    DROP TABLE IF EXISTS payment_history;
+    ALTER TABLE payments DROP COLUMN IF EXISTS refunded_amount;
+    ALTER TABLE payments DROP COLUMN IF EXISTS charged_amount;
+END;
@@ -0,0 +1,23 @@
+DO $$
+  BEGIN
+    -- COLUMNS --
+    ALTER TABLE payments ADD COLUMN IF NOT EXISTS charged_amount INT;
+    ALTER TABLE payments ADD COLUMN IF NOT EXISTS refunded_amount INT;
+
+    -- TABLES --
+    CREATE TABLE IF NOT EXISTS payment_history (
+        created_at  TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        id          UUID PRIMARY KEY DEFAULT GEN_RANDOM_UUID(),
+        invoice_id  VARCHAR NOT NULL REFERENCES payments (invoice_id) ON DELETE CASCADE,
+        operation   VARCHAR NOT NULL,
+        amount      INT NOT NULL,
+        from_status VARCHAR NOT NULL,
+        to_status   VARCHAR NOT NULL,
+        user_id     UUID REFERENCES users (id)
+    );
+
+    -- INDEXES --
+    CREATE INDEX IF NOT EXISTS payment_history_invoice_id_idx ON payment_history (invoice_id);
+
+  COMMIT;
+END $$;
@@ -0,0 +1,4 @@
+BEGIN;
+    ALTER TABLE hires DROP COLUMN IF EXISTS currency;
+    ALTER TABLE hires ALTER COLUMN amount TYPE INT USING ROUND(amount)::INT;
+END;
@@ -0,0 +1,8 @@
+DO $$
+  BEGIN
+    -- COLUMNS --
+    ALTER TABLE hires ALTER COLUMN amount TYPE NUMERIC(20, 2) USING amount::NUMERIC;
+    ALTER TABLE hires ADD COLUMN IF NOT EXISTS currency VARCHAR(3) NOT NULL DEFAULT 'KZT';
+
+  COMMIT;
+END $$;
@@ -0,0 +1,17 @@
+DO $$
+  BEGIN
+    -- TABLES --
+    CREATE TABLE IF NOT EXISTS rates (
+        id    VARCHAR PRIMARY KEY,
+        date  DATE NOT NULL,
+        code  VARCHAR(3) NOT NULL,
+        name  VARCHAR NOT NULL,
+        value NUMERIC(20,8) NOT NULL,
+        quant INT NOT NULL DEFAULT 1
+    );
+
+    -- INDEXES --
+    CREATE INDEX IF NOT EXISTS rates_date_idx ON rates (date);
+
+  COMMIT;
+END $$;
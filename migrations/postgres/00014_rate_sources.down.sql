@@ -0,0 +1,4 @@
+BEGIN;
+    ALTER TABLE rates DROP COLUMN IF EXISTS source;
+    ALTER TABLE rates DROP COLUMN IF EXISTS base;
+END;
@@ -0,0 +1,8 @@
+DO $$
+  BEGIN
+    -- COLUMNS --
+    ALTER TABLE rates ADD COLUMN IF NOT EXISTS base VARCHAR(3) NOT NULL DEFAULT 'KZT';
+    ALTER TABLE rates ADD COLUMN IF NOT EXISTS source VARCHAR NOT NULL DEFAULT 'nationalbank';
+
+  COMMIT;
+END $$;
@@ -0,0 +1,7 @@
+DO $$
+  BEGIN
+    -- COLUMNS --
+    ALTER TABLE workers ADD COLUMN IF NOT EXISTS pseudonym VARCHAR NOT NULL DEFAULT '';
+
+  COMMIT;
+END $$;
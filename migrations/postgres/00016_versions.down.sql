@@ -0,0 +1,5 @@
+BEGIN;
+    ALTER TABLE workers DROP COLUMN IF EXISTS version;
+    ALTER TABLE hires DROP COLUMN IF EXISTS version;
+    ALTER TABLE customers DROP COLUMN IF EXISTS version;
+END;
@@ -0,0 +1,9 @@
+DO $$
+  BEGIN
+    -- COLUMNS --
+    ALTER TABLE customers ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 1;
+    ALTER TABLE hires ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 1;
+    ALTER TABLE workers ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 1;
+
+  COMMIT;
+END $$;
@@ -0,0 +1,9 @@
+BEGIN;
+    DROP INDEX IF EXISTS workers_deleted_at_idx;
+    DROP INDEX IF EXISTS hires_deleted_at_idx;
+    DROP INDEX IF EXISTS customers_deleted_at_idx;
+
+    ALTER TABLE workers DROP COLUMN IF EXISTS deleted_at;
+    ALTER TABLE hires DROP COLUMN IF EXISTS deleted_at;
+    ALTER TABLE customers DROP COLUMN IF EXISTS deleted_at;
+END;
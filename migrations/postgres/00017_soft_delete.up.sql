@@ -0,0 +1,14 @@
+DO $$
+  BEGIN
+    -- COLUMNS --
+    ALTER TABLE customers ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+    ALTER TABLE hires ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+    ALTER TABLE workers ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+
+    -- INDEXES --
+    CREATE INDEX IF NOT EXISTS customers_deleted_at_idx ON customers (deleted_at) WHERE deleted_at IS NOT NULL;
+    CREATE INDEX IF NOT EXISTS hires_deleted_at_idx ON hires (deleted_at) WHERE deleted_at IS NOT NULL;
+    CREATE INDEX IF NOT EXISTS workers_deleted_at_idx ON workers (deleted_at) WHERE deleted_at IS NOT NULL;
+
+  COMMIT;
+END $$;
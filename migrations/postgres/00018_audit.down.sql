@@ -0,0 +1,5 @@
+BEGIN;
+    DROP INDEX IF EXISTS audit_entity_idx;
+
+    DROP TABLE IF EXISTS audit;
+END;
@@ -0,0 +1,20 @@
+DO $$
+  BEGIN
+    -- TABLES --
+    CREATE TABLE IF NOT EXISTS audit (
+        created_at  TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        id          UUID PRIMARY KEY DEFAULT GEN_RANDOM_UUID(),
+        entity      VARCHAR NOT NULL,
+        entity_id   VARCHAR NOT NULL,
+        action      VARCHAR NOT NULL,
+        actor_id    VARCHAR NOT NULL DEFAULT '',
+        actor_role  VARCHAR NOT NULL DEFAULT '',
+        request_id  VARCHAR NOT NULL DEFAULT '',
+        diff        JSONB NOT NULL DEFAULT '{}'
+    );
+
+    -- INDEXES --
+    CREATE INDEX IF NOT EXISTS audit_entity_idx ON audit (entity, entity_id, created_at);
+
+  COMMIT;
+END $$;
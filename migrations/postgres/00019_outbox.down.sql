@@ -0,0 +1,5 @@
+BEGIN;
+    DROP INDEX IF EXISTS outbox_pending_idx;
+
+    DROP TABLE IF EXISTS outbox;
+END;
@@ -0,0 +1,19 @@
+DO $$
+  BEGIN
+    -- TABLES --
+    CREATE TABLE IF NOT EXISTS outbox (
+        created_at   TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+        id           UUID PRIMARY KEY DEFAULT GEN_RANDOM_UUID(),
+        type         VARCHAR NOT NULL,
+        entity_id    VARCHAR NOT NULL,
+        payload      JSONB NOT NULL,
+        attempts     INT NOT NULL DEFAULT 0,
+        last_error   VARCHAR NOT NULL DEFAULT '',
+        published_at TIMESTAMP
+    );
+
+    -- INDEXES --
+    CREATE INDEX IF NOT EXISTS outbox_pending_idx ON outbox (created_at) WHERE published_at IS NULL;
+
+  COMMIT;
+END $$;
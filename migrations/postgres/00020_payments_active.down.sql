@@ -0,0 +1,3 @@
+BEGIN;
+    DROP INDEX IF EXISTS payments_active_hire_idx;
+END;
@@ -0,0 +1,7 @@
+DO $$
+  BEGIN
+    -- INDEXES --
+    CREATE UNIQUE INDEX IF NOT EXISTS payments_active_hire_idx ON payments (hire_id) WHERE status IN ('pending', 'funded');
+
+  COMMIT;
+END $$;
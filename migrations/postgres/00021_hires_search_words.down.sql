@@ -0,0 +1,9 @@
+BEGIN;
+    ALTER TABLE hires DROP COLUMN IF EXISTS search_vector;
+    ALTER TABLE hires ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+        SETWEIGHT(TO_TSVECTOR('english', COALESCE(job_name, '')), 'A') ||
+        SETWEIGHT(TO_TSVECTOR('english', COALESCE(position, '')), 'B') ||
+        SETWEIGHT(TO_TSVECTOR('english', COALESCE(description, '')), 'C')
+    ) STORED;
+    CREATE INDEX IF NOT EXISTS hires_search_vector_idx ON hires USING GIN (search_vector);
+END;
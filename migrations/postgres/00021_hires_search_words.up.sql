@@ -0,0 +1,17 @@
+DO $$
+  BEGIN
+    -- COLUMNS --
+    -- the words are split like hire.Terms splits them and aren't stemmed, so that the search can match their prefixes
+    ALTER TABLE hires DROP COLUMN IF EXISTS search_vector;
+    ALTER TABLE hires ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+        TO_TSVECTOR('simple', REGEXP_REPLACE(
+            LOWER(COALESCE(job_name, '') || ' ' || COALESCE(position, '') || ' ' || COALESCE(description, '')),
+            '[^[:alnum:]]+', ' ', 'g'
+        ))
+    ) STORED;
+
+    -- INDEXES --
+    CREATE INDEX IF NOT EXISTS hires_search_vector_idx ON hires USING GIN (search_vector);
+
+  COMMIT;
+END $$;
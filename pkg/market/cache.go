@@ -0,0 +1,30 @@
+package market
+
+import "sync/atomic"
+
+// CacheStats are the reads of a cache since it was created
+type CacheStats struct {
+	Hits   int64 `json:"hits"`
+	Misses int64 `json:"misses"`
+}
+
+// CacheCounter counts the hits and the misses of a cache
+type CacheCounter struct {
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+func (c *CacheCounter) Hit() {
+	c.hits.Add(1)
+}
+
+func (c *CacheCounter) Miss() {
+	c.misses.Add(1)
+}
+
+func (c *CacheCounter) Stats() CacheStats {
+	return CacheStats{
+		Hits:   c.hits.Load(),
+		Misses: c.misses.Load(),
+	}
+}
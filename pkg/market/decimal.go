@@ -0,0 +1,91 @@
+package market
+
+import (
+	"fmt"
+	"github.com/shopspring/decimal"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"reflect"
+)
+
+var decimalType = reflect.TypeOf(decimal.Decimal{})
+
+// decimalCodec stores the decimals as Decimal128 so that mongo compares and sorts them as numbers,
+// the amounts stored as plain numbers before are read as well
+type decimalCodec struct{}
+
+func (decimalCodec) EncodeValue(_ bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	if !val.IsValid() || val.Type() != decimalType {
+		return bsoncodec.ValueEncoderError{Name: "DecimalEncodeValue", Types: []reflect.Type{decimalType}, Received: val}
+	}
+
+	value, err := primitive.ParseDecimal128(val.Interface().(decimal.Decimal).String())
+	if err != nil {
+		return err
+	}
+
+	return vw.WriteDecimal128(value)
+}
+
+func (decimalCodec) DecodeValue(_ bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) (err error) {
+	if !val.CanSet() || val.Type() != decimalType {
+		return bsoncodec.ValueDecoderError{Name: "DecimalDecodeValue", Types: []reflect.Type{decimalType}, Received: val}
+	}
+
+	var value decimal.Decimal
+	switch vr.Type() {
+	case bsontype.Decimal128:
+		var number primitive.Decimal128
+		if number, err = vr.ReadDecimal128(); err != nil {
+			return
+		}
+		if value, err = decimal.NewFromString(number.String()); err != nil {
+			return
+		}
+	case bsontype.Int32:
+		var number int32
+		if number, err = vr.ReadInt32(); err != nil {
+			return
+		}
+		value = decimal.NewFromInt32(number)
+	case bsontype.Int64:
+		var number int64
+		if number, err = vr.ReadInt64(); err != nil {
+			return
+		}
+		value = decimal.NewFromInt(number)
+	case bsontype.Double:
+		var number float64
+		if number, err = vr.ReadDouble(); err != nil {
+			return
+		}
+		value = decimal.NewFromFloat(number)
+	case bsontype.String:
+		var number string
+		if number, err = vr.ReadString(); err != nil {
+			return
+		}
+		if value, err = decimal.NewFromString(number); err != nil {
+			return
+		}
+	case bsontype.Null:
+		err = vr.ReadNull()
+	default:
+		return fmt.Errorf("cannot decode %v into a decimal", vr.Type())
+	}
+	val.Set(reflect.ValueOf(value))
+
+	return
+}
+
+// newRegistry returns the default bson registry with the decimal codec
+func newRegistry() *bsoncodec.Registry {
+	registry := bson.NewRegistry()
+	registry.RegisterTypeEncoder(decimalType, decimalCodec{})
+	registry.RegisterTypeDecoder(decimalType, decimalCodec{})
+
+	return registry
+}
@@ -0,0 +1,76 @@
+package market
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type amounts struct {
+	Value   decimal.Decimal  `bson:"value"`
+	Pointer *decimal.Decimal `bson:"pointer"`
+}
+
+func TestDecimalCodecEncode(t *testing.T) {
+	value := decimal.RequireFromString("-1500.0501")
+	data, err := bson.MarshalWithRegistry(newRegistry(), amounts{Value: value, Pointer: &value})
+	if err != nil {
+		t.Fatalf("bson.Marshal() error = %v", err)
+	}
+
+	// the decimals are stored as Decimal128 so that mongo compares them as numbers
+	for _, key := range []string{"value", "pointer"} {
+		if kind := bson.Raw(data).Lookup(key).Type; kind != bsontype.Decimal128 {
+			t.Errorf("%s: type = %v, want %v", key, kind, bsontype.Decimal128)
+		}
+	}
+
+	var got amounts
+	if err = bson.UnmarshalWithRegistry(newRegistry(), data, &got); err != nil {
+		t.Fatalf("bson.Unmarshal() error = %v", err)
+	}
+	if !got.Value.Equal(value) || got.Pointer == nil || !got.Pointer.Equal(value) {
+		t.Errorf("round trip = %v/%v, want %v", got.Value, got.Pointer, value)
+	}
+}
+
+func TestDecimalCodecDecode(t *testing.T) {
+	decimal128, _ := primitive.ParseDecimal128("12.345")
+
+	tests := []struct {
+		name    string
+		stored  any
+		want    string
+		wantErr bool
+	}{
+		{name: "decimal128", stored: decimal128, want: "12.345"},
+		{name: "int32", stored: int32(7), want: "7"},
+		{name: "int64", stored: int64(9000000000), want: "9000000000"},
+		{name: "double", stored: 2.5, want: "2.5"},
+		{name: "string", stored: "3.25", want: "3.25"},
+		{name: "null", stored: nil, want: "0"},
+		{name: "malformed string", stored: "amount", wantErr: true},
+		{name: "boolean", stored: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := bson.Marshal(bson.D{{Key: "value", Value: tt.stored}})
+			if err != nil {
+				t.Fatalf("bson.Marshal() error = %v", err)
+			}
+
+			var got amounts
+			err = bson.UnmarshalWithRegistry(newRegistry(), data, &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("bson.Unmarshal() error = %v, want error %v", err, tt.wantErr)
+			}
+			if err == nil && !got.Value.Equal(decimal.RequireFromString(tt.want)) {
+				t.Errorf("Value = %v, want %s", got.Value, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package market
+
+import "context"
+
+type deleted struct{}
+
+// WithDeleted returns the context in which the repositories list and get the soft-deleted entities as well
+func WithDeleted(ctx context.Context) context.Context {
+	return context.WithValue(ctx, deleted{}, true)
+}
+
+// IncludesDeleted checks that the soft-deleted entities are asked for in the context
+func IncludesDeleted(ctx context.Context) bool {
+	included, _ := ctx.Value(deleted{}).(bool)
+	return included
+}
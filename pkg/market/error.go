@@ -3,5 +3,9 @@ package market
 import "errors"
 
 var (
-	ErrorNotFound = errors.New("error not found")
+	ErrorNotFound      = errors.New("error not found")
+	ErrorAlreadyExists = errors.New("error already exists")
+	ErrorForbidden     = errors.New("error forbidden")
+	// ErrorConflict is returned when the entity was changed since the version the change is made for
+	ErrorConflict = errors.New("error version conflict")
 )
@@ -15,8 +15,15 @@ type Mongo struct {
 	Client *mongo.Client
 }
 
-func NewMongo(url string) (market Mongo, err error) {
-	market.Client, err = mongo.NewClient(options.Client().ApplyURI("mongodb://localhost:27017"))
+func NewMongo(url string, maxPoolSize, minPoolSize int) (market Mongo, err error) {
+	opts := options.Client().
+		ApplyURI(url).
+		SetMaxPoolSize(uint64(maxPoolSize)).
+		SetMinPoolSize(uint64(minPoolSize)).
+		SetServerSelectionTimeout(timeout).
+		SetRegistry(newRegistry())
+
+	market.Client, err = mongo.NewClient(opts)
 	if err != nil {
 		return
 	}
@@ -28,7 +35,7 @@ func NewMongo(url string) (market Mongo, err error) {
 		return
 	}
 
-	if err = market.Client.Ping(context.Background(), nil); err != nil {
+	if err = market.Client.Ping(ctx, nil); err != nil {
 		return
 	}
 
@@ -0,0 +1,43 @@
+package market
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+var (
+	ErrorInvalidPatch = errors.New("patch: must be a JSON object")
+	ErrorEmptyPatch   = errors.New("patch: must set at least one field")
+)
+
+// DecodeMergePatch reads the RFC 7396 merge patch into dest, the pointer fields of dest stay nil
+// unless the patch sets them. The members set to null are refused, since none of the fields can be removed
+func DecodeMergePatch(r io.Reader, dest any) (err error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return
+	}
+
+	var members map[string]json.RawMessage
+	if err = json.Unmarshal(data, &members); err != nil || members == nil {
+		return ErrorInvalidPatch
+	}
+
+	if len(members) == 0 {
+		return ErrorEmptyPatch
+	}
+
+	for key, value := range members {
+		if bytes.Equal(bytes.TrimSpace(value), []byte("null")) {
+			return fmt.Errorf("%s: cannot be null", key)
+		}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	return decoder.Decode(dest)
+}
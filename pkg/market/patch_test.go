@@ -0,0 +1,62 @@
+package market
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type patch struct {
+	Name     *string `json:"name"`
+	Position *string `json:"position"`
+	Count    *int    `json:"count"`
+}
+
+func TestDecodeMergePatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr error
+		// wantMessage is matched against the error message when wantErr is nil
+		wantMessage string
+	}{
+		{name: "null member", body: `{"name": "Ann", "position": null}`, wantMessage: "position: cannot be null"},
+		{name: "unknown field", body: `{"name": "Ann", "salary": 10}`, wantMessage: `unknown field "salary"`},
+		{name: "wrong type", body: `{"count": "ten"}`, wantMessage: "cannot unmarshal"},
+		{name: "empty", body: `{}`, wantErr: ErrorEmptyPatch},
+		{name: "null", body: `null`, wantErr: ErrorInvalidPatch},
+		{name: "array", body: `[{"name": "Ann"}]`, wantErr: ErrorInvalidPatch},
+		{name: "malformed", body: `{"name": `, wantErr: ErrorInvalidPatch},
+		{name: "blank", body: ``, wantErr: ErrorInvalidPatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := DecodeMergePatch(strings.NewReader(tt.body), &patch{})
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("DecodeMergePatch() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && (err == nil || !strings.Contains(err.Error(), tt.wantMessage)) {
+				t.Fatalf("DecodeMergePatch() error = %v, want %q", err, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestDecodeMergePatchFields(t *testing.T) {
+	dest := patch{}
+	if err := DecodeMergePatch(strings.NewReader(`{"position": "designer", "count": 0}`), &dest); err != nil {
+		t.Fatalf("DecodeMergePatch() error = %v", err)
+	}
+
+	// the fields the patch doesn't set stay nil, the zero values it sets don't
+	if dest.Name != nil {
+		t.Errorf("name = %q, want nil", *dest.Name)
+	}
+	if dest.Position == nil || *dest.Position != "designer" {
+		t.Errorf("position = %v, want %q", dest.Position, "designer")
+	}
+	if dest.Count == nil || *dest.Count != 0 {
+		t.Errorf("count = %v, want 0", dest.Count)
+	}
+}
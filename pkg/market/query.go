@@ -0,0 +1,162 @@
+package market
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"github.com/shopspring/decimal"
+	"net/url"
+	"reflect"
+	"strconv"
+)
+
+const (
+	OrderAsc  = "asc"
+	OrderDesc = "desc"
+
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+var ErrorInvalidCursor = errors.New("cursor: is invalid")
+
+// Field describes a column the list can be sorted and filtered by
+type Field struct {
+	Column string
+	Kind   reflect.Kind
+}
+
+// Query describes the page, order and equality filters of a list.
+// Sort and the Filters keys are columns that were checked against the allowed fields.
+type Query struct {
+	Limit   int
+	Offset  int
+	Sort    string
+	Order   string
+	Filters map[string]any
+}
+
+// Page describes the position of a list in the whole result
+type Page struct {
+	Total      int    `json:"total"`
+	Limit      int    `json:"limit"`
+	Offset     int    `json:"offset"`
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// NewQuery returns the query of the first page sorted by id
+func NewQuery() Query {
+	return Query{
+		Limit:   DefaultLimit,
+		Sort:    "id",
+		Order:   OrderAsc,
+		Filters: make(map[string]any),
+	}
+}
+
+// Where returns a copy of the query that is filtered by the column as well
+func (q Query) Where(column string, value any) Query {
+	filters := make(map[string]any, len(q.Filters)+1)
+	for key, object := range q.Filters {
+		filters[key] = object
+	}
+	filters[column] = value
+	q.Filters = filters
+
+	return q
+}
+
+// Page returns the page of the query in the list of total objects
+func (q Query) Page(total int) (page Page) {
+	page = Page{
+		Total:  total,
+		Limit:  q.Limit,
+		Offset: q.Offset,
+	}
+
+	if next := q.Offset + q.Limit; next < total {
+		page.NextCursor = EncodeCursor(next)
+	}
+
+	return
+}
+
+// ParseQuery reads the limit, offset or cursor, sort, order and field filters of the list from the params,
+// the params that aren't fields of the list are left to the caller
+func ParseQuery(params url.Values, fields map[string]Field) (q Query, err error) {
+	q = NewQuery()
+
+	for key, values := range params {
+		value := values[0]
+
+		switch key {
+		case "limit":
+			if q.Limit, err = strconv.Atoi(value); err != nil || q.Limit < 1 || q.Limit > MaxLimit {
+				return q, fmt.Errorf("limit: must be between 1 and %d", MaxLimit)
+			}
+		case "offset":
+			if q.Offset, err = strconv.Atoi(value); err != nil || q.Offset < 0 {
+				return q, fmt.Errorf("offset: must be a positive number")
+			}
+		case "cursor":
+			if q.Offset, err = DecodeCursor(value); err != nil {
+				return
+			}
+		case "sort":
+			field, ok := fields[value]
+			if !ok {
+				return q, fmt.Errorf("sort: unknown field %s", value)
+			}
+			q.Sort = field.Column
+		case "order":
+			if value != OrderAsc && value != OrderDesc {
+				return q, fmt.Errorf("order: must be %s or %s", OrderAsc, OrderDesc)
+			}
+			q.Order = value
+		default:
+			field, ok := fields[key]
+			if !ok {
+				continue
+			}
+
+			switch field.Kind {
+			case reflect.Int:
+				number, err := strconv.Atoi(value)
+				if err != nil {
+					return q, fmt.Errorf("%s: must be a number", key)
+				}
+				q.Filters[field.Column] = number
+			case reflect.Float64:
+				number, err := decimal.NewFromString(value)
+				if err != nil {
+					return q, fmt.Errorf("%s: must be a number", key)
+				}
+				q.Filters[field.Column] = number
+			default:
+				q.Filters[field.Column] = value
+			}
+		}
+	}
+
+	return q, nil
+}
+
+// EncodeCursor returns an opaque cursor of the offset
+func EncodeCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+// DecodeCursor returns the offset of the opaque cursor
+func DecodeCursor(cursor string) (offset int, err error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, ErrorInvalidCursor
+	}
+
+	offset, err = strconv.Atoi(string(data))
+	if err != nil || offset < 0 {
+		return 0, ErrorInvalidCursor
+	}
+
+	return
+}
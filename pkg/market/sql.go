@@ -1,6 +1,7 @@
 package market
 
 import (
+	"context"
 	_ "database/sql"
 	"errors"
 	"strings"
@@ -15,18 +16,22 @@ type SQLX struct {
 	Client *sqlx.DB
 }
 
-func NewSQL(dataSourceName string) (market SQLX, err error) {
+func NewSQL(dataSourceName string, maxOpenConns, maxIdleConns int) (market SQLX, err error) {
 	if !strings.Contains(dataSourceName, "://") {
 		err = errors.New("market: indefined data source name")
 		return
 	}
 	driverName := strings.ToLower(strings.Split(dataSourceName, "://")[0])
 
-	market.Client, err = sqlx.Connect(driverName, dataSourceName)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	market.Client, err = sqlx.ConnectContext(ctx, driverName, dataSourceName)
 	if err != nil {
 		return
 	}
-	market.Client.SetMaxOpenConns(20)
+	market.Client.SetMaxOpenConns(maxOpenConns)
+	market.Client.SetMaxIdleConns(maxIdleConns)
 
 	return
 }
@@ -0,0 +1,10 @@
+package market
+
+import "context"
+
+// UnitOfWork runs several repository calls as one transaction of the store.
+// The repositories take part in the transaction when they are called with the context given to the function,
+// the changes are committed once the function returns nil and rolled back otherwise
+type UnitOfWork interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,258 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: hire.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	HireService_ListHires_FullMethodName  = "/exchanger.v1.HireService/ListHires"
+	HireService_GetHire_FullMethodName    = "/exchanger.v1.HireService/GetHire"
+	HireService_AddHire_FullMethodName    = "/exchanger.v1.HireService/AddHire"
+	HireService_UpdateHire_FullMethodName = "/exchanger.v1.HireService/UpdateHire"
+	HireService_DeleteHire_FullMethodName = "/exchanger.v1.HireService/DeleteHire"
+)
+
+// HireServiceClient is the client API for HireService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type HireServiceClient interface {
+	ListHires(ctx context.Context, in *ListHiresRequest, opts ...grpc.CallOption) (*ListHiresResponse, error)
+	GetHire(ctx context.Context, in *GetHireRequest, opts ...grpc.CallOption) (*Hire, error)
+	AddHire(ctx context.Context, in *HireRequest, opts ...grpc.CallOption) (*Hire, error)
+	UpdateHire(ctx context.Context, in *UpdateHireRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	DeleteHire(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type hireServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewHireServiceClient(cc grpc.ClientConnInterface) HireServiceClient {
+	return &hireServiceClient{cc}
+}
+
+func (c *hireServiceClient) ListHires(ctx context.Context, in *ListHiresRequest, opts ...grpc.CallOption) (*ListHiresResponse, error) {
+	out := new(ListHiresResponse)
+	err := c.cc.Invoke(ctx, HireService_ListHires_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hireServiceClient) GetHire(ctx context.Context, in *GetHireRequest, opts ...grpc.CallOption) (*Hire, error) {
+	out := new(Hire)
+	err := c.cc.Invoke(ctx, HireService_GetHire_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hireServiceClient) AddHire(ctx context.Context, in *HireRequest, opts ...grpc.CallOption) (*Hire, error) {
+	out := new(Hire)
+	err := c.cc.Invoke(ctx, HireService_AddHire_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hireServiceClient) UpdateHire(ctx context.Context, in *UpdateHireRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, HireService_UpdateHire_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hireServiceClient) DeleteHire(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, HireService_DeleteHire_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// HireServiceServer is the server API for HireService service.
+// All implementations must embed UnimplementedHireServiceServer
+// for forward compatibility
+type HireServiceServer interface {
+	ListHires(context.Context, *ListHiresRequest) (*ListHiresResponse, error)
+	GetHire(context.Context, *GetHireRequest) (*Hire, error)
+	AddHire(context.Context, *HireRequest) (*Hire, error)
+	UpdateHire(context.Context, *UpdateHireRequest) (*emptypb.Empty, error)
+	DeleteHire(context.Context, *DeleteRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedHireServiceServer()
+}
+
+// UnimplementedHireServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedHireServiceServer struct {
+}
+
+func (UnimplementedHireServiceServer) ListHires(context.Context, *ListHiresRequest) (*ListHiresResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListHires not implemented")
+}
+func (UnimplementedHireServiceServer) GetHire(context.Context, *GetHireRequest) (*Hire, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHire not implemented")
+}
+func (UnimplementedHireServiceServer) AddHire(context.Context, *HireRequest) (*Hire, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddHire not implemented")
+}
+func (UnimplementedHireServiceServer) UpdateHire(context.Context, *UpdateHireRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateHire not implemented")
+}
+func (UnimplementedHireServiceServer) DeleteHire(context.Context, *DeleteRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteHire not implemented")
+}
+func (UnimplementedHireServiceServer) mustEmbedUnimplementedHireServiceServer() {}
+
+// UnsafeHireServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to HireServiceServer will
+// result in compilation errors.
+type UnsafeHireServiceServer interface {
+	mustEmbedUnimplementedHireServiceServer()
+}
+
+func RegisterHireServiceServer(s grpc.ServiceRegistrar, srv HireServiceServer) {
+	s.RegisterService(&HireService_ServiceDesc, srv)
+}
+
+func _HireService_ListHires_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListHiresRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HireServiceServer).ListHires(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HireService_ListHires_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HireServiceServer).ListHires(ctx, req.(*ListHiresRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HireService_GetHire_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHireRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HireServiceServer).GetHire(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HireService_GetHire_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HireServiceServer).GetHire(ctx, req.(*GetHireRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HireService_AddHire_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HireRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HireServiceServer).AddHire(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HireService_AddHire_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HireServiceServer).AddHire(ctx, req.(*HireRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HireService_UpdateHire_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateHireRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HireServiceServer).UpdateHire(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HireService_UpdateHire_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HireServiceServer).UpdateHire(ctx, req.(*UpdateHireRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HireService_DeleteHire_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HireServiceServer).DeleteHire(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HireService_DeleteHire_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HireServiceServer).DeleteHire(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// HireService_ServiceDesc is the grpc.ServiceDesc for HireService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var HireService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "exchanger.v1.HireService",
+	HandlerType: (*HireServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListHires",
+			Handler:    _HireService_ListHires_Handler,
+		},
+		{
+			MethodName: "GetHire",
+			Handler:    _HireService_GetHire_Handler,
+		},
+		{
+			MethodName: "AddHire",
+			Handler:    _HireService_AddHire_Handler,
+		},
+		{
+			MethodName: "UpdateHire",
+			Handler:    _HireService_UpdateHire_Handler,
+		},
+		{
+			MethodName: "DeleteHire",
+			Handler:    _HireService_DeleteHire_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "hire.proto",
+}
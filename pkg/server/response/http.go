@@ -9,6 +9,7 @@ type Object struct {
 	Success bool   `json:"success"`
 	Message string `json:"message,omitempty"`
 	Data    any    `json:"data,omitempty"`
+	Meta    any    `json:"meta,omitempty"`
 }
 
 func OK(w http.ResponseWriter, r *http.Request, data any) {
@@ -21,6 +22,17 @@ func OK(w http.ResponseWriter, r *http.Request, data any) {
 	render.JSON(w, r, v)
 }
 
+func OKWithMeta(w http.ResponseWriter, r *http.Request, data, meta any) {
+	render.Status(r, http.StatusOK)
+
+	v := Object{
+		Success: true,
+		Data:    data,
+		Meta:    meta,
+	}
+	render.JSON(w, r, v)
+}
+
 func BadRequest(w http.ResponseWriter, r *http.Request, err error, data any) {
 	render.Status(r, http.StatusBadRequest)
 
@@ -51,3 +63,73 @@ func InternalServerError(w http.ResponseWriter, r *http.Request, err error) {
 	}
 	render.JSON(w, r, v)
 }
+
+func Unauthorized(w http.ResponseWriter, r *http.Request, err error) {
+	render.Status(r, http.StatusUnauthorized)
+
+	v := Object{
+		Success: false,
+		Message: err.Error(),
+	}
+	render.JSON(w, r, v)
+}
+
+func Forbidden(w http.ResponseWriter, r *http.Request, err error) {
+	render.Status(r, http.StatusForbidden)
+
+	v := Object{
+		Success: false,
+		Message: err.Error(),
+	}
+	render.JSON(w, r, v)
+}
+
+func Conflict(w http.ResponseWriter, r *http.Request, err error) {
+	render.Status(r, http.StatusConflict)
+
+	v := Object{
+		Success: false,
+		Message: err.Error(),
+	}
+	render.JSON(w, r, v)
+}
+
+func PreconditionFailed(w http.ResponseWriter, r *http.Request, err error) {
+	render.Status(r, http.StatusPreconditionFailed)
+
+	v := Object{
+		Success: false,
+		Message: err.Error(),
+	}
+	render.JSON(w, r, v)
+}
+
+func PreconditionRequired(w http.ResponseWriter, r *http.Request, err error) {
+	render.Status(r, http.StatusPreconditionRequired)
+
+	v := Object{
+		Success: false,
+		Message: err.Error(),
+	}
+	render.JSON(w, r, v)
+}
+
+func UnsupportedMediaType(w http.ResponseWriter, r *http.Request, err error) {
+	render.Status(r, http.StatusUnsupportedMediaType)
+
+	v := Object{
+		Success: false,
+		Message: err.Error(),
+	}
+	render.JSON(w, r, v)
+}
+
+func ServiceUnavailable(w http.ResponseWriter, r *http.Request, err error) {
+	render.Status(r, http.StatusServiceUnavailable)
+
+	v := Object{
+		Success: false,
+		Message: err.Error(),
+	}
+	render.JSON(w, r, v)
+}